@@ -0,0 +1,94 @@
+package alias
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/alias"
+	"github.com/obay/hevycli/internal/config"
+)
+
+var (
+	addForce bool
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add <name> <command...>",
+	Short: "Add an alias",
+	Long: `Add an alias or macro.
+
+The expansion can be given as separate words or as a single quoted string.
+Use "--" before the expansion when it contains flags.
+
+Examples:
+  hevycli alias add push routine get 1234
+  hevycli alias add wk -- workout list --since monday
+  hevycli alias add ex 'exercise search $1'
+  hevycli alias add last 'workout list --limit 1 && workout get ${prev.workouts.0.id}'
+  hevycli alias add push --force routine get 5678   # Replace an existing alias`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAliasAdd,
+}
+
+func init() {
+	addCmd.Flags().BoolVarP(&addForce, "force", "f", false, "Replace an existing alias")
+}
+
+func runAliasAdd(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+	if err := alias.ValidateName(name); err != nil {
+		return err
+	}
+	if isCommand(cmd.Root(), name) {
+		return fmt.Errorf("%q is a built-in command and cannot be used as an alias", name)
+	}
+
+	expansion := args[1]
+	if len(args) > 2 {
+		expansion = alias.Join(args[1:])
+	}
+	steps, err := alias.Parse(expansion)
+	if err != nil {
+		return err
+	}
+	for _, step := range steps {
+		if first := step[0]; !strings.HasPrefix(first, "-") && !isCommand(cmd.Root(), first) {
+			fmt.Printf("Note: %q is not a built-in command; it must name another alias\n", first)
+		}
+	}
+
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if existing, ok := cfg.Aliases[name]; ok && !addForce {
+		return fmt.Errorf("alias already exists: %s = %s (use --force to replace it)", name, existing)
+	}
+
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[name] = expansion
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("Added alias %s = %s\n", name, expansion)
+	return nil
+}
+
+// isCommand reports whether name is a top-level command
+func isCommand(root *cobra.Command, name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
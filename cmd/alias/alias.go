@@ -0,0 +1,31 @@
+package alias
+
+import "github.com/spf13/cobra"
+
+// Cmd is the alias command
+var Cmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage command aliases and macros",
+	Long: `Manage shortcuts for frequently used commands.
+
+Aliases are stored in the aliases section of the config file and are
+expanded before the command runs. Extra arguments are appended to the
+expansion, or substituted for $1..$9 and $@ when the alias uses them.
+
+Macros chain several commands with "&&". Each step can reference the JSON
+output of an earlier step with ${prev.path} or ${stepN.path}, where path is
+a dot-separated list of fields and array indexes.
+
+Examples:
+  hevycli alias add push routine get 1234
+  hevycli alias add wk -- workout list --since monday
+  hevycli alias add last 'workout list --limit 1 && workout get ${prev.workouts.0.id}'
+  hevycli alias list
+  hevycli alias remove push`,
+}
+
+func init() {
+	Cmd.AddCommand(addCmd)
+	Cmd.AddCommand(listCmd)
+	Cmd.AddCommand(removeCmd)
+}
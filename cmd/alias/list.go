@@ -0,0 +1,84 @@
+package alias
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List aliases",
+	Long: `List configured aliases and their expansions.
+
+Examples:
+  hevycli alias list
+  hevycli alias list -o json`,
+	RunE: runAliasList,
+}
+
+func init() {
+	schema.Register("alias-list", "Configured aliases", []Info{})
+}
+
+// Info is the JSON representation of an alias
+type Info struct {
+	Name      string `json:"name"`
+	Expansion string `json:"expansion"`
+}
+
+func runAliasList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	aliases := make([]Info, 0, len(cfg.Aliases))
+	for name, expansion := range cfg.Aliases {
+		aliases = append(aliases, Info{Name: name, Expansion: expansion})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(aliases)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	if len(aliases) == 0 {
+		fmt.Println("No aliases configured. Add one with 'hevycli alias add <name> <command...>'")
+		return nil
+	}
+
+	table := output.NewSimpleTable([]string{"Name", "Expansion"})
+	for _, a := range aliases {
+		table.AddRow(a.Name, a.Expansion)
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
@@ -0,0 +1,93 @@
+package alias
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	removeForce bool
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an alias",
+	Long: `Remove an alias from the config file.
+
+By default, you will be prompted to confirm the removal.
+Use --force to skip the confirmation prompt.
+
+Examples:
+  hevycli alias remove push
+  hevycli alias remove push --force`,
+	Args: cmdutil.RequireArgs(1, "<name>"),
+	RunE: runAliasRemove,
+}
+
+func init() {
+	removeCmd.Flags().BoolVarP(&removeForce, "force", "f", false, "Skip confirmation prompt")
+}
+
+func runAliasRemove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = strings.ToLower(strings.TrimSpace(args[0]))
+	} else {
+		names := make([]string, 0, len(cfg.Aliases))
+		for n := range cfg.Aliases {
+			names = append(names, n)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no aliases configured")
+		}
+		sort.Strings(names)
+
+		options := make([]prompt.SelectOption, len(names))
+		for i, n := range names {
+			options[i] = prompt.SelectOption{ID: n, Title: n, Description: cfg.Aliases[n]}
+		}
+		selected, err := prompt.Select("Select alias to remove", options, "Choose an alias...")
+		if err != nil {
+			return err
+		}
+		name = selected.ID
+	}
+
+	expansion, ok := cfg.Aliases[name]
+	if !ok {
+		return fmt.Errorf("alias not found: %s", name)
+	}
+
+	if !removeForce {
+		fmt.Printf("Remove alias %s = %s? [y/N]: ", name, expansion)
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	delete(cfg.Aliases, name)
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("Removed alias %s\n", name)
+	return nil
+}
@@ -14,11 +14,14 @@ by environment variables (HEVYCLI_*) or command-line flags.
 Examples:
   hevycli config init          # Interactive setup
   hevycli config show          # Display current configuration
-  hevycli config set api-key   # Set your API key`,
+  hevycli config set api-key   # Set your API key
+  hevycli config profile list  # List account profiles`,
 }
 
 func init() {
 	Cmd.AddCommand(initCmd)
 	Cmd.AddCommand(showCmd)
 	Cmd.AddCommand(setCmd)
+	Cmd.AddCommand(profileCmd)
+	Cmd.AddCommand(migrateSecretsCmd)
 }
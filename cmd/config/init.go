@@ -2,6 +2,7 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -21,7 +22,9 @@ This command creates the configuration file and prompts for:
 - API key (required, from https://hevy.com/settings?developer)
 - Display preferences (output format, units)
 
-The configuration is saved to ~/.hevycli/config.yaml
+The configuration is saved to ~/.hevycli/config.yaml. The API key is
+stored in the system keyring when available, otherwise in a
+passphrase-encrypted file (~/.hevycli/secrets.age).
 
 Note: Hevy Pro subscription is required for API access.`,
 	RunE: runInit,
@@ -41,7 +44,23 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	// Check if config already exists
 	existingCfg, err := config.Load("")
-	if err == nil && existingCfg.API.Key != "" {
+	if errors.Is(err, config.ErrProfileNotFound) {
+		return err
+	}
+	existingKey := ""
+	if err == nil {
+		// Keep other accounts when re-initializing
+		cfg.CurrentProfile = existingCfg.CurrentProfile
+		cfg.Profiles = existingCfg.Profiles
+		cfg.Aliases = existingCfg.Aliases
+		cfg.ActiveProfile = existingCfg.ActiveProfile
+		cfg.API.KeyBackend = existingCfg.API.KeyBackend
+		existingKey = existingCfg.API.Key
+		if existingCfg.ActiveProfile != "" {
+			existingKey = existingCfg.Profiles[existingCfg.ActiveProfile].APIKey
+		}
+	}
+	if existingKey != "" {
 		fmt.Printf("Existing configuration found at %s\n", config.ConfigPath())
 		fmt.Print("Do you want to overwrite it? [y/N]: ")
 		answer, _ := reader.ReadString('\n')
@@ -73,7 +92,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid API key: %w", err)
 	}
 	fmt.Println("OK")
-	cfg.API.Key = apiKey
+	if err := storeAPIKey(cfg, apiKey); err != nil {
+		return err
+	}
 	fmt.Println()
 
 	// Prompt for units
@@ -87,12 +108,13 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Prompt for default output format
-	fmt.Print("Default output format (json/table/plain) [table]: ")
+	fmt.Print("Default output format (json/table/plain/yaml/ndjson/csv) [table]: ")
 	outputFmt, _ := reader.ReadString('\n')
 	outputFmt = strings.TrimSpace(strings.ToLower(outputFmt))
-	if outputFmt == "json" || outputFmt == "plain" {
+	switch outputFmt {
+	case "json", "plain", "yaml", "ndjson", "csv":
 		cfg.Display.OutputFormat = outputFmt
-	} else {
+	default:
 		cfg.Display.OutputFormat = "table"
 	}
 
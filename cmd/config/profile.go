@@ -0,0 +1,420 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	profileAddKey         string
+	profileAddDescription string
+	profileAddUse         bool
+	profileRemoveForce    bool
+	profileRemoveKeepData bool
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named account profiles",
+	Long: `Manage named profiles, each with its own Hevy API key.
+
+Profiles let you switch between several Hevy accounts, for example when
+coaching multiple clients. Each profile keeps its own undo journal under
+~/.hevycli/profiles/<name>.
+
+The active profile is chosen in this order:
+  1. the --profile flag
+  2. the HEVYCLI_PROFILE environment variable
+  3. the profile selected with 'hevycli config profile use'
+
+The "default" profile refers to the top-level api.key in the config file.
+
+Examples:
+  hevycli config profile add client-a --api-key <key>
+  hevycli config profile list
+  hevycli config profile use client-a
+  hevycli --profile client-b workout list
+  hevycli config profile remove client-a`,
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a profile",
+	Long: `Add a named profile with its own API key.
+
+If --api-key is not given you will be prompted for it.
+
+Examples:
+  hevycli config profile add client-a --api-key <key>
+  hevycli config profile add client-a --use     # Add and switch to it`,
+	Args: cmdutil.RequireArgs(1, "<name>"),
+	RunE: runProfileAdd,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Long: `List configured profiles with masked API keys.
+
+Examples:
+  hevycli config profile list
+  hevycli config profile list -o json`,
+	RunE: runProfileList,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch the current profile",
+	Long: `Make a profile the current one for future commands.
+
+Use "default" to switch back to the top-level API key.
+
+Examples:
+  hevycli config profile use client-a
+  hevycli config profile use default`,
+	Args: cmdutil.RequireArgs(1, "<name>"),
+	RunE: runProfileUse,
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a profile",
+	Long: `Remove a profile and its undo journal.
+
+By default, you will be prompted to confirm the removal.
+Use --force to skip the confirmation prompt.
+
+Examples:
+  hevycli config profile remove client-a
+  hevycli config profile remove client-a --force --keep-data`,
+	Args: cmdutil.RequireArgs(1, "<name>"),
+	RunE: runProfileRemove,
+}
+
+func init() {
+	profileAddCmd.Flags().StringVar(&profileAddKey, "api-key", "", "Hevy API key for the profile")
+	profileAddCmd.Flags().StringVar(&profileAddDescription, "description", "", "Description, e.g. the client's name")
+	profileAddCmd.Flags().BoolVar(&profileAddUse, "use", false, "Switch to the profile after adding it")
+	profileAddCmd.Flags().BoolVar(&validateKey, "validate", true, "Validate the API key before saving")
+
+	profileRemoveCmd.Flags().BoolVarP(&profileRemoveForce, "force", "f", false, "Skip confirmation prompt")
+	profileRemoveCmd.Flags().BoolVar(&profileRemoveKeepData, "keep-data", false, "Keep the profile's undo journal")
+
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileRemoveCmd)
+
+	schema.Register("config-profile-list", "Account profiles", []ProfileInfo{})
+}
+
+// ProfileInfo is the JSON representation of a profile
+type ProfileInfo struct {
+	Name        string `json:"name"`
+	APIKey      string `json:"api_key"`
+	KeyStorage  string `json:"key_storage"`
+	Description string `json:"description,omitempty"`
+	Current     bool   `json:"current"`
+	Active      bool   `json:"active"`
+	DataDir     string `json:"data_dir"`
+}
+
+func runProfileAdd(cmd *cobra.Command, args []string) error {
+	var rawName string
+	if len(args) > 0 {
+		rawName = args[0]
+	} else {
+		var err error
+		rawName, err = prompt.TextInput("Profile name", "e.g., client-a", "enter to confirm")
+		if err != nil {
+			return err
+		}
+	}
+
+	name, err := config.NormalizeProfileName(rawName)
+	if err != nil {
+		return err
+	}
+	if name == config.DefaultProfile {
+		return fmt.Errorf("%q is reserved for the top-level API key; use 'hevycli config set api-key'", name)
+	}
+
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if _, exists := cfg.Profiles[name]; exists {
+		return fmt.Errorf("profile already exists: %s", name)
+	}
+
+	key := profileAddKey
+	if key == "" {
+		key, err = prompt.TextInput("API key for "+name, "Enter the Hevy API key...", "enter to confirm")
+		if err != nil {
+			return err
+		}
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return fmt.Errorf("API key is required")
+	}
+
+	if validateKey {
+		fmt.Print("Validating API key... ")
+		if err := api.NewClient(key).ValidateAuth(); err != nil {
+			fmt.Println("FAILED")
+			return fmt.Errorf("invalid API key: %w", err)
+		}
+		fmt.Println("OK")
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]config.ProfileConfig)
+	}
+	cfg.Profiles[name] = config.ProfileConfig{Description: profileAddDescription}
+	backend, err := cfg.StoreProfileAPIKey(name, key, "")
+	if err != nil {
+		return err
+	}
+	if profileAddUse {
+		cfg.CurrentProfile = name
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("Added profile %s (API key stored in %s)\n", name, backend)
+	if profileAddUse {
+		fmt.Printf("Switched to profile %s\n", name)
+	}
+	return nil
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	current := cfg.CurrentProfile
+	if current == "" {
+		current = config.DefaultProfile
+	}
+	active := config.RequestedProfile()
+	if active == "" {
+		active = current
+	}
+
+	profiles := []ProfileInfo{{
+		Name:        config.DefaultProfile,
+		APIKey:      maskKey(cfg.API.Key),
+		KeyStorage:  cfg.KeyBackendName(config.DefaultProfile),
+		Description: "Top-level api.key",
+		DataDir:     config.ProfileDir(config.DefaultProfile),
+	}}
+	for _, name := range cfg.ProfileNames() {
+		p := cfg.Profiles[name]
+		profiles = append(profiles, ProfileInfo{
+			Name:        name,
+			APIKey:      maskKey(p.APIKey),
+			KeyStorage:  cfg.KeyBackendName(name),
+			Description: p.Description,
+			DataDir:     config.ProfileDir(name),
+		})
+	}
+	for i := range profiles {
+		profiles[i].Current = profiles[i].Name == current
+		profiles[i].Active = profiles[i].Name == active
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(profiles)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	table := output.NewSimpleTable([]string{"", "Name", "API Key", "Storage", "Description"})
+	for _, p := range profiles {
+		marker := ""
+		if p.Active {
+			marker = "*"
+		}
+		table.AddRow(marker, p.Name, p.APIKey, p.KeyStorage, p.Description)
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+
+	if active != current {
+		fmt.Printf("\n* active via --profile/HEVYCLI_PROFILE (current profile is %s)\n", current)
+	}
+	return nil
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var rawName string
+	if len(args) > 0 {
+		rawName = args[0]
+	} else {
+		selected, err := selectProfile(cfg, "Select profile to use", true)
+		if err != nil {
+			return err
+		}
+		rawName = selected
+	}
+
+	name, err := config.NormalizeProfileName(rawName)
+	if err != nil {
+		return err
+	}
+
+	if name == config.DefaultProfile {
+		cfg.CurrentProfile = ""
+	} else {
+		if _, ok := cfg.Profiles[name]; !ok {
+			return fmt.Errorf("profile not found: %s", name)
+		}
+		cfg.CurrentProfile = name
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("Switched to profile %s\n", name)
+	return nil
+}
+
+func runProfileRemove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var rawName string
+	if len(args) > 0 {
+		rawName = args[0]
+	} else {
+		selected, err := selectProfile(cfg, "Select profile to remove", false)
+		if err != nil {
+			return err
+		}
+		rawName = selected
+	}
+
+	name, err := config.NormalizeProfileName(rawName)
+	if err != nil {
+		return err
+	}
+	if name == config.DefaultProfile {
+		return fmt.Errorf("the default profile cannot be removed")
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+
+	if !profileRemoveForce {
+		fmt.Printf("Remove profile %s? [y/N]: ", name)
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	if err := cfg.DeleteProfileSecret(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove stored API key: %v\n", err)
+	}
+	delete(cfg.Profiles, name)
+	if cfg.CurrentProfile == name {
+		cfg.CurrentProfile = ""
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if !profileRemoveKeepData {
+		if err := os.RemoveAll(config.ProfileDir(name)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove profile data: %v\n", err)
+		}
+	}
+
+	fmt.Printf("Removed profile %s\n", name)
+	return nil
+}
+
+// selectProfile interactively picks a profile name
+func selectProfile(cfg *config.Config, title string, includeDefault bool) (string, error) {
+	var options []prompt.SelectOption
+	if includeDefault {
+		options = append(options, prompt.SelectOption{
+			ID:          config.DefaultProfile,
+			Title:       config.DefaultProfile,
+			Description: "Top-level api.key",
+		})
+	}
+	for _, name := range cfg.ProfileNames() {
+		options = append(options, prompt.SelectOption{
+			ID:          name,
+			Title:       name,
+			Description: cfg.Profiles[name].Description,
+		})
+	}
+	if len(options) == 0 {
+		return "", fmt.Errorf("no profiles configured. Run 'hevycli config profile add <name>' to create one")
+	}
+
+	selected, err := prompt.Select(title, options, "Choose a profile...")
+	if err != nil {
+		return "", err
+	}
+	return selected.ID, nil
+}
+
+// maskKey hides all but the last four characters of an API key
+func maskKey(key string) string {
+	if key == "" {
+		return ""
+	}
+	if len(key) > 4 {
+		return "***" + key[len(key)-4:]
+	}
+	return "****"
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/secrets"
+)
+
+var migrateBackend string
+
+var migrateSecretsCmd = &cobra.Command{
+	Use:   "migrate-secrets",
+	Short: "Move plaintext API keys out of the config file",
+	Long: `Move API keys stored in plaintext in config.yaml into a secrets backend.
+
+Backends:
+  auto     System keyring when available, otherwise the encrypted file (default)
+  keyring  System keyring (freedesktop Secret Service over D-Bus on Linux)
+  file     Passphrase-encrypted file (~/.hevycli/secrets.age)
+
+The passphrase for the encrypted file is read from HEVYCLI_PASSPHRASE or
+prompted for on the terminal.
+
+Keys can also be fetched from a password manager by setting api.key_command
+(or api_key_command in a profile), e.g. "pass show hevy".
+
+Examples:
+  hevycli config migrate-secrets                   # Pick a backend automatically
+  hevycli config migrate-secrets --backend file    # Use the encrypted file`,
+	RunE: runMigrateSecrets,
+}
+
+func init() {
+	migrateSecretsCmd.Flags().StringVar(&migrateBackend, "backend", secrets.BackendAuto,
+		"secrets backend: auto, keyring, file")
+}
+
+func runMigrateSecrets(cmd *cobra.Command, args []string) error {
+	backend, err := secrets.ParseBackend(migrateBackend)
+	if err != nil {
+		return err
+	}
+	if backend == secrets.BackendPlain {
+		return fmt.Errorf("cannot migrate to the plain backend")
+	}
+
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	profiles := cfg.PlaintextKeyProfiles()
+	if len(profiles) == 0 {
+		fmt.Println("No plaintext API keys found")
+		return nil
+	}
+
+	for _, profile := range profiles {
+		key := cfg.API.Key
+		if profile != config.DefaultProfile {
+			key = cfg.Profiles[profile].APIKey
+		}
+
+		used, err := cfg.StoreProfileAPIKey(profile, key, backend)
+		if err != nil {
+			return fmt.Errorf("failed to migrate profile %s: %w", profile, err)
+		}
+		if used == secrets.BackendPlain {
+			return fmt.Errorf("no secrets backend available: set %s or run interactively to use the encrypted file", secrets.PassphraseEnv)
+		}
+
+		// Save after every key so a later failure doesn't leave secrets in two places
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("Moved API key for profile %s to %s\n", profile, used)
+	}
+
+	return nil
+}
+
+// storeAPIKey stores the API key of the active profile and reports where it went
+func storeAPIKey(cfg *config.Config, key string) error {
+	backend, err := cfg.StoreAPIKey(key, "")
+	if err != nil {
+		return err
+	}
+
+	switch backend {
+	case secrets.BackendPlain:
+		fmt.Fprintf(os.Stderr, "Warning: no keyring or passphrase available, API key stored in plaintext.\n"+
+			"Run 'hevycli config migrate-secrets' to move it later.\n")
+	case secrets.BackendFile:
+		fmt.Printf("API key stored in %s\n", config.SecretsFile())
+	default:
+		fmt.Println("API key stored in the system keyring")
+	}
+	return nil
+}
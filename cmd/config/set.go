@@ -1,11 +1,14 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/obay/hevycli/internal/analysis"
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
@@ -20,18 +23,28 @@ var setCmd = &cobra.Command{
 	Long: `Set a configuration value.
 
 Available keys:
-  api-key          Your Hevy API key
-  default-output   Default output format (json, table, plain)
+  api-key          Your Hevy API key (stored in the active profile, if any,
+                   using the system keyring or encrypted file when available)
+  default-output   Default output format (json, table, plain, yaml, ndjson, csv)
   units            Measurement units (metric, imperial)
   color            Enable/disable colored output (true, false)
   date-format      Date format (Go format string, e.g., "2006-01-02")
   time-format      Time format (Go format string, e.g., "15:04")
+  timezone         Timezone for date flags (IANA name, e.g., "Europe/Berlin";
+                   empty uses the system timezone)
+  week-start       First day of the week for date flags (monday, sunday, ...)
+  one-rm-formula   1RM estimation formula (brzycki, epley, lombardi,
+                   mayhew, wathan, oconner, rpe)
+  weekly-target    Training days per week to aim for (0 disables)
 
 Examples:
   hevycli config set api-key your-api-key-here
   hevycli config set units imperial
   hevycli config set default-output json
-  hevycli config set color false`,
+  hevycli config set color false
+  hevycli config set one-rm-formula epley
+  hevycli config set week-start sunday
+  hevycli config set weekly-target 4`,
 	Args: cmdutil.RequireArgs(2, "<key> <value>"),
 	RunE: runSet,
 }
@@ -51,11 +64,15 @@ func runSet(cmd *cobra.Command, args []string) error {
 		// Interactive mode - let user select key and enter value
 		keyOptions := []prompt.SelectOption{
 			{ID: "api-key", Title: "API Key", Description: "Your Hevy API key"},
-			{ID: "default-output", Title: "Default Output", Description: "Output format (json, table, plain)"},
+			{ID: "default-output", Title: "Default Output", Description: "Output format (json, table, plain, yaml, ndjson, csv)"},
 			{ID: "units", Title: "Units", Description: "Measurement units (metric, imperial)"},
 			{ID: "color", Title: "Color", Description: "Enable/disable colored output"},
 			{ID: "date-format", Title: "Date Format", Description: "Date format (Go format string)"},
 			{ID: "time-format", Title: "Time Format", Description: "Time format (Go format string)"},
+			{ID: "timezone", Title: "Timezone", Description: "Timezone for date flags (IANA name)"},
+			{ID: "week-start", Title: "Week Start", Description: "First day of the week for date flags"},
+			{ID: "one-rm-formula", Title: "1RM Formula", Description: "Formula used for estimated one-rep max"},
+			{ID: "weekly-target", Title: "Weekly Target", Description: "Training days per week to aim for"},
 		}
 
 		selected, err := prompt.Select("Select configuration key", keyOptions, "Choose a setting to configure...")
@@ -71,6 +88,9 @@ func runSet(cmd *cobra.Command, args []string) error {
 				{ID: "table", Title: "Table", Description: "Formatted table output (default)"},
 				{ID: "json", Title: "JSON", Description: "Raw JSON output"},
 				{ID: "plain", Title: "Plain", Description: "Plain text output"},
+				{ID: "yaml", Title: "YAML", Description: "YAML output"},
+				{ID: "ndjson", Title: "NDJSON", Description: "One JSON object per line"},
+				{ID: "csv", Title: "CSV", Description: "Comma-separated values"},
 			}
 			selectedOutput, err := prompt.Select("Select output format", outputOptions, "Choose a format...")
 			if err != nil {
@@ -100,6 +120,17 @@ func runSet(cmd *cobra.Command, args []string) error {
 			}
 			value = selectedColor.ID
 
+		case "one-rm-formula":
+			var formulaOptions []prompt.SelectOption
+			for _, name := range analysis.FormulaNames() {
+				formulaOptions = append(formulaOptions, prompt.SelectOption{ID: name, Title: name})
+			}
+			selectedFormula, err := prompt.Select("Select 1RM formula", formulaOptions, "Choose a formula...")
+			if err != nil {
+				return err
+			}
+			value = selectedFormula.ID
+
 		default:
 			// Text input for other keys
 			placeholder := "Enter value..."
@@ -109,6 +140,12 @@ func runSet(cmd *cobra.Command, args []string) error {
 				placeholder = "e.g., 2006-01-02"
 			} else if key == "time-format" {
 				placeholder = "e.g., 15:04"
+			} else if key == "weekly-target" {
+				placeholder = "e.g., 4"
+			} else if key == "timezone" {
+				placeholder = "e.g., Europe/Berlin"
+			} else if key == "week-start" {
+				placeholder = "e.g., monday"
 			}
 
 			value, err = prompt.TextInput("Enter "+key, placeholder, "enter to confirm")
@@ -120,7 +157,9 @@ func runSet(cmd *cobra.Command, args []string) error {
 
 	// Load existing config or create default
 	cfg, err := config.Load("")
-	if err != nil {
+	if errors.Is(err, config.ErrProfileNotFound) {
+		return err
+	} else if err != nil {
 		cfg = config.DefaultConfig()
 	}
 
@@ -141,19 +180,23 @@ func runSet(cmd *cobra.Command, args []string) error {
 			}
 			fmt.Println("OK")
 		}
-		cfg.API.Key = value
+		if err := storeAPIKey(cfg, value); err != nil {
+			return err
+		}
 
-	case "default-output", "output-format", "output":
+	case "default-output", "output-format", "output_format", "output":
 		value = strings.ToLower(value)
-		if value != "json" && value != "table" && value != "plain" {
-			return fmt.Errorf("invalid output format: %s (must be json, table, or plain)", value)
+		switch value {
+		case "json", "table", "plain", "yaml", "ndjson", "csv":
+		default:
+			return cmdutil.UsageErrorf("invalid output format: %s (must be json, table, plain, yaml, ndjson, or csv)", value)
 		}
 		cfg.Display.OutputFormat = value
 
 	case "units":
 		value = strings.ToLower(value)
 		if value != "metric" && value != "imperial" {
-			return fmt.Errorf("invalid units: %s (must be metric or imperial)", value)
+			return cmdutil.UsageErrorf("invalid units: %s (must be metric or imperial)", value)
 		}
 		cfg.Display.Units = value
 
@@ -167,11 +210,38 @@ func runSet(cmd *cobra.Command, args []string) error {
 	case "time-format":
 		cfg.Display.TimeFormat = value
 
+	case "timezone", "tz":
+		if _, err := cmdutil.NewDateParser(value, ""); err != nil {
+			return err
+		}
+		cfg.Display.Timezone = value
+
+	case "week-start":
+		day, err := cmdutil.ParseWeekday(value)
+		if err != nil {
+			return err
+		}
+		cfg.Display.WeekStart = strings.ToLower(day.String())
+
 	case "base-url", "baseurl":
 		cfg.API.BaseURL = value
 
+	case "one-rm-formula", "formula":
+		estimator, err := analysis.NewEstimator(value)
+		if err != nil {
+			return err
+		}
+		cfg.Stats.OneRMFormula = string(estimator.Formula())
+
+	case "weekly-target", "target":
+		target, err := strconv.Atoi(value)
+		if err != nil || target < 0 || target > 7 {
+			return cmdutil.UsageErrorf("invalid weekly target: %s (must be a number from 0 to 7)", value)
+		}
+		cfg.Stats.WeeklyTarget = target
+
 	default:
-		return fmt.Errorf("unknown configuration key: %s\n\nAvailable keys: api-key, default-output, units, color, date-format, time-format", key)
+		return fmt.Errorf("unknown configuration key: %s\n\nAvailable keys: api-key, default-output, units, color, date-format, time-format, timezone, week-start, one-rm-formula, weekly-target", key)
 	}
 
 	// Save configuration
@@ -179,6 +249,10 @@ func runSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
+	if cfg.ActiveProfile != "" && (key == "api-key" || key == "apikey" || key == "key") {
+		fmt.Printf("Set %s for profile %s successfully\n", key, cfg.ActiveProfile)
+		return nil
+	}
 	fmt.Printf("Set %s successfully\n", key)
 	return nil
 }
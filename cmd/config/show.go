@@ -16,8 +16,8 @@ var showCmd = &cobra.Command{
 	Short: "Display current configuration",
 	Long: `Display the current hevycli configuration values.
 
-By default, sensitive values like the API key are masked.
-Use --show-secrets to reveal them.`,
+By default, sensitive values like API keys, including those of every
+profile, are masked. Use --show-secrets to reveal them.`,
 	RunE: runShow,
 }
 
@@ -35,13 +35,13 @@ func runShow(cmd *cobra.Command, args []string) error {
 	// Create a copy for display
 	displayCfg := *cfg
 
-	// Mask API key unless --show-secrets is used
-	if !showSecrets && displayCfg.API.Key != "" {
-		keyLen := len(displayCfg.API.Key)
-		if keyLen > 4 {
-			displayCfg.API.Key = "***" + displayCfg.API.Key[keyLen-4:]
-		} else {
-			displayCfg.API.Key = "****"
+	// Mask API keys unless --show-secrets is used
+	if !showSecrets {
+		displayCfg.API.Key = maskKey(displayCfg.API.Key)
+		displayCfg.Profiles = make(map[string]config.ProfileConfig, len(cfg.Profiles))
+		for name, p := range cfg.Profiles {
+			p.APIKey = maskKey(p.APIKey)
+			displayCfg.Profiles[name] = p
 		}
 	}
 
@@ -51,6 +51,7 @@ func runShow(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("# Configuration file: %s\n", config.ConfigPath())
+	fmt.Printf("# Active profile: %s\n", cfg.ProfileName())
 	fmt.Println()
 	fmt.Print(string(out))
 
@@ -8,8 +8,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
@@ -25,7 +27,9 @@ var createCmd = &cobra.Command{
 	Short: "Create a custom exercise",
 	Long: `Create a new custom exercise template.
 
-You can create from a JSON file or using command-line flags.
+You can create from a JSON file or using command-line flags. The type,
+muscle groups and equipment are checked before the exercise is created; the
+display names shown in the Hevy app, like "Upper Back", are accepted too.
 
 Exercise Types:
   weight_reps, reps_only, bodyweight_reps, bodyweight_assisted_reps,
@@ -64,6 +68,11 @@ func init() {
 	createCmd.Flags().StringVar(&exerciseCreateType, "type", "weight_reps", "Exercise type")
 	createCmd.Flags().StringVar(&exerciseCreateMuscle, "muscle", "", "Primary muscle group")
 	createCmd.Flags().StringVar(&exerciseCreateEquipment, "equipment", "none", "Equipment category")
+	createCmd.RegisterFlagCompletionFunc("type", cmdutil.CompleteExerciseTypes)
+	createCmd.RegisterFlagCompletionFunc("muscle", cmdutil.CompleteMuscleGroups)
+	createCmd.RegisterFlagCompletionFunc("equipment", cmdutil.CompleteEquipment)
+
+	schema.Register("exercise-create", "The created custom exercise template", api.ExerciseTemplate{})
 }
 
 func runExerciseCreate(cmd *cobra.Command, args []string) error {
@@ -74,7 +83,7 @@ func runExerciseCreate(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -136,6 +145,11 @@ func runExerciseCreate(cmd *cobra.Command, args []string) error {
 		req.Exercise.EquipmentCategory = api.EquipmentCategory(exerciseCreateEquipment)
 	}
 
+	// Check the values before sending, so a typo fails fast
+	if err := validateCustomExercise(&req.Exercise); err != nil {
+		return err
+	}
+
 	// Create the exercise
 	exercise, err := client.CreateCustomExercise(&req)
 	if err != nil {
@@ -143,7 +157,7 @@ func runExerciseCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(exercise)
 		if err != nil {
 			return err
@@ -160,3 +174,25 @@ func runExerciseCreate(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// validateCustomExercise replaces the type, muscle groups and equipment of
+// an exercise with their parsed values, or returns a usage error for the
+// first unknown one
+func validateCustomExercise(ex *api.CreateCustomExerciseData) error {
+	var err error
+	if ex.ExerciseType, err = api.ParseExerciseType(string(ex.ExerciseType)); err != nil {
+		return cmdutil.UsageErrorf("%v", err)
+	}
+	if ex.MuscleGroup, err = api.ParseMuscleGroup(string(ex.MuscleGroup)); err != nil {
+		return cmdutil.UsageErrorf("%v", err)
+	}
+	if ex.EquipmentCategory, err = api.ParseEquipmentCategory(string(ex.EquipmentCategory)); err != nil {
+		return cmdutil.UsageErrorf("%v", err)
+	}
+	for i, m := range ex.OtherMuscles {
+		if ex.OtherMuscles[i], err = api.ParseMuscleGroup(string(m)); err != nil {
+			return cmdutil.UsageErrorf("other muscles: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package exercise
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/custom"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+)
+
+var (
+	remapForce  bool
+	remapDryRun bool
+)
+
+// RemapResult is the output of exercise custom remap
+type RemapResult struct {
+	From      api.ExerciseTemplate `json:"from"`
+	To        api.ExerciseTemplate `json:"to"`
+	DryRun    bool                 `json:"dry_run"`
+	Remapped  custom.Usage         `json:"remapped"`
+	Operation string               `json:"operation,omitempty"`
+}
+
+var customCmd = &cobra.Command{
+	Use:   "custom",
+	Short: "Manage your custom exercises",
+	Long: `List your custom exercises and move history between exercises.
+
+The API can create custom exercises ('hevycli exercise create' and
+'hevycli exercise import') but cannot edit or delete them. To fix or remove
+one:
+  1. create the corrected exercise, or pick the built-in one to use instead
+  2. move your workouts and routines to it with 'hevycli exercise custom remap'
+  3. delete the old exercise in the Hevy app
+
+'hevycli exercise dedupe' finds custom exercises that duplicate built-in
+ones and remaps them in one go.
+
+Examples:
+  hevycli exercise custom list
+  hevycli exercise custom remap <old-id> <new-id> --dry-run`,
+}
+
+var customListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your custom exercises",
+	Long: `List the exercise templates you created.
+
+Examples:
+  hevycli exercise custom list
+  hevycli exercise custom list -o json`,
+	RunE: runCustomList,
+}
+
+var customRemapCmd = &cobra.Command{
+	Use:   "remap <from-exercise> <to-exercise>",
+	Short: "Move history from one exercise to another",
+	Long: `Replace an exercise with another in every workout and routine.
+
+Both exercises can be given by ID or name. Sets, notes and supersets are kept;
+only the exercise template changes. Both exercises must be of the same type
+unless --force is given, and nothing is changed if any set has a value the
+new exercise cannot record, like a distance moved to a weight and reps
+exercise. The changes can be reverted with 'hevycli undo'. The old exercise itself is not deleted: the API cannot delete
+exercise templates, so remove it in the Hevy app once it is no longer used.
+
+Examples:
+  hevycli exercise custom remap <custom-id> <builtin-id> --dry-run
+  hevycli exercise custom remap "My Bench" "Bench Press (Barbell)" --force`,
+	Args: cmdutil.RequireArgs(2, "<from-exercise> <to-exercise>"),
+	RunE: runCustomRemap,
+}
+
+func init() {
+	customRemapCmd.Flags().BoolVarP(&remapForce, "force", "f", false, "Skip confirmation prompt and allow exercises of different types")
+	customRemapCmd.Flags().BoolVar(&remapDryRun, "dry-run", false, "Show what would change without changing it")
+
+	customCmd.AddCommand(customListCmd)
+	customCmd.AddCommand(customRemapCmd)
+	Cmd.AddCommand(customCmd)
+
+	schema.Register("exercise-custom-list", "Your custom exercise templates", []api.ExerciseTemplate{})
+	schema.Register("exercise-custom-remap", "The workouts and routines moved from one exercise to another", RemapResult{})
+}
+
+func runCustomList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	client := api.NewClient(apiKey)
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	templates, err := client.GetAllExerciseTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to fetch exercises: %w", err)
+	}
+	customs := []api.ExerciseTemplate{}
+	for _, t := range templates {
+		if t.IsCustom {
+			customs = append(customs, t)
+		}
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(customs)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	if len(customs) == 0 {
+		fmt.Println("You have no custom exercises.")
+		return nil
+	}
+
+	table := output.NewSimpleTable([]string{"ID", "Title", "Type", "Primary Muscle", "Equipment"})
+	for _, ex := range customs {
+		table.AddRow(ex.ID, truncateString(ex.Title, 35), string(ex.Type), string(ex.PrimaryMuscleGroup), string(ex.Equipment))
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+
+	if outputFmt == "table" {
+		fmt.Printf("\n%d custom exercise(s)\n", len(customs))
+	}
+	return nil
+}
+
+func runCustomRemap(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+	if len(args) < 2 {
+		return cmdutil.UsageErrorf("both exercises are required")
+	}
+
+	client := api.NewClient(apiKey)
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	from, err := findTemplate(client, args[0])
+	if err != nil {
+		return err
+	}
+	to, err := findTemplate(client, args[1])
+	if err != nil {
+		return err
+	}
+	if from.ID == to.ID {
+		return cmdutil.UsageErrorf("%q and %q are the same exercise", args[0], args[1])
+	}
+	if from.Type != to.Type && !remapForce {
+		return cmdutil.UsageErrorf("'%s' is a %s exercise but '%s' is a %s exercise: use --force to remap anyway",
+			from.Title, from.Type.DisplayName(), to.Title, to.Type.DisplayName())
+	}
+
+	workouts, routines, err := fetchHistory(client)
+	if err != nil {
+		return err
+	}
+	usage := custom.FindUsage(from.ID, workouts, routines)
+	if err := custom.CheckRemap(from.ID, *to, workouts, routines); err != nil {
+		return cmdutil.UsageErrorf("%v", err)
+	}
+
+	result := RemapResult{From: *from, To: *to, DryRun: remapDryRun, Remapped: usage}
+	var remapErr error
+	if !remapDryRun && !usage.Empty() {
+		if !remapForce {
+			if !cmdutil.IsInteractive() {
+				return cmdutil.UsageErrorf("refusing to change %s without confirmation: use --force", describeUsage(usage))
+			}
+			ok, err := confirm(fmt.Sprintf("Replace '%s' with '%s' in %s?", from.Title, to.Title, describeUsage(usage)))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(os.Stderr, "Cancelled.")
+				return nil
+			}
+		}
+
+		rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+		result.Remapped, remapErr = custom.Remap(client, rec, *from, *to, workouts, routines)
+		if !result.Remapped.Empty() {
+			result.Operation = rec.ID()
+			if remapErr != nil {
+				remapErr = fmt.Errorf("%w; undo the changes made so far with 'hevycli undo %s'", remapErr, result.Operation)
+			}
+		}
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return remapErr
+	}
+
+	switch {
+	case usage.Empty():
+		fmt.Printf("'%s' is not used in any workout or routine.\n", from.Title)
+	case remapDryRun:
+		fmt.Printf("Dry run: '%s' would be replaced with '%s' in %s.\n", from.Title, to.Title, describeUsage(usage))
+	default:
+		fmt.Printf("Replaced '%s' with '%s' in %s.\n", from.Title, to.Title, describeUsage(result.Remapped))
+		if result.Operation != "" {
+			fmt.Printf("Undo with 'hevycli undo %s'.\n", result.Operation)
+		}
+	}
+	if remapErr != nil {
+		return remapErr
+	}
+	if from.IsCustom && !remapDryRun {
+		fmt.Printf("Delete '%s' in the Hevy app to remove it; the API cannot delete exercises.\n", from.Title)
+	}
+	return nil
+}
+
+// fetchHistory fetches all workouts and routines
+func fetchHistory(client *api.Client) ([]api.Workout, []api.Routine, error) {
+	fmt.Fprintln(os.Stderr, "Fetching workout data...")
+	workouts, err := client.GetAllWorkouts()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch workouts: %w", err)
+	}
+	routines, err := client.GetAllRoutines()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch routines: %w", err)
+	}
+	return workouts, routines, nil
+}
+
+// describeUsage renders usage like "3 workouts and 1 routine"
+func describeUsage(u custom.Usage) string {
+	return plural(len(u.Workouts), "workout") + " and " + plural(len(u.Routines), "routine")
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// stdin is shared by the questions of a command so none of the answers are
+// lost in a buffer
+var stdin = bufio.NewReader(os.Stdin)
+
+// confirm asks a yes/no question on stderr and reads the answer from stdin
+func confirm(question string) (bool, error) {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, err := stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,208 @@
+package exercise
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/custom"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+)
+
+var (
+	dedupeMinScore float64
+	dedupeRemap    bool
+	dedupeForce    bool
+)
+
+// DedupeEntry is a duplicate custom exercise with where it is used
+type DedupeEntry struct {
+	custom.Duplicate
+	Usage custom.Usage `json:"usage"`
+	// Remapped is where the custom exercise was replaced, with --remap
+	Remapped *custom.Usage `json:"remapped,omitempty"`
+}
+
+// DedupeResult is the output of exercise dedupe
+type DedupeResult struct {
+	Duplicates []DedupeEntry `json:"duplicates"`
+	Operation  string        `json:"operation,omitempty"`
+}
+
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Find custom exercises that duplicate built-in ones",
+	Long: `Find custom exercises that duplicate a built-in exercise.
+
+A custom exercise is a duplicate when a built-in exercise of the same type
+and primary muscle group has a similar title, ignoring word order, punctuation, plurals
+and shorthand like "DB" or "RDL". --min-score sets how similar the titles must
+be, from 0 to 1.
+
+With --remap every workout and routine using a duplicate is changed to use the
+built-in exercise instead; you are asked about each duplicate unless --force
+is given. Every chosen duplicate is checked before anything is changed, and
+the changes can be reverted with 'hevycli undo'. The API cannot
+delete exercises, so delete the remapped custom exercises in the Hevy app.
+
+Examples:
+  hevycli exercise dedupe
+  hevycli exercise dedupe --min-score 0.6
+  hevycli exercise dedupe --remap`,
+	RunE: runDedupe,
+}
+
+func init() {
+	dedupeCmd.Flags().Float64Var(&dedupeMinScore, "min-score", custom.DefaultMinScore, "Minimum title similarity from 0 to 1")
+	dedupeCmd.Flags().BoolVar(&dedupeRemap, "remap", false, "Move workouts and routines to the built-in exercises")
+	dedupeCmd.Flags().BoolVarP(&dedupeForce, "force", "f", false, "Remap every duplicate without asking")
+	Cmd.AddCommand(dedupeCmd)
+
+	schema.Register("exercise-dedupe", "Custom exercises that duplicate built-in exercises", DedupeResult{})
+}
+
+func runDedupe(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+	if dedupeMinScore <= 0 || dedupeMinScore > 1 {
+		return cmdutil.UsageErrorf("--min-score must be above 0 and at most 1")
+	}
+	if dedupeRemap && !dedupeForce && !cmdutil.IsInteractive() {
+		return cmdutil.UsageErrorf("refusing to remap without confirmation: use --force")
+	}
+
+	client := api.NewClient(apiKey)
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	templates, err := client.GetAllExerciseTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to fetch exercises: %w", err)
+	}
+	duplicates := custom.FindDuplicates(templates, dedupeMinScore)
+
+	var workouts []api.Workout
+	var routines []api.Routine
+	if len(duplicates) > 0 {
+		workouts, routines, err = fetchHistory(client)
+		if err != nil {
+			return err
+		}
+	}
+
+	result := DedupeResult{Duplicates: make([]DedupeEntry, len(duplicates))}
+	for i, d := range duplicates {
+		result.Duplicates[i] = DedupeEntry{Duplicate: d, Usage: custom.FindUsage(d.Custom.ID, workouts, routines)}
+	}
+
+	var remapErr error
+	if dedupeRemap {
+		var chosen []*DedupeEntry
+		for i := range result.Duplicates {
+			e := &result.Duplicates[i]
+			if e.Usage.Empty() {
+				continue
+			}
+			if !dedupeForce {
+				ok, err := confirm(fmt.Sprintf("Replace '%s' with '%s' in %s?", e.Custom.Title, e.Builtin.Title, describeUsage(e.Usage)))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+			}
+			if err := custom.CheckRemap(e.Custom.ID, e.Builtin, workouts, routines); err != nil {
+				return cmdutil.UsageErrorf("%v", err)
+			}
+			chosen = append(chosen, e)
+		}
+
+		rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+		for _, e := range chosen {
+			done, err := custom.Remap(client, rec, e.Custom, e.Builtin, workouts, routines)
+			e.Remapped = &done
+			if !done.Empty() {
+				result.Operation = rec.ID()
+			}
+			if err != nil {
+				remapErr = err
+				if result.Operation != "" {
+					remapErr = fmt.Errorf("%w; undo the changes made so far with 'hevycli undo %s'", err, result.Operation)
+				}
+				break
+			}
+		}
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return remapErr
+	}
+
+	if len(result.Duplicates) == 0 {
+		fmt.Println("No custom exercise duplicates a built-in exercise.")
+		return nil
+	}
+
+	table := output.NewSimpleTable([]string{"Custom", "Built-in", "Muscle", "Score", "Workouts", "Routines", "Remapped"})
+	for _, e := range result.Duplicates {
+		remapped := ""
+		if e.Remapped != nil && !e.Remapped.Empty() {
+			remapped = "yes"
+		}
+		table.AddRow(
+			truncateString(e.Custom.Title, 30),
+			truncateString(e.Builtin.Title, 30),
+			string(e.Custom.PrimaryMuscleGroup),
+			fmt.Sprintf("%.2f", e.Score),
+			fmt.Sprintf("%d", len(e.Usage.Workouts)),
+			fmt.Sprintf("%d", len(e.Usage.Routines)),
+			remapped,
+		)
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+
+	if outputFmt == "table" {
+		fmt.Printf("\n%d duplicate(s) found\n", len(result.Duplicates))
+		if result.Operation != "" {
+			fmt.Printf("Undo with 'hevycli undo %s'.\n", result.Operation)
+		}
+		if !dedupeRemap {
+			fmt.Println("Move your history to the built-in exercises with --remap.")
+		}
+		fmt.Println("The API cannot delete exercises: delete the duplicates in the Hevy app.")
+	}
+	return remapErr
+}
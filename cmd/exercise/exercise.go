@@ -11,8 +11,12 @@ var Cmd = &cobra.Command{
 Examples:
   hevycli exercise list             # List exercise templates
   hevycli exercise get <id>         # Get exercise details
+  hevycli exercise show "bench" --history  # Exercise with your history
   hevycli exercise search "bench"   # Search for exercises
   hevycli exercise create --title "My Exercise" --muscle chest --type weight_reps
+  hevycli exercise import exercises.yaml   # Create many custom exercises
+  hevycli exercise custom list      # List your custom exercises
+  hevycli exercise dedupe           # Find duplicates of built-in exercises
   hevycli exercise interactive      # Interactive exercise browser`,
 }
 
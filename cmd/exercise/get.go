@@ -3,7 +3,6 @@ package exercise
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
@@ -26,6 +26,10 @@ Examples:
 	RunE: runGet,
 }
 
+func init() {
+	schema.Register("exercise-get", "An exercise template", api.ExerciseTemplate{})
+}
+
 func runGet(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load("")
 	if err != nil {
@@ -34,7 +38,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -67,7 +71,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 					options[i] = prompt.SelectOption{
 						ID:          ex.ID,
 						Title:       ex.Title,
-						Description: ex.PrimaryMuscleGroup + " • " + ex.Equipment,
+						Description: ex.Summary(),
 					}
 				}
 				return options, nil
@@ -120,7 +124,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 		Writer:  os.Stdout,
 	})
 
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(exercise)
 		if err != nil {
 			return err
@@ -136,18 +140,18 @@ func runGet(cmd *cobra.Command, args []string) error {
 func printExerciseDetails(ex *api.ExerciseTemplate, cfg *config.Config) {
 	fmt.Printf("Exercise: %s\n", ex.Title)
 	fmt.Printf("ID: %s\n", ex.ID)
-	fmt.Printf("Primary Muscle: %s\n", ex.PrimaryMuscleGroup)
+	fmt.Printf("Primary Muscle: %s\n", ex.PrimaryMuscleGroup.DisplayName())
 
 	if len(ex.SecondaryMuscleGroups) > 0 {
-		fmt.Printf("Secondary Muscles: %s\n", strings.Join(ex.SecondaryMuscleGroups, ", "))
+		fmt.Printf("Secondary Muscles: %s\n", ex.SecondaryMuscles())
 	}
 
 	if ex.Equipment != "" {
-		fmt.Printf("Equipment: %s\n", ex.Equipment)
+		fmt.Printf("Equipment: %s\n", ex.Equipment.DisplayName())
 	}
 
 	if ex.Type != "" {
-		fmt.Printf("Type: %s\n", ex.Type)
+		fmt.Printf("Type: %s\n", ex.Type.DisplayName())
 	}
 
 	if ex.IsCustom {
@@ -0,0 +1,204 @@
+package exercise
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/custom"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+)
+
+var importDryRun bool
+
+// Import statuses
+const (
+	importCreated = "created"
+	importExists  = "exists"
+	importPlanned = "planned"
+	importFailed  = "failed"
+)
+
+// ImportedExercise is the outcome for one exercise of an import file
+type ImportedExercise struct {
+	Line   int    `json:"line"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportResult is the output of exercise import
+type ImportResult struct {
+	DryRun    bool               `json:"dry_run"`
+	Created   int                `json:"created"`
+	Existing  int                `json:"existing"`
+	Failed    int                `json:"failed"`
+	Exercises []ImportedExercise `json:"exercises"`
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file.yaml|file.csv>",
+	Short: "Create many custom exercises from a file",
+	Long: `Create custom exercises from a YAML or CSV file.
+
+Every entry is checked before anything is created: the type, muscle and
+equipment must be values listed in 'hevycli exercise create --help'. Type
+defaults to weight_reps and equipment to none. Entries whose title matches
+an existing custom exercise are skipped, so an import can be re-run after
+fixing a failure.
+
+YAML file format:
+  - title: Cable Fly
+    type: weight_reps
+    muscle: chest
+    equipment: machine
+    other_muscles: [shoulders, triceps]
+  - title: Plank Hold
+    type: duration
+    muscle: abdominals
+
+CSV file format (other muscles separated by semicolons):
+  title,type,muscle,equipment,other_muscles
+  Cable Fly,weight_reps,chest,machine,shoulders;triceps
+
+Custom exercises cannot be edited or deleted through the API. To replace
+one, import the corrected exercise, move your history to it with
+'hevycli exercise custom remap' and delete the old one in the Hevy app.
+
+Examples:
+  hevycli exercise import exercises.yaml --dry-run
+  hevycli exercise import exercises.csv -o json`,
+	Args: cmdutil.RequireArgs(1, "<file>"),
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Validate the file and show what would be created")
+	Cmd.AddCommand(importCmd)
+
+	schema.Register("exercise-import", "The outcome for every exercise of an import file", ImportResult{})
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	if len(args) == 0 {
+		return cmdutil.UsageErrorf("an import file is required")
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	entries, err := custom.Parse(args[0], data)
+	if err != nil {
+		return cmdutil.UsageErrorf("%v", err)
+	}
+	if len(entries) == 0 {
+		return cmdutil.UsageErrorf("%s has no exercises", args[0])
+	}
+
+	client := api.NewClient(apiKey)
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	templates, err := client.GetAllExerciseTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to fetch exercises: %w", err)
+	}
+	planned, err := custom.Plan(entries, templates)
+	var verr *custom.ValidationError
+	if errors.As(err, &verr) {
+		return cmdutil.UsageErrorf("%v", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	result := ImportResult{DryRun: importDryRun, Exercises: make([]ImportedExercise, len(planned))}
+	for i, p := range planned {
+		ex := ImportedExercise{Line: p.Entry.Line, Title: p.Request.Title}
+		switch {
+		case p.Existing != nil:
+			ex.Status = importExists
+			ex.ID = p.Existing.ID
+			result.Existing++
+		case importDryRun:
+			ex.Status = importPlanned
+		default:
+			// One at a time, so a failure leaves a clear point to re-run from
+			created, err := client.CreateCustomExercise(&api.CreateCustomExerciseRequest{Exercise: p.Request})
+			if err != nil {
+				ex.Status = importFailed
+				ex.Error = err.Error()
+				result.Failed++
+			} else {
+				ex.Status = importCreated
+				ex.ID = created.ID
+				result.Created++
+			}
+		}
+		result.Exercises[i] = ex
+	}
+
+	var importErr error
+	if result.Failed > 0 {
+		importErr = fmt.Errorf("%d of %d exercises failed to import", result.Failed, len(planned))
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return importErr
+	}
+
+	table := output.NewSimpleTable([]string{"Line", "Title", "Status", "ID"})
+	for _, ex := range result.Exercises {
+		status := ex.Status
+		if ex.Error != "" {
+			status += ": " + ex.Error
+		}
+		table.AddRow(fmt.Sprintf("%d", ex.Line), truncateString(ex.Title, 35), status, ex.ID)
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+
+	if outputFmt == "table" {
+		if importDryRun {
+			fmt.Printf("\nDry run: %d exercise(s) would be created, %d already exist.\n", len(planned)-result.Existing, result.Existing)
+		} else {
+			fmt.Printf("\nCreated %d, skipped %d existing, %d failed.\n", result.Created, result.Existing, result.Failed)
+		}
+	}
+	return importErr
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	tuiExercise "github.com/obay/hevycli/internal/tui/exercise"
 )
@@ -37,7 +38,7 @@ func runInteractive(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -6,10 +6,12 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/obay/hevycli/internal/analysis"
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	tuiExercise "github.com/obay/hevycli/internal/tui/exercise"
 )
 
@@ -35,6 +37,8 @@ func init() {
 	listCmd.Flags().IntVar(&listPage, "page", 1, "Page number for pagination")
 	listCmd.Flags().IntVar(&listLimit, "limit", 10, "Number of exercises to fetch")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "Fetch all exercises (warning: may be slow)")
+
+	schema.Register("exercise-list", "Exercise templates", []api.ExerciseTemplate{})
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -45,7 +49,7 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -61,13 +65,21 @@ func runList(cmd *cobra.Command, args []string) error {
 	// - Not explicitly requesting JSON output
 	// - Not using pagination flags (--page, --limit, --all)
 	useInteractive := cmdutil.IsInteractive() &&
-		outputFmt != "json" &&
+		!output.IsStructured(outputFmt) &&
 		!cmd.Flags().Changed("page") &&
 		!cmd.Flags().Changed("limit") &&
 		!cmd.Flags().Changed("all")
 
 	if useInteractive {
-		result, err := tuiExercise.RunTable(client)
+		estimator, err := analysis.NewEstimator(cfg.Stats.OneRMFormula)
+		if err != nil {
+			return err
+		}
+		result, err := tuiExercise.RunTable(client, tuiExercise.DetailOptions{
+			Estimator: estimator,
+			Imperial:  cfg.Display.Units == "imperial",
+			Recent:    5,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to run interactive table: %w", err)
 		}
@@ -80,15 +92,19 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Non-interactive mode: use traditional table output
-	formatter := output.NewFormatter(output.Options{
+	opts := output.Options{
 		Format:  output.FormatType(outputFmt),
 		NoColor: !cfg.Display.Color,
 		Writer:  os.Stdout,
-	})
+	}
+	formatter := output.NewFormatter(opts)
 
 	var allExercises []api.ExerciseTemplate
 
 	if listAll {
+		// ndjson and csv write each page as it arrives
+		stream, streaming := output.NewStream(opts)
+
 		// Fetch all exercises with pagination
 		page := 1
 		for {
@@ -96,7 +112,15 @@ func runList(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return fmt.Errorf("failed to fetch exercises: %w", err)
 			}
-			allExercises = append(allExercises, resp.ExerciseTemplates...)
+			if streaming {
+				for _, ex := range resp.ExerciseTemplates {
+					if err := stream.Write(ex); err != nil {
+						return err
+					}
+				}
+			} else {
+				allExercises = append(allExercises, resp.ExerciseTemplates...)
+			}
 
 			if page >= resp.PageCount || resp.PageCount == 0 {
 				break
@@ -104,11 +128,14 @@ func runList(cmd *cobra.Command, args []string) error {
 			page++
 
 			// Progress indicator
-			if !cmd.Flags().Changed("output") || outputFmt == "table" {
+			if !output.IsStructured(outputFmt) {
 				fmt.Printf("\rFetching exercises... page %d/%d", page, resp.PageCount)
 			}
 		}
-		if !cmd.Flags().Changed("output") || outputFmt == "table" {
+		if streaming {
+			return stream.Flush()
+		}
+		if !output.IsStructured(outputFmt) {
 			fmt.Print("\r                                    \r")
 		}
 	} else {
@@ -125,7 +152,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		result := map[string]interface{}{
 			"exercise_templates": allExercises,
 			"count":              len(allExercises),
@@ -147,8 +174,8 @@ func runList(cmd *cobra.Command, args []string) error {
 			table.AddRow(
 				ex.ID,
 				truncateString(ex.Title, 30),
-				ex.PrimaryMuscleGroup,
-				ex.Equipment,
+				string(ex.PrimaryMuscleGroup),
+				string(ex.Equipment),
 				custom,
 			)
 		}
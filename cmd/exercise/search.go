@@ -11,6 +11,7 @@ import (
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
@@ -25,6 +26,10 @@ var searchCmd = &cobra.Command{
 	Short: "Search exercise templates",
 	Long: `Search for exercises by name, muscle group, or equipment.
 
+--muscle matches the primary or a secondary muscle group. Muscle groups and
+equipment are the values listed in 'hevycli exercise create --help'; an
+unknown value is an error.
+
 Examples:
   hevycli exercise search "bench"                    # Search by name
   hevycli exercise search "press" --muscle chest     # Filter by muscle
@@ -38,15 +43,23 @@ func init() {
 	searchCmd.Flags().StringVar(&searchMuscle, "muscle", "", "Filter by muscle group")
 	searchCmd.Flags().StringVar(&searchEquipment, "equipment", "", "Filter by equipment type")
 	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "Maximum number of results")
+	searchCmd.RegisterFlagCompletionFunc("muscle", cmdutil.CompleteMuscleGroups)
+	searchCmd.RegisterFlagCompletionFunc("equipment", cmdutil.CompleteEquipment)
+
+	schema.Register("exercise-search", "Exercise templates matching a search; meta.query holds the search", []api.ExerciseTemplate{})
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
+	filter, err := searchFilter()
+	if err != nil {
+		return err
+	}
+
 	var query string
 	if len(args) > 0 {
 		query = strings.ToLower(args[0])
 	} else {
 		// Interactive mode - prompt for search query
-		var err error
 		query, err = prompt.TextInput(
 			"Search Exercises",
 			"Enter search term...",
@@ -65,7 +78,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -93,7 +106,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		}
 
 		for _, ex := range resp.ExerciseTemplates {
-			if matchesSearch(ex, query, searchMuscle, searchEquipment) {
+			if matchesSearch(ex, query, filter) {
 				results = append(results, ex)
 				if len(results) >= searchLimit {
 					break
@@ -117,7 +130,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		result := map[string]interface{}{
 			"exercise_templates": results,
 			"count":              len(results),
@@ -140,8 +153,8 @@ func runSearch(cmd *cobra.Command, args []string) error {
 			table.AddRow(
 				ex.ID,
 				truncateString(ex.Title, 35),
-				ex.PrimaryMuscleGroup,
-				ex.Equipment,
+				string(ex.PrimaryMuscleGroup),
+				string(ex.Equipment),
 			)
 		}
 
@@ -159,34 +172,27 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func matchesSearch(ex api.ExerciseTemplate, query, muscle, equipment string) bool {
+func matchesSearch(ex api.ExerciseTemplate, query string, filter api.TemplateFilter) bool {
 	// Check name match
 	if query != "" && !strings.Contains(strings.ToLower(ex.Title), query) {
 		return false
 	}
+	return filter.Match(ex)
+}
 
-	// Check muscle filter
-	if muscle != "" {
-		muscle = strings.ToLower(muscle)
-		primaryMatch := strings.Contains(strings.ToLower(ex.PrimaryMuscleGroup), muscle)
-		secondaryMatch := false
-		for _, m := range ex.SecondaryMuscleGroups {
-			if strings.Contains(strings.ToLower(m), muscle) {
-				secondaryMatch = true
-				break
-			}
-		}
-		if !primaryMatch && !secondaryMatch {
-			return false
+// searchFilter parses the --muscle and --equipment flags
+func searchFilter() (api.TemplateFilter, error) {
+	var filter api.TemplateFilter
+	var err error
+	if searchMuscle != "" {
+		if filter.Muscle, err = api.ParseMuscleGroup(searchMuscle); err != nil {
+			return filter, cmdutil.UsageErrorf("invalid --muscle: %v", err)
 		}
 	}
-
-	// Check equipment filter
-	if equipment != "" {
-		if !strings.Contains(strings.ToLower(ex.Equipment), strings.ToLower(equipment)) {
-			return false
+	if searchEquipment != "" {
+		if filter.Equipment, err = api.ParseEquipmentCategory(searchEquipment); err != nil {
+			return filter, cmdutil.UsageErrorf("invalid --equipment: %v", err)
 		}
 	}
-
-	return true
+	return filter, nil
 }
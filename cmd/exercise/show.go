@@ -0,0 +1,268 @@
+package exercise
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/notation"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	showHistory bool
+	showRecent  int
+	showFormula string
+)
+
+// ShowResult is the output of exercise show
+type ShowResult struct {
+	Exercise api.ExerciseTemplate       `json:"exercise"`
+	History  *analysis.ExerciseInsights `json:"history,omitempty"`
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <exercise-id|name>",
+	Short: "Show an exercise with your history for it",
+	Long: `Show an exercise template, found by ID or by name.
+
+With --history the template is combined with your workouts: how often and
+when you did the exercise, your typical rep range, a rep-max table, the trend
+of your estimated 1RM, your latest sessions and the routines that include it.
+
+Examples:
+  hevycli exercise show "bench press" --history
+  hevycli exercise show D04AC939 --history --recent 10
+  hevycli exercise show "squat" --history -o json`,
+	Args: cmdutil.RequireArgs(1, "<exercise-id|name>"),
+	RunE: runShow,
+}
+
+func init() {
+	showCmd.Flags().BoolVar(&showHistory, "history", false, "Include your history for the exercise")
+	showCmd.Flags().IntVar(&showRecent, "recent", 5, "Number of recent sessions to show with --history")
+	showCmd.Flags().StringVar(&showFormula, "formula", "",
+		"1RM formula: "+strings.Join(analysis.FormulaNames(), ", ")+" (default from config)")
+	Cmd.AddCommand(showCmd)
+
+	schema.Register("exercise-show", "An exercise template with your history for it", ShowResult{})
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+	if showRecent < 0 {
+		return cmdutil.UsageErrorf("--recent cannot be negative")
+	}
+
+	// Resolve the 1RM estimator (flag > config > default)
+	formula := cfg.Stats.OneRMFormula
+	if cmd.Flags().Changed("formula") {
+		formula = showFormula
+	}
+	estimator, err := analysis.NewEstimator(formula)
+	if err != nil {
+		return cmdutil.UsageErrorf("%v", err)
+	}
+
+	client := api.NewClient(apiKey)
+
+	var query string
+	if len(args) > 0 {
+		query = args[0]
+	} else {
+		// Interactive mode - let user search and select an exercise
+		selected, err := prompt.SearchSelect(prompt.SearchSelectConfig{
+			Title:       "Select an Exercise",
+			Placeholder: "Search exercises...",
+			Help:        "Type to filter by exercise name",
+			LoadFunc: func() ([]prompt.SelectOption, error) {
+				templates, err := client.GetAllExerciseTemplates()
+				if err != nil {
+					return nil, err
+				}
+				options := make([]prompt.SelectOption, len(templates))
+				for i, ex := range templates {
+					options[i] = prompt.SelectOption{
+						ID:          ex.ID,
+						Title:       ex.Title,
+						Description: ex.Summary(),
+					}
+				}
+				return options, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		query = selected.ID
+	}
+
+	template, err := findTemplate(client, query)
+	if err != nil {
+		return err
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	result := ShowResult{Exercise: *template}
+	if showHistory {
+		fmt.Fprintln(os.Stderr, "Fetching workout data...")
+		workouts, err := client.GetAllWorkouts()
+		if err != nil {
+			return fmt.Errorf("failed to fetch workouts: %w", err)
+		}
+		routines, err := client.GetAllRoutines()
+		if err != nil {
+			return fmt.Errorf("failed to fetch routines: %w", err)
+		}
+		result.History = analysis.BuildExerciseInsights(template.ID, template.Title, workouts, routines, estimator, showRecent)
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	printExerciseDetails(template, cfg)
+	if result.History != nil {
+		printInsights(os.Stdout, result.History, cfg.Display.Units == "imperial")
+	}
+	return nil
+}
+
+// findTemplate finds an exercise template by ID or name. When several
+// templates match a name the user chooses one in interactive mode;
+// otherwise the best match is used.
+func findTemplate(client *api.Client, query string) (*api.ExerciseTemplate, error) {
+	if !strings.ContainsAny(query, " \t") {
+		template, err := client.GetExerciseTemplate(query)
+		// The endpoint may answer without full data, like exercise get notes
+		if err == nil && template.ID != "" {
+			return template, nil
+		}
+		// Anything but "no such ID" is a real failure
+		if apiErr, ok := api.AsAPIError(err); err != nil && !errors.Is(err, api.ErrNotFound) && (!ok || apiErr.ErrorCode != "VALIDATION_ERROR") {
+			return nil, fmt.Errorf("failed to fetch exercise: %w", err)
+		}
+	}
+
+	templates, err := client.GetAllExerciseTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exercises: %w", err)
+	}
+	matches := notation.Match(query, templates)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no exercise matches %q (try 'hevycli exercise search %s')", query, query)
+	}
+	choice := matches[0]
+	if len(matches) > 1 && cmdutil.IsInteractive() {
+		options := make([]prompt.SelectOption, len(matches))
+		for i, t := range matches {
+			options[i] = prompt.SelectOption{ID: t.ID, Title: t.Title, Description: t.Summary()}
+		}
+		selected, err := prompt.Select(fmt.Sprintf("Which exercise is %q?", query), options, "Choose an exercise...")
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range matches {
+			if t.ID == selected.ID {
+				choice = t
+			}
+		}
+	}
+	return &choice, nil
+}
+
+// printInsights writes the history part of exercise show
+func printInsights(w io.Writer, ins *analysis.ExerciseInsights, imperial bool) {
+	fmt.Fprintf(w, "\n📊 History\n")
+	if ins.TimesPerformed == 0 {
+		fmt.Fprintln(w, "   You have not done this exercise yet.")
+	} else {
+		fmt.Fprintf(w, "   Performed:     %d times (%d sets)\n", ins.TimesPerformed, ins.TotalSets)
+		fmt.Fprintf(w, "   First:         %s\n", ins.FirstPerformed)
+		fmt.Fprintf(w, "   Last:          %s\n", ins.LastPerformed)
+		if r := ins.TypicalReps; r != nil {
+			fmt.Fprintf(w, "   Typical reps:  %s (most often %d)\n", repRange(r.Low, r.High), r.MostCommon)
+		}
+	}
+
+	if n := len(ins.E1RMTrend); n > 0 {
+		values := make([]float64, n)
+		for i, p := range ins.E1RMTrend {
+			values[i] = p.Value
+		}
+		// Keep the sparkline readable on narrow terminals
+		if len(values) > 40 {
+			values = values[len(values)-40:]
+		}
+		fmt.Fprintf(w, "\n📈 Estimated 1RM (%s)\n", ins.Formula)
+		fmt.Fprintf(w, "   %s  %s → %s\n", analysis.Sparkline(values),
+			analysis.FormatWeight(values[0], imperial), analysis.FormatWeight(values[len(values)-1], imperial))
+	}
+
+	if len(ins.RepMaxes) > 0 {
+		fmt.Fprintf(w, "\n🏆 Rep Maxes\n")
+		for _, r := range ins.RepMaxes {
+			fmt.Fprintf(w, "   %2dRM  %-12s %s\n", r.Reps, analysis.FormatWeight(r.Value, imperial), r.Date)
+		}
+	}
+
+	if len(ins.RecentSessions) > 0 {
+		fmt.Fprintf(w, "\n🕑 Recent Sessions\n")
+		for _, s := range ins.RecentSessions {
+			fmt.Fprintf(w, "   %s  %s\n", s.Date, s.WorkoutTitle)
+			for i, set := range s.Sets {
+				fmt.Fprintf(w, "      %2d  %-8s %s\n", i+1, set.SetType, analysis.FormatSet(set, imperial))
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n📋 Routines\n")
+	if len(ins.Routines) == 0 {
+		fmt.Fprintln(w, "   Not in any routine.")
+	}
+	for _, r := range ins.Routines {
+		fmt.Fprintf(w, "   %s (%s)\n", r.Title, r.ID)
+	}
+}
+
+// repRange renders a range of reps, e.g. "5–8" or "5"
+func repRange(low, high int) string {
+	if low == high {
+		return fmt.Sprintf("%d", low)
+	}
+	return fmt.Sprintf("%d–%d", low, high)
+}
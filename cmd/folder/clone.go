@@ -0,0 +1,188 @@
+package folder
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/clone"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/organize"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	cloneTitle  string
+	cloneSuffix string
+	cloneFlags  clone.Flags
+)
+
+// CloneResult is the output of folder clone
+type CloneResult struct {
+	Folder    api.RoutineFolder `json:"folder"`
+	Routines  []api.Routine     `json:"routines"`
+	Operation string            `json:"operation,omitempty"`
+}
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone [folder-id]",
+	Short: "Copy a folder with all of its routines",
+	Long: `Create a new folder holding a copy of every routine in a folder.
+
+The suffix is added to the folder title and to every routine title. The
+routine copies keep every exercise, set target, rest time, note and
+superset, and can be changed on the way with the same options as
+'hevycli routine clone'. The whole copy can be removed with 'hevycli undo'.
+
+Examples:
+  hevycli folder clone <id> --suffix " (Block 2)"
+  hevycli folder clone <id> --suffix " (Block 2)" --weight-percent 2.5 --round 2.5
+  hevycli folder clone <id> --title "Deload" --suffix "" --weight-percent -40`,
+	Args: cmdutil.RequireArgs(1, "<folder-id>"),
+	RunE: runFolderClone,
+}
+
+func init() {
+	cloneCmd.Flags().StringVar(&cloneTitle, "title", "", "Title of the new folder (default: folder title plus suffix)")
+	cloneCmd.Flags().StringVar(&cloneSuffix, "suffix", " (copy)", "Text added to the folder and routine titles")
+	cloneFlags.Register(cloneCmd.Flags())
+	Cmd.AddCommand(cloneCmd)
+
+	schema.Register("folder-clone", "The created folder and routine copies", CloneResult{})
+}
+
+func runFolderClone(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	transform, err := cloneFlags.Transform()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiKey)
+
+	var folderID string
+	if len(args) > 0 {
+		folderID = args[0]
+	} else {
+		// Interactive mode - let user select from folders
+		selected, err := prompt.SearchSelect(prompt.SearchSelectConfig{
+			Title:       "Select Folder to Clone",
+			Placeholder: "Search folders...",
+			Help:        "Type to filter by folder title",
+			LoadFunc: func() ([]prompt.SelectOption, error) {
+				folders, err := client.GetAllRoutineFolders()
+				if err != nil {
+					return nil, err
+				}
+				options := make([]prompt.SelectOption, len(folders))
+				for i, f := range folders {
+					options[i] = prompt.SelectOption{
+						ID:          f.ID,
+						Title:       f.Title,
+						Description: fmt.Sprintf("Index: %d", f.Index),
+					}
+				}
+				return options, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		folderID = selected.ID
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	source, err := client.GetRoutineFolder(folderID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch folder: %w", err)
+	}
+	routines, err := client.GetAllRoutines()
+	if err != nil {
+		return fmt.Errorf("failed to fetch routines: %w", err)
+	}
+
+	title := cloneTitle
+	if title == "" {
+		title = source.Title + cloneSuffix
+	}
+	if title == source.Title {
+		return cmdutil.UsageErrorf("the copy needs a different title: use --title or a non-empty --suffix")
+	}
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	folder, err := client.CreateRoutineFolder(&api.CreateRoutineFolderRequest{
+		RoutineFolder: api.CreateRoutineFolderData{Title: title},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+	change, err := journal.Snapshot(journal.ActionCreate, folder)
+	if err != nil {
+		return err
+	}
+	if err := rec.Record(change); err != nil {
+		return fmt.Errorf("failed to record undo journal: %w", err)
+	}
+	newID, err := strconv.Atoi(folder.ID)
+	if err != nil {
+		return fmt.Errorf("invalid folder ID %q", folder.ID)
+	}
+
+	result := CloneResult{Folder: *folder, Routines: []api.Routine{}, Operation: rec.ID()}
+	var cloneErr error
+	for i := range routines {
+		r := &routines[i]
+		if !organize.InFolder(r, source.ID) {
+			continue
+		}
+		created, err := clone.Routine(client, rec, r, r.Title+cloneSuffix, &newID, transform)
+		if err != nil {
+			cloneErr = fmt.Errorf("failed to clone routine %q: %w", r.Title, err)
+			break
+		}
+		result.Routines = append(result.Routines, *created)
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return cloneErr
+	}
+
+	fmt.Printf("Folder '%s' cloned to '%s' (ID: %s)\n", source.Title, folder.Title, folder.ID)
+	for _, r := range result.Routines {
+		fmt.Printf("✓ %s (%s)\n", r.Title, r.ID)
+	}
+	fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
+	return cloneErr
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
@@ -27,6 +28,8 @@ Examples:
 
 func init() {
 	Cmd.AddCommand(createCmd)
+
+	schema.Register("folder-create", "The created routine folder", api.RoutineFolder{})
 }
 
 func runFolderCreate(cmd *cobra.Command, args []string) error {
@@ -56,7 +59,7 @@ func runFolderCreate(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -86,7 +89,7 @@ func runFolderCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(folder)
 		if err != nil {
 			return err
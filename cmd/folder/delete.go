@@ -9,19 +9,27 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/bulk"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
 var (
-	deleteForce bool
+	deleteForce       bool
+	deleteMatch       string
+	deleteDryRun      bool
+	deleteConcurrency int
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete <folder-id>",
+	Use:   "delete [folder-id]",
 	Short: "Delete a routine folder",
-	Long: `Delete a routine folder by ID.
+	Long: `Delete a routine folder by ID, or every folder whose title matches
+a glob pattern with --match (* matches any text).
 
 By default, you will be prompted to confirm the deletion.
 Use --force to skip the confirmation prompt.
@@ -31,14 +39,28 @@ The routines will become unorganized (no folder).
 
 Examples:
   hevycli folder delete <id>           # Delete with confirmation
-  hevycli folder delete <id> --force   # Delete without confirmation`,
-	Args: cmdutil.RequireArgs(1, "<folder-id>"),
+  hevycli folder delete <id> --force   # Delete without confirmation
+  hevycli folder delete --match "Old*" --dry-run   # Preview a bulk delete`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if deleteMatch != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("a folder ID cannot be combined with --match")
+			}
+			return nil
+		}
+		return cmdutil.RequireArgs(1, "<folder-id>")(cmd, args)
+	},
 	RunE: runFolderDelete,
 }
 
 func init() {
 	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "Skip confirmation prompt")
+	deleteCmd.Flags().StringVar(&deleteMatch, "match", "", "Delete folders whose title matches a glob pattern")
+	deleteCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "Show the selected folders without deleting them")
+	deleteCmd.Flags().IntVar(&deleteConcurrency, "concurrency", bulk.DefaultConcurrency, "Number of deletions to run at once")
 	Cmd.AddCommand(deleteCmd)
+
+	schema.Register("folder-delete", "Per-folder results of a bulk delete", bulk.Report{})
 }
 
 func runFolderDelete(cmd *cobra.Command, args []string) error {
@@ -49,11 +71,15 @@ func runFolderDelete(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
 
+	if deleteMatch != "" {
+		return runBulkFolderDelete(cmd, cfg, client)
+	}
+
 	var folderID string
 	if len(args) > 0 {
 		folderID = args[0]
@@ -64,12 +90,12 @@ func runFolderDelete(cmd *cobra.Command, args []string) error {
 			Placeholder: "Search folders...",
 			Help:        "Type to filter by folder title",
 			LoadFunc: func() ([]prompt.SelectOption, error) {
-				folders, err := client.GetRoutineFolders(1, 20)
+				folders, err := client.GetAllRoutineFolders()
 				if err != nil {
 					return nil, err
 				}
-				options := make([]prompt.SelectOption, len(folders.RoutineFolders))
-				for i, f := range folders.RoutineFolders {
+				options := make([]prompt.SelectOption, len(folders))
+				for i, f := range folders {
 					options[i] = prompt.SelectOption{
 						ID:          f.ID,
 						Title:       f.Title,
@@ -94,7 +120,7 @@ func runFolderDelete(cmd *cobra.Command, args []string) error {
 	// Confirm deletion unless --force is used
 	if !deleteForce {
 		fmt.Printf("Are you sure you want to delete folder '%s' (%s)?\n", folder.Title, folder.ID)
-		fmt.Printf("This action cannot be undone. Routines inside will become unorganized.\n")
+		fmt.Printf("Routines inside will become unorganized, and undo will not move them back.\n")
 		fmt.Print("Type 'yes' to confirm: ")
 
 		reader := bufio.NewReader(os.Stdin)
@@ -111,10 +137,76 @@ func runFolderDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Delete the folder
-	if err := client.DeleteRoutineFolder(folderID); err != nil {
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	err = rec.Track(journal.ActionDelete, folder, func() error {
+		return client.DeleteRoutineFolder(folderID)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete folder: %w", err)
 	}
 
 	fmt.Printf("Folder '%s' deleted successfully.\n", folder.Title)
+	fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
 	return nil
 }
+
+// runBulkFolderDelete deletes every folder whose title matches --match
+func runBulkFolderDelete(cmd *cobra.Command, cfg *config.Config, client *api.Client) error {
+	if deleteConcurrency < 1 {
+		return cmdutil.UsageErrorf("--concurrency must be at least 1")
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	folders, err := client.GetAllRoutineFolders()
+	if err != nil {
+		return fmt.Errorf("failed to fetch folders: %w", err)
+	}
+
+	var items []bulk.Item
+	for _, f := range folders {
+		if !bulk.MatchTitle(deleteMatch, f.Title) {
+			continue
+		}
+		items = append(items, bulk.Item{
+			ID:     f.ID,
+			Title:  f.Title,
+			Detail: fmt.Sprintf("%d", f.Index),
+		})
+	}
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	return bulk.Execute(bulk.Options{
+		Action:       "delete",
+		Done:         "deleted",
+		Noun:         "folders",
+		DetailHeader: "Index",
+		Items:        items,
+		Concurrency:  deleteConcurrency,
+		DryRun:       deleteDryRun,
+		Force:        deleteForce,
+		OutputFormat: outputFmt,
+		Formatter:    formatter,
+		NoColor:      !cfg.Display.Color,
+		Operation:    rec.ID(),
+		Do: func(item bulk.Item) (string, error) {
+			folder, err := client.GetRoutineFolder(item.ID)
+			if err != nil {
+				return "", err
+			}
+			return "", rec.Track(journal.ActionDelete, folder, func() error {
+				return client.DeleteRoutineFolder(item.ID)
+			})
+		},
+	})
+}
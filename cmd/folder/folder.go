@@ -10,10 +10,14 @@ var Cmd = &cobra.Command{
 
 Examples:
   hevycli folder list             # List all folders
-  hevycli folder get <id>         # Get folder details
+  hevycli folder list --tree      # Folders with their routines
+  hevycli folder get <id>         # Get folder details and routines
   hevycli folder create "Name"    # Create new folder
   hevycli folder update <id> --title "New Name"  # Update folder
-  hevycli folder delete <id>      # Delete folder`,
+  hevycli folder delete <id>      # Delete folder
+  hevycli folder tree             # Rearrange folders and routines
+  hevycli folder reorder <id>...  # Put folders first in this order
+  hevycli folder clone <id> --suffix " (Block 2)"  # Copy folder and routines`,
 }
 
 func init() {
@@ -9,14 +9,17 @@ import (
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/organize"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
 var getCmd = &cobra.Command{
 	Use:   "get <folder-id>",
 	Short: "Get folder details",
-	Long: `Get detailed information about a specific routine folder.
+	Long: `Get detailed information about a specific routine folder and the
+routines in it.
 
 Examples:
   hevycli folder get abc123-def456    # Get folder by ID
@@ -25,6 +28,16 @@ Examples:
 	RunE: runGet,
 }
 
+// FolderDetails is a folder with the routines in it
+type FolderDetails struct {
+	api.RoutineFolder
+	Routines []RoutineSummary `json:"routines"`
+}
+
+func init() {
+	schema.Register("folder-get", "A routine folder with its routines", FolderDetails{})
+}
+
 func runGet(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load("")
 	if err != nil {
@@ -33,7 +46,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -48,12 +61,12 @@ func runGet(cmd *cobra.Command, args []string) error {
 			Placeholder: "Search folders...",
 			Help:        "Type to filter by folder title",
 			LoadFunc: func() ([]prompt.SelectOption, error) {
-				folders, err := client.GetRoutineFolders(1, 20)
+				folders, err := client.GetAllRoutineFolders()
 				if err != nil {
 					return nil, err
 				}
-				options := make([]prompt.SelectOption, len(folders.RoutineFolders))
-				for i, f := range folders.RoutineFolders {
+				options := make([]prompt.SelectOption, len(folders))
+				for i, f := range folders {
 					options[i] = prompt.SelectOption{
 						ID:          f.ID,
 						Title:       f.Title,
@@ -96,6 +109,18 @@ func runGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("folder not found: %s", folderID)
 	}
 
+	routines, err := client.GetAllRoutines()
+	if err != nil {
+		return fmt.Errorf("failed to fetch routines: %w", err)
+	}
+	var inFolder []api.Routine
+	for i := range routines {
+		if organize.InFolder(&routines[i], folder.ID) {
+			inFolder = append(inFolder, routines[i])
+		}
+	}
+	details := FolderDetails{RoutineFolder: *folder, Routines: summarize(inFolder, false)}
+
 	// Determine output format
 	outputFmt := cfg.Display.OutputFormat
 	if cmd.Flags().Changed("output") {
@@ -108,23 +133,41 @@ func runGet(cmd *cobra.Command, args []string) error {
 		Writer:  os.Stdout,
 	})
 
-	if outputFmt == "json" {
-		out, err := formatter.Format(folder)
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(details)
 		if err != nil {
 			return err
 		}
 		fmt.Println(out)
 	} else {
-		printFolderDetails(folder, cfg)
+		if err := printFolderDetails(&details, cfg, formatter); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func printFolderDetails(f *api.RoutineFolder, cfg *config.Config) {
+func printFolderDetails(f *FolderDetails, cfg *config.Config, formatter output.Formatter) error {
 	fmt.Printf("Folder: %s\n", f.Title)
 	fmt.Printf("ID: %s\n", f.ID)
 	fmt.Printf("Index: %d\n", f.Index)
 	fmt.Printf("Created: %s\n", f.CreatedAt.Format(cfg.Display.DateFormat))
 	fmt.Printf("Updated: %s\n", f.UpdatedAt.Format(cfg.Display.DateFormat))
+
+	fmt.Printf("\nRoutines (%d):\n", len(f.Routines))
+	if len(f.Routines) == 0 {
+		fmt.Println("  No routines in this folder.")
+		return nil
+	}
+	table := output.NewSimpleTable([]string{"ID", "Title", "Exercises"})
+	for _, r := range f.Routines {
+		table.AddRow(r.ID, r.Title, fmt.Sprintf("%d", r.ExerciseCount))
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
 }
@@ -7,14 +7,18 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
 	listPage  int
 	listLimit int
 	listAll   bool
+	listTree  bool
+	listDepth int
 )
 
 var listCmd = &cobra.Command{
@@ -22,9 +26,15 @@ var listCmd = &cobra.Command{
 	Short: "List routine folders",
 	Long: `List your Hevy routine folders.
 
+With --tree, every folder is shown with the routines in it. --depth sets how
+far the tree goes: 1 for folders only, 2 for routines (the default) and 3 for
+the exercises of each routine.
+
 Examples:
   hevycli folder list           # List folders
-  hevycli folder list -o json   # Output as JSON`,
+  hevycli folder list -o json   # Output as JSON
+  hevycli folder list --tree    # Folders with their routines
+  hevycli folder list --tree --depth 3 -o plain`,
 	RunE: runList,
 }
 
@@ -32,6 +42,11 @@ func init() {
 	listCmd.Flags().IntVar(&listPage, "page", 1, "Page number for pagination")
 	listCmd.Flags().IntVar(&listLimit, "limit", 10, "Number of folders to fetch")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "Fetch all folders")
+	listCmd.Flags().BoolVar(&listTree, "tree", false, "Show all folders with their routines")
+	listCmd.Flags().IntVar(&listDepth, "depth", depthRoutines, "Tree depth: 1 folders, 2 routines, 3 exercises")
+
+	schema.Register("folder-list", "Routine folders", []api.RoutineFolder{})
+	schema.Register("folder-list-tree", "Routine folders with their routines (--tree)", Tree{})
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -42,7 +57,11 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
+	}
+
+	if listDepth < depthFolders || listDepth > depthExercises {
+		return cmdutil.UsageErrorf("--depth must be 1, 2 or 3")
 	}
 
 	client := api.NewClient(apiKey)
@@ -59,6 +78,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		Writer:  os.Stdout,
 	})
 
+	if listTree {
+		return runListTree(client, outputFmt, formatter)
+	}
+
 	var allFolders []api.RoutineFolder
 
 	if listAll {
@@ -89,7 +112,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		result := map[string]interface{}{
 			"routine_folders": allFolders,
 			"count":           len(allFolders),
@@ -130,3 +153,31 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runListTree prints every folder with its routines
+func runListTree(client *api.Client, outputFmt string, formatter output.Formatter) error {
+	_, layout, err := loadLayout(client)
+	if err != nil {
+		return err
+	}
+	tree := buildTree(layout, listDepth)
+	output.SetSchemaName("folder-list-tree")
+
+	switch {
+	case output.IsStructured(outputFmt):
+		out, err := formatter.Format(tree)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+	case outputFmt == "table":
+		printTree(os.Stdout, layout, listDepth)
+	default:
+		out, err := formatter.Format(treeTable(tree, listDepth))
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+	}
+	return nil
+}
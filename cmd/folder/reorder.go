@@ -0,0 +1,168 @@
+package folder
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/organize"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+)
+
+var (
+	reorderTo     int
+	reorderDryRun bool
+)
+
+var reorderCmd = &cobra.Command{
+	Use:   "reorder [folder-id...]",
+	Short: "Change the order of routine folders",
+	Long: `Change the order of routine folders.
+
+The listed folders are placed first, in the given order, followed by the
+other folders in their current order. With --to, a single folder is moved to
+a position (1 is the top). Without arguments in a terminal, the interactive
+folder tree is opened (see 'hevycli folder tree').
+
+The change can be reverted with 'hevycli undo'.
+
+Examples:
+  hevycli folder reorder <id3> <id1> <id2>   # Set the order
+  hevycli folder reorder <id> --to 1         # Move a folder to the top
+  hevycli folder reorder <id> --to 3 --dry-run
+  hevycli folder reorder                     # Rearrange interactively`,
+	RunE: runFolderReorder,
+}
+
+func init() {
+	reorderCmd.Flags().IntVar(&reorderTo, "to", 0, "Move a single folder to this position (1 is the top)")
+	reorderCmd.Flags().BoolVar(&reorderDryRun, "dry-run", false, "Show the new order without saving it")
+	Cmd.AddCommand(reorderCmd)
+
+	schema.Register("folder-reorder", "Routine folders in their new order", []api.RoutineFolder{})
+}
+
+func runFolderReorder(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("to") && len(args) != 1 {
+		return cmdutil.UsageErrorf("--to takes exactly one folder ID")
+	}
+	if len(args) == 0 && !cmdutil.IsInteractive() {
+		return cmdutil.UsageErrorf("requires folder IDs in the new order")
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	client := api.NewClient(apiKey)
+
+	if len(args) == 0 {
+		folders, layout, err := loadLayout(client)
+		if err != nil {
+			return err
+		}
+		return editTree(cmd, cfg, client, folders, layout)
+	}
+
+	folders, err := client.GetAllRoutineFolders()
+	if err != nil {
+		return fmt.Errorf("failed to fetch folders: %w", err)
+	}
+
+	ids := args
+	if cmd.Flags().Changed("to") {
+		ids, err = positionOrder(folders, args[0], reorderTo)
+		if err != nil {
+			return err
+		}
+	}
+	ordered, err := organize.Order(folders, ids)
+	if err != nil {
+		return cmdutil.UsageErrorf("%v", err)
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	result := ordered
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	if !reorderDryRun {
+		result, err = organize.ApplyOrder(client, rec, folders, ordered)
+		if err != nil {
+			return err
+		}
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	table := output.NewSimpleTable([]string{"#", "ID", "Title"})
+	for i, f := range result {
+		table.AddRow(fmt.Sprintf("%d", i+1), f.ID, f.Title)
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	if reorderDryRun {
+		fmt.Println("Dry run: the order was not saved.")
+	} else {
+		fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
+	}
+	return nil
+}
+
+// positionOrder returns the folder IDs in their current order with id moved
+// to a 1-based position
+func positionOrder(folders []api.RoutineFolder, id string, position int) ([]string, error) {
+	current, err := organize.Order(folders, nil)
+	if err != nil {
+		return nil, err
+	}
+	if position < 1 || position > len(current) {
+		return nil, cmdutil.UsageErrorf("--to must be between 1 and %d", len(current))
+	}
+
+	ids := make([]string, 0, len(current))
+	found := false
+	for _, f := range current {
+		if f.ID == id {
+			found = true
+			continue
+		}
+		ids = append(ids, f.ID)
+	}
+	if !found {
+		return nil, cmdutil.UsageErrorf("folder %s not found", id)
+	}
+	ids = append(ids[:position-1], append([]string{id}, ids[position-1:]...)...)
+	return ids, nil
+}
@@ -0,0 +1,305 @@
+package folder
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/organize"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	tuiFolder "github.com/obay/hevycli/internal/tui/folder"
+)
+
+var treePrint bool
+
+var treeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show folders with their routines and rearrange them",
+	Long: `Show routine folders with the routines in each of them.
+
+In a terminal the tree is interactive: press space to pick up a folder or
+routine, move it with the arrow keys and press space again to drop it.
+Folders are reordered and routines move between folders. Press enter to
+save the changes or esc to discard them.
+
+Routines that the API cannot move in place are copied into the new folder
+and the original deleted. All changes can be reverted with 'hevycli undo'.
+
+Examples:
+  hevycli folder tree            # Interactive tree
+  hevycli folder tree --print    # Print the tree
+  hevycli folder tree -o json`,
+	RunE: runFolderTree,
+}
+
+func init() {
+	treeCmd.Flags().BoolVar(&treePrint, "print", false, "Print the tree instead of opening the interactive view")
+	Cmd.AddCommand(treeCmd)
+
+	schema.Register("folder-tree", "Routine folders with their routines", organize.Layout{})
+}
+
+func runFolderTree(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	client := api.NewClient(apiKey)
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	folders, layout, err := loadLayout(client)
+	if err != nil {
+		return err
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(layout)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	if treePrint || !cmdutil.IsInteractive() {
+		printTree(os.Stdout, layout, depthRoutines)
+		return nil
+	}
+
+	return editTree(cmd, cfg, client, folders, layout)
+}
+
+// loadLayout fetches every folder and routine
+func loadLayout(client *api.Client) ([]api.RoutineFolder, *organize.Layout, error) {
+	folders, err := client.GetAllRoutineFolders()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch folders: %w", err)
+	}
+	routines, err := client.GetAllRoutines()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch routines: %w", err)
+	}
+	return folders, organize.NewLayout(folders, routines), nil
+}
+
+// RoutineSummary is a routine in folder listings
+type RoutineSummary struct {
+	ID            string            `json:"id"`
+	Title         string            `json:"title"`
+	ExerciseCount int               `json:"exercise_count"`
+	Exercises     []ExerciseSummary `json:"exercises,omitempty"`
+}
+
+// ExerciseSummary is an exercise of a routine in folder listings
+type ExerciseSummary struct {
+	ExerciseTemplateID string `json:"exercise_template_id"`
+	Title              string `json:"title"`
+	Sets               int    `json:"sets"`
+}
+
+// TreeFolder is a folder with its routines
+type TreeFolder struct {
+	ID       string           `json:"id"`
+	Title    string           `json:"title"`
+	Index    int              `json:"index"`
+	Routines []RoutineSummary `json:"routines,omitempty"`
+}
+
+// Tree is the output of 'folder list --tree'
+type Tree struct {
+	Folders []TreeFolder     `json:"folders"`
+	Unfiled []RoutineSummary `json:"unfiled,omitempty"`
+}
+
+// Tree depths: folders only, with routines, with routine exercises
+const (
+	depthFolders   = 1
+	depthRoutines  = 2
+	depthExercises = 3
+)
+
+// summarize returns routine summaries, with exercises if withExercises is set
+func summarize(routines []api.Routine, withExercises bool) []RoutineSummary {
+	summaries := make([]RoutineSummary, len(routines))
+	for i, r := range routines {
+		s := RoutineSummary{ID: r.ID, Title: r.Title, ExerciseCount: len(r.Exercises)}
+		if withExercises {
+			s.Exercises = make([]ExerciseSummary, len(r.Exercises))
+			for j, e := range r.Exercises {
+				s.Exercises[j] = ExerciseSummary{
+					ExerciseTemplateID: e.ExerciseTemplateID,
+					Title:              e.Title,
+					Sets:               len(e.Sets),
+				}
+			}
+		}
+		summaries[i] = s
+	}
+	return summaries
+}
+
+// buildTree converts a layout into a tree limited to depth levels
+func buildTree(layout *organize.Layout, depth int) Tree {
+	tree := Tree{Folders: make([]TreeFolder, len(layout.Folders))}
+	for i, f := range layout.Folders {
+		tree.Folders[i] = TreeFolder{ID: f.Folder.ID, Title: f.Folder.Title, Index: f.Folder.Index}
+		if depth >= depthRoutines {
+			tree.Folders[i].Routines = summarize(f.Routines, depth >= depthExercises)
+		}
+	}
+	if depth >= depthRoutines {
+		tree.Unfiled = summarize(layout.Unfiled, depth >= depthExercises)
+	}
+	return tree
+}
+
+// printTree writes the folders and their routines as an indented tree,
+// going depth levels deep
+func printTree(w io.Writer, layout *organize.Layout, depth int) {
+	tree := buildTree(layout, depth)
+	printGroup := func(title string, count int, routines []RoutineSummary) {
+		fmt.Fprintf(w, "%s (%d)\n", title, count)
+		for i, r := range routines {
+			branch, indent := "├──", "│   "
+			if i == len(routines)-1 {
+				branch, indent = "└──", "    "
+			}
+			fmt.Fprintf(w, "%s %s (%s) · %d exercises\n", branch, r.Title, r.ID, r.ExerciseCount)
+			for j, e := range r.Exercises {
+				leaf := "├──"
+				if j == len(r.Exercises)-1 {
+					leaf = "└──"
+				}
+				fmt.Fprintf(w, "%s%s %s × %d sets\n", indent, leaf, e.Title, e.Sets)
+			}
+		}
+	}
+	for i, f := range tree.Folders {
+		printGroup(fmt.Sprintf("%s [%s]", f.Title, f.ID), len(layout.Folders[i].Routines), f.Routines)
+	}
+	if depth >= depthRoutines && len(tree.Unfiled) > 0 {
+		printGroup("No folder", len(tree.Unfiled), tree.Unfiled)
+	}
+	if len(layout.Folders) == 0 && len(layout.Unfiled) == 0 {
+		fmt.Fprintln(w, "No folders or routines found.")
+	}
+}
+
+// treeTable flattens a tree into one row per folder, routine or exercise
+// for plain output
+func treeTable(tree Tree, depth int) *output.SimpleTable {
+	if depth == depthFolders {
+		table := output.NewSimpleTable([]string{"ID", "Title", "Index"})
+		for _, f := range tree.Folders {
+			table.AddRow(f.ID, f.Title, fmt.Sprintf("%d", f.Index))
+		}
+		return table
+	}
+
+	headers := []string{"Folder ID", "Folder", "Routine ID", "Routine", "Exercises"}
+	if depth >= depthExercises {
+		headers = append(headers, "Exercise", "Sets")
+	}
+	table := output.NewSimpleTable(headers)
+	addRoutines := func(folderID, folder string, routines []RoutineSummary) {
+		if len(routines) == 0 {
+			row := []string{folderID, folder, "-", "-", "0"}
+			if depth >= depthExercises {
+				row = append(row, "-", "0")
+			}
+			table.AddRow(row...)
+		}
+		for _, r := range routines {
+			row := []string{folderID, folder, r.ID, r.Title, fmt.Sprintf("%d", r.ExerciseCount)}
+			if depth < depthExercises {
+				table.AddRow(row...)
+				continue
+			}
+			if len(r.Exercises) == 0 {
+				table.AddRow(append(row, "-", "0")...)
+			}
+			for _, e := range r.Exercises {
+				table.AddRow(append(row, e.Title, fmt.Sprintf("%d", e.Sets))...)
+			}
+		}
+	}
+	for _, f := range tree.Folders {
+		addRoutines(f.ID, f.Title, f.Routines)
+	}
+	if len(tree.Unfiled) > 0 {
+		addRoutines("-", "No folder", tree.Unfiled)
+	}
+	return table
+}
+
+// editTree opens the interactive tree and applies the saved changes
+func editTree(cmd *cobra.Command, cfg *config.Config, client *api.Client, folders []api.RoutineFolder, layout *organize.Layout) error {
+	plan, err := tuiFolder.RunTree(layout)
+	if err != nil {
+		return err
+	}
+	if plan.Empty() {
+		fmt.Println("No changes.")
+		return nil
+	}
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	if plan.Order != nil {
+		ordered, err := organize.Order(folders, plan.Order)
+		if err != nil {
+			return err
+		}
+		if _, err := organize.ApplyOrder(client, rec, folders, ordered); err != nil {
+			return err
+		}
+		fmt.Println("✓ Folders reordered")
+	}
+
+	changed := plan.Order != nil
+	failed := 0
+	for _, m := range plan.Moves {
+		moved, method, err := organize.MoveRoutine(client, rec, &m.Routine, m.Folder.ID)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Printf("✗ %s: %v\n", m.Routine.Title, err)
+		case method == organize.MethodCopied:
+			changed = true
+			fmt.Printf("✓ %s → %s (copied, new ID %s)\n", m.Routine.Title, m.Folder.Title, moved.ID)
+		default:
+			changed = true
+			fmt.Printf("✓ %s → %s\n", m.Routine.Title, m.Folder.Title)
+		}
+	}
+	if changed {
+		fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d routines could not be moved", failed, len(plan.Moves))
+	}
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
@@ -33,6 +35,8 @@ func init() {
 	updateCmd.Flags().StringVar(&updateTitle, "title", "", "New folder title")
 	updateCmd.MarkFlagRequired("title")
 	Cmd.AddCommand(updateCmd)
+
+	schema.Register("folder-update", "The updated routine folder", api.RoutineFolder{})
 }
 
 func runFolderUpdate(cmd *cobra.Command, args []string) error {
@@ -43,7 +47,7 @@ func runFolderUpdate(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -58,12 +62,12 @@ func runFolderUpdate(cmd *cobra.Command, args []string) error {
 			Placeholder: "Search folders...",
 			Help:        "Type to filter by folder title",
 			LoadFunc: func() ([]prompt.SelectOption, error) {
-				folders, err := client.GetRoutineFolders(1, 20)
+				folders, err := client.GetAllRoutineFolders()
 				if err != nil {
 					return nil, err
 				}
-				options := make([]prompt.SelectOption, len(folders.RoutineFolders))
-				for i, f := range folders.RoutineFolders {
+				options := make([]prompt.SelectOption, len(folders))
+				for i, f := range folders {
 					options[i] = prompt.SelectOption{
 						ID:          f.ID,
 						Title:       f.Title,
@@ -98,13 +102,24 @@ func runFolderUpdate(cmd *cobra.Command, args []string) error {
 		},
 	}
 
-	folder, err := client.UpdateRoutineFolder(folderID, req)
+	// Snapshot the current folder so the update can be undone
+	current, err := client.GetRoutineFolder(folderID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch folder: %w", err)
+	}
+
+	var folder *api.RoutineFolder
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	err = rec.Track(journal.ActionUpdate, current, func() error {
+		folder, err = client.UpdateRoutineFolder(folderID, req)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update folder: %w", err)
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(folder)
 		if err != nil {
 			return err
@@ -114,6 +129,7 @@ func runFolderUpdate(cmd *cobra.Command, args []string) error {
 		fmt.Println("Folder updated successfully!")
 		fmt.Printf("ID: %s\n", folder.ID)
 		fmt.Printf("Title: %s\n", folder.Title)
+		fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
 	}
 
 	return nil
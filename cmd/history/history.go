@@ -0,0 +1,187 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+)
+
+var historyLimit int
+
+// Cmd is the history command
+var Cmd = &cobra.Command{
+	Use:   "history [op-id]",
+	Short: "List recorded changes that can be undone",
+	Long: `List the operations recorded in the undo journal.
+
+Every delete, update, edit and move command saves the objects it changes
+before changing them. Each operation can be reverted with 'hevycli undo <op-id>'.
+With an operation ID, the objects changed by that operation are listed.
+
+The journal is kept locally per profile; the newest 500 operations are kept.
+
+Examples:
+  hevycli history                   # Recent operations
+  hevycli history --limit 0         # All operations
+  hevycli history 20261018-170102   # Objects changed by one operation
+  hevycli history -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	Cmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Number of operations to show (0 for all)")
+
+	schema.Register("history", "Operations recorded in the undo journal", []Entry{})
+}
+
+// Entry is the JSON representation of a journal operation
+type Entry struct {
+	ID      string        `json:"id"`
+	Time    time.Time     `json:"time"`
+	Command string        `json:"command"`
+	Summary string        `json:"summary"`
+	Undone  bool          `json:"undone"`
+	Reverts string        `json:"reverts,omitempty"`
+	Changes []ChangeEntry `json:"changes"`
+}
+
+// ChangeEntry is the JSON representation of one changed object
+type ChangeEntry struct {
+	Kind       journal.Kind   `json:"kind"`
+	Action     journal.Action `json:"action"`
+	ID         string         `json:"id"`
+	Title      string         `json:"title"`
+	UndoneAt   *time.Time     `json:"undone_at,omitempty"`
+	RestoredID string         `json:"restored_id,omitempty"`
+}
+
+func newEntry(op *journal.Operation) Entry {
+	e := Entry{
+		ID:      op.ID,
+		Time:    op.Time,
+		Command: op.Command,
+		Summary: op.Summary(),
+		Undone:  op.Undone(),
+		Reverts: op.Reverts,
+		Changes: make([]ChangeEntry, len(op.Changes)),
+	}
+	for i, c := range op.Changes {
+		e.Changes[i] = ChangeEntry{
+			Kind:       c.Kind,
+			Action:     c.Action,
+			ID:         c.ID,
+			Title:      c.Title,
+			UndoneAt:   c.UndoneAt,
+			RestoredID: c.RestoredID,
+		}
+	}
+	return e
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if historyLimit < 0 {
+		return cmdutil.UsageErrorf("--limit must not be negative")
+	}
+
+	j := journal.Open(cfg.JournalDir())
+	var ops []*journal.Operation
+	if len(args) > 0 {
+		op, err := j.Get(args[0])
+		if err != nil {
+			return err
+		}
+		ops = []*journal.Operation{op}
+	} else {
+		ops, err = j.List()
+		if err != nil {
+			return err
+		}
+		if historyLimit > 0 && len(ops) > historyLimit {
+			ops = ops[:historyLimit]
+		}
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	if output.IsStructured(outputFmt) {
+		entries := make([]Entry, len(ops))
+		for i, op := range ops {
+			entries[i] = newEntry(op)
+		}
+		out, err := formatter.Format(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	if len(ops) == 0 {
+		fmt.Println("No recorded operations.")
+		return nil
+	}
+
+	timeFormat := cfg.Display.DateFormat + " " + cfg.Display.TimeFormat
+	if len(args) > 0 {
+		op := ops[0]
+		fmt.Printf("Operation: %s\n", op.ID)
+		fmt.Printf("Time: %s\n", op.Time.Local().Format(timeFormat))
+		fmt.Printf("Command: %s\n", op.Command)
+		if status := op.Status(); status != "" {
+			fmt.Printf("Status: %s\n", status)
+		}
+		fmt.Println()
+
+		table := output.NewSimpleTable([]string{"Action", "Kind", "ID", "Title", "Undone"})
+		for _, c := range op.Changes {
+			undone := ""
+			if c.UndoneAt != nil {
+				undone = c.UndoneAt.Local().Format(timeFormat)
+				if c.RestoredID != "" {
+					undone += " (now " + c.RestoredID + ")"
+				}
+			}
+			table.AddRow(string(c.Action), string(c.Kind), c.ID, c.Title, undone)
+		}
+		out, err := formatter.Format(table)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	table := output.NewSimpleTable([]string{"ID", "Time", "Command", "Changes", "Status"})
+	for _, op := range ops {
+		table.AddRow(op.ID, op.Time.Local().Format(timeFormat), op.Command, op.Summary(), op.Status())
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
@@ -0,0 +1,170 @@
+package history
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+)
+
+var undoYes bool
+
+// UndoCmd is the undo command
+var UndoCmd = &cobra.Command{
+	Use:   "undo [op-id]",
+	Short: "Revert a recorded delete or update",
+	Long: `Revert an operation from the undo journal (see 'hevycli history').
+
+Without an ID, the most recent operation that has not been undone is
+reverted; repeating it goes further back in the history. Deleted workouts,
+routines and folders are created again from the saved copy and get new IDs;
+updated objects are put back to their previous state. Routines that were
+inside a deleted folder are not moved back into the restored folder.
+
+Reverting an update is itself recorded, so it can be undone in turn. If some
+changes of an operation fail to revert, running undo again retries them.
+
+Examples:
+  hevycli undo                      # Revert the last operation
+  hevycli undo 20261018-170102      # Revert a specific operation
+  hevycli undo --yes -o json        # Revert without confirmation`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUndo,
+}
+
+func init() {
+	UndoCmd.Flags().BoolVarP(&undoYes, "yes", "y", false, "Revert without confirmation")
+
+	schema.Register("undo", "Outcome of reverting an operation", Result{})
+}
+
+// Result is the JSON representation of an undo
+type Result struct {
+	Operation string            `json:"operation"`
+	Command   string            `json:"command"`
+	Results   []journal.Outcome `json:"results"`
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	client := api.NewClient(apiKey)
+
+	j := journal.Open(cfg.JournalDir())
+	var op *journal.Operation
+	if len(args) > 0 {
+		op, err = j.Get(args[0])
+	} else {
+		op, err = j.Latest()
+	}
+	if err != nil {
+		return err
+	}
+	if op.Undone() {
+		return fmt.Errorf("operation %s has already been undone", op.ID)
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	if !undoYes {
+		if !cmdutil.IsInteractive() {
+			return cmdutil.UsageErrorf("confirmation required: use --yes to undo without a prompt")
+		}
+		printUndoPreview(os.Stderr, op, cfg.Display.DateFormat+" "+cfg.Display.TimeFormat)
+		fmt.Fprint(os.Stderr, "Undo these changes? [y/N]: ")
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintln(os.Stderr, "Cancelled")
+			return nil
+		}
+	}
+
+	outcomes, err := j.Undo(op, client, cmd.CommandPath()+" "+op.ID)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, o := range outcomes {
+		if !o.OK {
+			failed++
+		}
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(Result{Operation: op.ID, Command: op.Command, Results: outcomes})
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+	} else {
+		for _, o := range outcomes {
+			switch {
+			case !o.OK:
+				fmt.Printf("✗ %s %s %q (%s): %s\n", o.Kind, o.Action, o.Title, o.ID, o.Error)
+			case o.Action == journal.ActionDelete:
+				fmt.Printf("✓ Restored %s %q as %s\n", o.Kind, o.Title, o.RestoredID)
+			case o.Action == journal.ActionCreate:
+				fmt.Printf("✓ Deleted %s %q (%s)\n", o.Kind, o.Title, o.ID)
+			default:
+				fmt.Printf("✓ Reverted update of %s %q (%s)\n", o.Kind, o.Title, o.ID)
+			}
+			if o.OK && o.Note != "" {
+				fmt.Printf("  Note: %s\n", o.Note)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d changes could not be undone; run 'hevycli undo %s' to retry", failed, len(outcomes), op.ID)
+	}
+	return nil
+}
+
+// printUndoPreview lists the changes of op that will be reverted
+func printUndoPreview(w io.Writer, op *journal.Operation, timeFormat string) {
+	fmt.Fprintf(w, "Operation %s from %s: %s\n", op.ID, op.Time.Local().Format(timeFormat), op.Command)
+	for _, c := range op.Changes {
+		if c.UndoneAt != nil {
+			continue
+		}
+		switch c.Action {
+		case journal.ActionDelete:
+			fmt.Fprintf(w, "  restore deleted %s %q (%s)\n", c.Kind, c.Title, c.ID)
+		case journal.ActionCreate:
+			fmt.Fprintf(w, "  delete %s %q created as a copy (%s)\n", c.Kind, c.Title, c.ID)
+		default:
+			fmt.Fprintf(w, "  revert update of %s %q (%s)\n", c.Kind, c.Title, c.ID)
+		}
+	}
+}
@@ -1,20 +1,38 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
+	aliasCmd "github.com/obay/hevycli/cmd/alias"
 	"github.com/obay/hevycli/cmd/completion"
 	"github.com/obay/hevycli/cmd/config"
 	"github.com/obay/hevycli/cmd/exercise"
 	"github.com/obay/hevycli/cmd/folder"
+	"github.com/obay/hevycli/cmd/history"
 	"github.com/obay/hevycli/cmd/routine"
+	schemaCmd "github.com/obay/hevycli/cmd/schema"
 	"github.com/obay/hevycli/cmd/stats"
 	"github.com/obay/hevycli/cmd/workout"
+	"github.com/obay/hevycli/internal/alias"
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	internalConfig "github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
@@ -24,15 +42,21 @@ var (
 	Date    = "unknown"
 
 	// Global flags
-	cfgFile   string
-	outputFmt string
-	noColor   bool
-	quiet     bool
-	verbose   bool
+	cfgFile       string
+	profile       string
+	outputFmt     string
+	templateFile  string
+	noColor       bool
+	quiet         bool
+	verbose       bool
+	schemaVersion int
+	traceFile     string
+	replayFile    string
 
 	// Global state
 	cfg       *internalConfig.Config
 	formatter output.Formatter
+	recorder  *api.Recorder
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -59,14 +83,50 @@ Get your API key at: https://hevy.com/settings?developer
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	args, steps, err := expandAlias(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	if len(steps) > 0 {
+		os.Exit(runMacro(args, steps))
+	}
+	rootCmd.SetArgs(args)
+
+	err = rootCmd.Execute()
+	if recorder != nil {
+		if closeErr := recorder.Close(); closeErr != nil {
+			fmt.Fprintln(os.Stderr, "Warning: failed to write trace file:", closeErr)
+		}
+	}
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "unknown command") {
+			err = &cmdutil.UsageError{Err: err}
+		}
+
 		// Format error based on output format
 		if formatter != nil {
 			output.PrintError(formatter, err)
 		} else {
 			fmt.Fprintln(os.Stderr, "Error:", err)
 		}
-		os.Exit(1)
+		os.Exit(cmdutil.ExitCode(err))
+	}
+}
+
+// markUsageErrors makes argument and flag validation errors of every
+// command exit with the invalid arguments code
+func markUsageErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			if err := validate(c, args); err != nil {
+				return &cmdutil.UsageError{Err: err}
+			}
+			return nil
+		}
+	}
+	for _, c := range cmd.Commands() {
+		markUsageErrors(c)
 	}
 }
 
@@ -74,28 +134,238 @@ func init() {
 	// Global persistent flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "",
 		"config file (default is $HOME/.hevycli/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "",
+		"account profile to use (default from $HEVYCLI_PROFILE or config)")
 	rootCmd.PersistentFlags().StringVarP(&outputFmt, "output", "o", "",
-		"output format: json, table, plain (default: table)")
+		"output format: json, yaml, ndjson, csv, table, plain, template=<name|text> (default: table)")
+	rootCmd.PersistentFlags().StringVar(&templateFile, "template-file", "",
+		"render output with a Go template file (same data as -o json)")
+	rootCmd.PersistentFlags().IntVar(&schemaVersion, "schema-version", schema.Version,
+		"JSON/YAML output schema version (0 for unversioned output without an envelope)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false,
 		"disable colored output")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false,
 		"suppress non-essential output")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false,
-		"enable verbose/debug output")
+		"enable verbose/debug output, including a log of HTTP requests")
+	rootCmd.PersistentFlags().StringVar(&traceFile, "trace-file", "",
+		"record API requests and responses to a file (.har for HAR, otherwise NDJSON); api-key is redacted")
+	rootCmd.PersistentFlags().StringVar(&replayFile, "replay", "",
+		"answer API requests from a recorded trace file instead of the network")
 
 	// Add subcommands
 	rootCmd.AddCommand(config.Cmd)
 	rootCmd.AddCommand(workout.Cmd)
+	rootCmd.AddCommand(workout.LogCmd)
 	rootCmd.AddCommand(routine.Cmd)
 	rootCmd.AddCommand(exercise.Cmd)
 	rootCmd.AddCommand(folder.Cmd)
 	rootCmd.AddCommand(stats.Cmd)
+	rootCmd.AddCommand(history.Cmd)
+	rootCmd.AddCommand(history.UndoCmd)
+	rootCmd.AddCommand(aliasCmd.Cmd)
+	rootCmd.AddCommand(schemaCmd.Cmd)
 	rootCmd.AddCommand(completion.Cmd)
 	rootCmd.AddCommand(versionCmd)
+
+	rootCmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return &cmdutil.UsageError{Err: err}
+	})
+	markUsageErrors(rootCmd)
+}
+
+// aliasDepthEnv tracks macro nesting across subprocesses
+const aliasDepthEnv = "HEVYCLI_ALIAS_DEPTH"
+
+// maxAliasDepth limits alias expansion to catch recursive aliases
+const maxAliasDepth = 5
+
+// expandAlias resolves a user-defined alias in the command line.
+// Global flags given before the alias are kept. Single-step aliases are
+// returned as the new arguments; multi-step macros are returned as steps,
+// with args holding the global flags to pass to every step.
+func expandAlias(args []string) ([]string, [][]string, error) {
+	seen := make(map[string]bool)
+	for {
+		globals, name, rest, configFile := splitCommandLine(args)
+		if name == "" || isBuiltinCommand(name) {
+			return args, nil, nil
+		}
+
+		cfg, err := internalConfig.LoadUnresolved(configFile)
+		if err != nil {
+			return args, nil, nil
+		}
+		expansion, ok := cfg.Aliases[name]
+		if !ok {
+			return args, nil, nil
+		}
+		if seen[name] || len(seen) >= maxAliasDepth {
+			return nil, nil, fmt.Errorf("alias %q expands recursively", name)
+		}
+		seen[name] = true
+
+		steps, err := alias.Parse(expansion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid alias %q: %w", name, err)
+		}
+		if alias.UsesArgs(steps) {
+			for i := range steps {
+				if steps[i], err = alias.ExpandArgs(steps[i], rest); err != nil {
+					return nil, nil, fmt.Errorf("alias %q: %w", name, err)
+				}
+			}
+		} else {
+			last := len(steps) - 1
+			steps[last] = append(steps[last], rest...)
+		}
+
+		if len(steps) > 1 {
+			return globals, steps, nil
+		}
+		args = append(append([]string{}, globals...), steps[0]...)
+	}
+}
+
+// splitCommandLine finds the first positional argument, skipping global
+// flags and their values. It also returns the --config value, if any.
+func splitCommandLine(args []string) (globals []string, name string, rest []string, configFile string) {
+	flags := rootCmd.PersistentFlags()
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return args, "", nil, configFile
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return args[:i], arg, args[i+1:], configFile
+		}
+
+		var flag *pflag.Flag
+		hasValue := false
+		if strings.HasPrefix(arg, "--") {
+			flagName, _, found := strings.Cut(arg[2:], "=")
+			flag, hasValue = flags.Lookup(flagName), found
+		} else {
+			flag, hasValue = flags.ShorthandLookup(arg[1:2]), len(arg) > 2
+		}
+		if flag == nil || flag.Value.Type() == "bool" || hasValue {
+			if flag != nil && flag.Name == "config" && hasValue {
+				_, configFile, _ = strings.Cut(arg, "=")
+			}
+			continue
+		}
+		if i+1 < len(args) {
+			i++
+			if flag.Name == "config" {
+				configFile = args[i]
+			}
+		}
+	}
+	return args, "", nil, configFile
+}
+
+// isBuiltinCommand reports whether name is a command that aliases cannot shadow
+func isBuiltinCommand(name string) bool {
+	if name == "help" || strings.HasPrefix(name, "__") {
+		return true
+	}
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// runMacro runs the steps of a multi-step macro as separate hevycli processes.
+// Output of intermediate steps is captured as unversioned JSON so that later
+// steps can reference it with ${prev.path} or ${stepN.path}. It returns the
+// exit code.
+func runMacro(globals []string, steps [][]string) int {
+	depth, _ := strconv.Atoi(os.Getenv(aliasDepthEnv))
+	if depth >= maxAliasDepth {
+		fmt.Fprintln(os.Stderr, "Error: macros are nested too deeply")
+		return 1
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: failed to locate hevycli executable:", err)
+		return 1
+	}
+
+	var outputs []any
+	for i, step := range steps {
+		step, err := alias.ExpandOutputs(step, outputs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: step %d: %v\n", i+1, err)
+			return 1
+		}
+
+		last := i == len(steps)-1
+		args := append(append([]string{}, globals...), step...)
+		if !last && !hasOutputFlag(args) {
+			args = append(args, "--output", "json", "--schema-version", strconv.Itoa(schema.Legacy))
+		}
+
+		var stdout bytes.Buffer
+		c := exec.Command(exe, args...)
+		c.Stdin = os.Stdin
+		c.Stderr = os.Stderr
+		c.Env = append(os.Environ(), fmt.Sprintf("%s=%d", aliasDepthEnv, depth+1))
+		if last {
+			c.Stdout = os.Stdout
+		} else {
+			c.Stdout = &stdout
+		}
+
+		if err := c.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return exitErr.ExitCode()
+			}
+			fmt.Fprintf(os.Stderr, "Error: step %d: %v\n", i+1, err)
+			return 1
+		}
+
+		var result any
+		if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+			result = strings.TrimSpace(stdout.String())
+		}
+		outputs = append(outputs, result)
+	}
+	return 0
+}
+
+// hasOutputFlag reports whether args select an output format
+func hasOutputFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		if arg == "--output" || strings.HasPrefix(arg, "--output=") || strings.HasPrefix(arg, "-o") {
+			return true
+		}
+	}
+	return false
 }
 
 // initializeApp loads configuration and sets up the formatter
 func initializeApp(cmd *cobra.Command, args []string) error {
+	// Select the profile before any command loads the configuration
+	if profile != "" {
+		internalConfig.SetProfileOverride(profile)
+	}
+
+	if err := schema.CheckVersion(schemaVersion); err != nil {
+		return &cmdutil.UsageError{Err: err}
+	}
+	output.SetSchema(schema.CommandName(cmd.CommandPath()), schemaVersion)
+
+	if err := setupHTTPTracing(); err != nil {
+		return err
+	}
+
 	// Skip initialization for config init command (chicken-egg problem)
 	if cmd.Name() == "init" && cmd.Parent() != nil && cmd.Parent().Name() == "config" {
 		return nil
@@ -109,6 +379,31 @@ func initializeApp(cmd *cobra.Command, args []string) error {
 		cfg = internalConfig.DefaultConfig()
 	}
 
+	// A template file selects template output for the command
+	if templateFile != "" {
+		text, err := os.ReadFile(templateFile)
+		if err != nil {
+			return fmt.Errorf("failed to read template file: %w", err)
+		}
+		if err := cmd.Flags().Set("output", output.TemplatePrefix+string(text)); err != nil {
+			return err
+		}
+	}
+
+	location := time.Local
+	if cfg.Display.Timezone != "" {
+		if loc, err := time.LoadLocation(cfg.Display.Timezone); err == nil {
+			location = loc
+		}
+	}
+	output.SetTemplateSettings(output.TemplateSettings{
+		Units:      cfg.Display.Units,
+		DateFormat: cfg.Display.DateFormat,
+		TimeFormat: cfg.Display.TimeFormat,
+		Location:   location,
+		Dir:        filepath.Join(internalConfig.ConfigDir(), "templates"),
+	})
+
 	// Override config with flags
 	if cmd.Flags().Changed("output") {
 		cfg.Display.OutputFormat = outputFmt
@@ -144,6 +439,39 @@ func initializeApp(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// setupHTTPTracing configures request logging, recording and replay for
+// every API client the command creates
+func setupHTTPTracing() error {
+	var opts []api.ClientOption
+	if verbose {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		opts = append(opts, api.WithLogger(logger))
+	}
+
+	var transport http.RoundTripper
+	if replayFile != "" {
+		replayer, err := api.NewReplayer(replayFile)
+		if err != nil {
+			return err
+		}
+		transport = replayer
+	}
+	if traceFile != "" {
+		var err error
+		recorder, err = api.NewRecorder(traceFile, transport)
+		if err != nil {
+			return err
+		}
+		transport = recorder
+	}
+	if transport != nil {
+		opts = append(opts, api.WithTransport(transport))
+	}
+
+	api.SetDefaultOptions(opts...)
+	return nil
+}
+
 // GetConfig returns the current configuration
 func GetConfig() *internalConfig.Config {
 	return cfg
@@ -0,0 +1,156 @@
+package routine
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/clone"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	cloneTitle  string
+	cloneFolder string
+	cloneFlags  clone.Flags
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone [routine-id]",
+	Short: "Copy a routine",
+	Long: `Create a copy of a routine with every exercise, set target, rest time,
+note and superset.
+
+The copy goes into the same folder unless --folder is given. Weights and reps
+can be changed on the way: --weight-percent scales every weight, --round
+rounds the scaled weights, --reps adds to every rep target and --swap
+replaces one exercise with another (use 'hevycli exercise search' to find
+template IDs). The copy can be removed again with 'hevycli undo'.
+
+Examples:
+  hevycli routine clone <id>                              # "<title> (copy)"
+  hevycli routine clone <id> --title "Push B" --folder <folder-id>
+  hevycli routine clone <id> --weight-percent 5 --round 2.5 --reps -1
+  hevycli routine clone <id> --swap 79D0BB3A=D04AC939`,
+	Args: cmdutil.RequireArgs(1, "<routine-id>"),
+	RunE: runRoutineClone,
+}
+
+func init() {
+	cloneCmd.Flags().StringVar(&cloneTitle, "title", "", "Title of the copy (default: \"<title> (copy)\")")
+	cloneCmd.Flags().StringVar(&cloneFolder, "folder", "", "Folder ID to put the copy in (default: same folder)")
+	cloneFlags.Register(cloneCmd.Flags())
+	Cmd.AddCommand(cloneCmd)
+
+	schema.Register("routine-clone", "The created copy of the routine", api.Routine{})
+}
+
+func runRoutineClone(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	transform, err := cloneFlags.Transform()
+	if err != nil {
+		return err
+	}
+	var folderID *int
+	if cloneFolder != "" {
+		id, err := strconv.Atoi(cloneFolder)
+		if err != nil {
+			return cmdutil.UsageErrorf("invalid folder ID %q", cloneFolder)
+		}
+		folderID = &id
+	}
+
+	client := api.NewClient(apiKey)
+
+	var routineID string
+	if len(args) > 0 {
+		routineID = args[0]
+	} else {
+		// Interactive mode - let user select from routines
+		selected, err := prompt.SearchSelect(prompt.SearchSelectConfig{
+			Title:       "Select Routine to Clone",
+			Placeholder: "Search routines...",
+			Help:        "Type to filter by routine title",
+			LoadFunc: func() ([]prompt.SelectOption, error) {
+				routines, err := client.GetAllRoutines()
+				if err != nil {
+					return nil, err
+				}
+				options := make([]prompt.SelectOption, len(routines))
+				for i, r := range routines {
+					options[i] = prompt.SelectOption{
+						ID:          r.ID,
+						Title:       r.Title,
+						Description: fmt.Sprintf("%d exercises", len(r.Exercises)),
+					}
+				}
+				return options, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		routineID = selected.ID
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	routine, err := client.GetRoutine(routineID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch routine: %w", err)
+	}
+
+	title := cloneTitle
+	if title == "" {
+		title = routine.Title + " (copy)"
+	}
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	created, err := clone.Routine(client, rec, routine, title, folderID, transform)
+	if err != nil {
+		return fmt.Errorf("failed to clone routine: %w", err)
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(created)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	fmt.Println("Routine cloned successfully!")
+	fmt.Printf("ID: %s\n", created.ID)
+	fmt.Printf("Title: %s\n", created.Title)
+	fmt.Printf("Exercises: %d\n", len(created.Exercises))
+	fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
@@ -54,6 +56,8 @@ func init() {
 	createCmd.Flags().StringVar(&routineCreateTitle, "title", "", "Routine title (overrides file)")
 	createCmd.Flags().IntVar(&routineCreateFolder, "folder", 0, "Folder ID to place routine in")
 	createCmd.MarkFlagRequired("file")
+
+	schema.Register("routine-create", "The created routine", api.Routine{})
 }
 
 func runRoutineCreate(cmd *cobra.Command, args []string) error {
@@ -64,7 +68,7 @@ func runRoutineCreate(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -109,7 +113,7 @@ func runRoutineCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(routine)
 		if err != nil {
 			return err
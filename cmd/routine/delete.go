@@ -9,33 +9,67 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/bulk"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
 var (
-	deleteForce bool
+	deleteForce       bool
+	deleteMatch       string
+	deleteFolder      string
+	deleteDryRun      bool
+	deleteConcurrency int
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete <routine-id>",
+	Use:   "delete [routine-id]",
 	Short: "Delete a routine",
-	Long: `Delete a routine by ID.
+	Long: `Delete a routine by ID, or many routines selected with filters.
 
 By default, you will be prompted to confirm the deletion.
 Use --force to skip the confirmation prompt.
 
+With --match or --folder, every matching routine is deleted. --match selects
+titles with a glob pattern where * matches any text and --folder selects the
+routines in a folder. The selected routines are shown first and a single
+confirmation is asked; structured output formats get a per-item report.
+
 Examples:
   hevycli routine delete <id>           # Delete with confirmation
-  hevycli routine delete <id> --force   # Delete without confirmation`,
-	Args: cmdutil.RequireArgs(1, "<routine-id>"),
+  hevycli routine delete <id> --force   # Delete without confirmation
+  hevycli routine delete --match "PPL*" --dry-run     # Preview only
+  hevycli routine delete --folder <folder-id> --match "*old*"`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if deleteBulk() {
+			if len(args) > 0 {
+				return fmt.Errorf("a routine ID cannot be combined with --match or --folder")
+			}
+			return nil
+		}
+		return cmdutil.RequireArgs(1, "<routine-id>")(cmd, args)
+	},
 	RunE: runDelete,
 }
 
 func init() {
 	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "Skip confirmation prompt")
+	deleteCmd.Flags().StringVar(&deleteMatch, "match", "", "Delete routines whose title matches a glob pattern")
+	deleteCmd.Flags().StringVar(&deleteFolder, "folder", "", "Delete routines in a folder (folder ID)")
+	deleteCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "Show the selected routines without deleting them")
+	deleteCmd.Flags().IntVar(&deleteConcurrency, "concurrency", bulk.DefaultConcurrency, "Number of deletions to run at once")
 	Cmd.AddCommand(deleteCmd)
+
+	schema.Register("routine-delete", "Per-routine results of a bulk delete", bulk.Report{})
+}
+
+// deleteBulk reports whether routines are selected with filters
+func deleteBulk() bool {
+	return deleteMatch != "" || deleteFolder != ""
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
@@ -46,11 +80,15 @@ func runDelete(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
 
+	if deleteBulk() {
+		return runBulkDelete(cmd, cfg, client)
+	}
+
 	var routineID string
 	if len(args) > 0 {
 		routineID = args[0]
@@ -91,7 +129,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	// Confirm deletion unless --force is used
 	if !deleteForce {
 		fmt.Printf("Are you sure you want to delete routine '%s' (%s)?\n", routine.Title, routine.ID)
-		fmt.Printf("This action cannot be undone.\n")
+		fmt.Printf("It can be restored later with 'hevycli undo'.\n")
 		fmt.Print("Type 'yes' to confirm: ")
 
 		reader := bufio.NewReader(os.Stdin)
@@ -108,10 +146,79 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Delete the routine
-	if err := client.DeleteRoutine(routineID); err != nil {
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	err = rec.Track(journal.ActionDelete, routine, func() error {
+		return client.DeleteRoutine(routineID)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete routine: %w", err)
 	}
 
 	fmt.Printf("Routine '%s' deleted successfully.\n", routine.Title)
+	fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
 	return nil
 }
+
+// runBulkDelete deletes every routine selected by the filter flags
+func runBulkDelete(cmd *cobra.Command, cfg *config.Config, client *api.Client) error {
+	if deleteConcurrency < 1 {
+		return cmdutil.UsageErrorf("--concurrency must be at least 1")
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	routines, err := client.GetAllRoutines()
+	if err != nil {
+		return fmt.Errorf("failed to fetch routines: %w", err)
+	}
+
+	var items []bulk.Item
+	for _, r := range routines {
+		if deleteFolder != "" && (r.FolderID == nil || *r.FolderID != deleteFolder) {
+			continue
+		}
+		if deleteMatch != "" && !bulk.MatchTitle(deleteMatch, r.Title) {
+			continue
+		}
+		items = append(items, bulk.Item{
+			ID:     r.ID,
+			Title:  r.Title,
+			Detail: fmt.Sprintf("%d", len(r.Exercises)),
+		})
+	}
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	return bulk.Execute(bulk.Options{
+		Action:       "delete",
+		Done:         "deleted",
+		Noun:         "routines",
+		DetailHeader: "Exercises",
+		Items:        items,
+		Concurrency:  deleteConcurrency,
+		DryRun:       deleteDryRun,
+		Force:        deleteForce,
+		OutputFormat: outputFmt,
+		Formatter:    formatter,
+		NoColor:      !cfg.Display.Color,
+		Operation:    rec.ID(),
+		Do: func(item bulk.Item) (string, error) {
+			routine, err := client.GetRoutine(item.ID)
+			if err != nil {
+				return "", err
+			}
+			return "", rec.Track(journal.ActionDelete, routine, func() error {
+				return client.DeleteRoutine(item.ID)
+			})
+		},
+	})
+}
@@ -0,0 +1,179 @@
+package routine
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/edit"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	routineEditFormat string
+	routineEditEditor string
+	routineEditYes    bool
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit [routine-id]",
+	Short: "Edit a routine in your editor",
+	Long: `Open a routine in $VISUAL or $EDITOR as a YAML (or JSON) document.
+
+Exercise titles are shown as comments next to their template IDs. When you
+save and close the editor the document is validated, the changes are shown
+as a diff against the current routine, and after confirmation the routine is
+updated. Invalid documents are reopened with the problems listed at the top;
+save an empty file to cancel.
+
+Examples:
+  hevycli routine edit <id>                     # Edit as YAML
+  hevycli routine edit <id> --format json       # Edit as JSON
+  hevycli routine edit <id> --editor "code --wait"
+  hevycli routine edit <id> --yes               # Save without confirming the diff`,
+	Args: cmdutil.RequireArgs(1, "<routine-id>"),
+	RunE: runRoutineEdit,
+}
+
+func init() {
+	editCmd.Flags().StringVar(&routineEditFormat, "format", "yaml", "Document format: yaml or json")
+	editCmd.Flags().StringVar(&routineEditEditor, "editor", "", "Editor command (default: $VISUAL, $EDITOR or vi)")
+	editCmd.Flags().BoolVarP(&routineEditYes, "yes", "y", false, "Save without confirming the diff")
+	Cmd.AddCommand(editCmd)
+
+	schema.Register("routine-edit", "The edited routine", api.Routine{})
+}
+
+func runRoutineEdit(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	format, err := edit.ParseFormat(routineEditFormat)
+	if err != nil {
+		return cmdutil.UsageErrorf("%w", err)
+	}
+
+	client := api.NewClient(apiKey)
+
+	var routineID string
+	if len(args) > 0 {
+		routineID = args[0]
+	} else {
+		// Interactive mode - let user select from routines
+		selected, err := prompt.SearchSelect(prompt.SearchSelectConfig{
+			Title:       "Select Routine to Edit",
+			Placeholder: "Search routines...",
+			Help:        "Type to filter by routine title",
+			LoadFunc: func() ([]prompt.SelectOption, error) {
+				routines, err := client.GetRoutines(1, 20)
+				if err != nil {
+					return nil, err
+				}
+				options := make([]prompt.SelectOption, len(routines.Routines))
+				for i, r := range routines.Routines {
+					options[i] = prompt.SelectOption{
+						ID:          r.ID,
+						Title:       r.Title,
+						Description: fmt.Sprintf("%d exercises", len(r.Exercises)),
+					}
+				}
+				return options, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		routineID = selected.ID
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	current, err := client.GetRoutine(routineID)
+	if err != nil {
+		return fmt.Errorf("failed to get routine: %w", err)
+	}
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	var updated *api.Routine
+	session := &edit.Session[*edit.RoutineDoc]{
+		Format: format,
+		Header: []string{
+			fmt.Sprintf("Editing routine %s (%s).", current.ID, current.Title),
+			"Weights are in kg. Set types: normal, warmup, dropset, failure.",
+			"Save and close to update the routine, or save an empty file to cancel.",
+		},
+		Titles: edit.Titles(current.Exercises),
+		Edit: func(text string) (string, error) {
+			return cmdutil.EditTextWith(routineEditEditor, text, "hevycli-routine-*"+format.Ext())
+		},
+		New:    func() *edit.RoutineDoc { return &edit.RoutineDoc{} },
+		Lookup: edit.TemplateLookup(client),
+		Confirm: func(diff string) (bool, error) {
+			if routineEditYes {
+				return true, nil
+			}
+			return edit.ConfirmDiff(diff)
+		},
+		Save: func(doc *edit.RoutineDoc) error {
+			return rec.Track(journal.ActionUpdate, current, func() error {
+				var err error
+				updated, err = client.UpdateRoutine(routineID, doc.Request())
+				return err
+			})
+		},
+	}
+
+	result, err := session.Run(edit.FromRoutine(current))
+	if err != nil {
+		return fmt.Errorf("failed to update routine: %w", err)
+	}
+	switch result {
+	case edit.Unchanged:
+		fmt.Fprintln(os.Stderr, "No changes")
+		return nil
+	case edit.Cancelled:
+		fmt.Fprintln(os.Stderr, "Cancelled")
+		return nil
+	}
+
+	// Format output
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(updated)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+	} else {
+		fmt.Println("Routine updated successfully!")
+		fmt.Printf("ID: %s\n", updated.ID)
+		fmt.Printf("Title: %s\n", updated.Title)
+		fmt.Printf("Exercises: %d\n", len(updated.Exercises))
+		fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
+	}
+
+	return nil
+}
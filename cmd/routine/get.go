@@ -11,6 +11,7 @@ import (
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
@@ -26,6 +27,10 @@ Examples:
 	RunE: runGet,
 }
 
+func init() {
+	schema.Register("routine-get", "A routine", api.Routine{})
+}
+
 func runGet(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load("")
 	if err != nil {
@@ -34,7 +39,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -87,7 +92,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 		Writer:  os.Stdout,
 	})
 
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(routine)
 		if err != nil {
 			return err
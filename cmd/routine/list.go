@@ -7,8 +7,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
@@ -26,6 +28,7 @@ var listCmd = &cobra.Command{
 Examples:
   hevycli routine list              # List routines
   hevycli routine list --all        # List all routines
+  hevycli routine list --folder <id>  # All routines in a folder
   hevycli routine list -o json      # Output as JSON`,
 	RunE: runList,
 }
@@ -34,7 +37,9 @@ func init() {
 	listCmd.Flags().IntVar(&listPage, "page", 1, "Page number for pagination")
 	listCmd.Flags().IntVar(&listLimit, "limit", 10, "Number of routines to fetch")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "Fetch all routines")
-	listCmd.Flags().StringVar(&listFolder, "folder", "", "Filter by folder ID")
+	listCmd.Flags().StringVar(&listFolder, "folder", "", "Filter by folder ID (searches all routines)")
+
+	schema.Register("routine-list", "Routines", []api.Routine{})
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -45,7 +50,7 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -56,15 +61,20 @@ func runList(cmd *cobra.Command, args []string) error {
 		outputFmt, _ = cmd.Flags().GetString("output")
 	}
 
-	formatter := output.NewFormatter(output.Options{
+	opts := output.Options{
 		Format:  output.FormatType(outputFmt),
 		NoColor: !cfg.Display.Color,
 		Writer:  os.Stdout,
-	})
+	}
+	formatter := output.NewFormatter(opts)
 
 	var allRoutines []api.Routine
 
-	if listAll {
+	// A folder filter needs every page, not just the requested one
+	if listAll || listFolder != "" {
+		// ndjson and csv write each page as it arrives
+		stream, streaming := output.NewStream(opts)
+
 		// Fetch all routines with pagination
 		page := 1
 		for {
@@ -72,13 +82,27 @@ func runList(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return fmt.Errorf("failed to fetch routines: %w", err)
 			}
-			allRoutines = append(allRoutines, resp.Routines...)
+			for _, r := range resp.Routines {
+				if listFolder != "" && (r.FolderID == nil || *r.FolderID != listFolder) {
+					continue
+				}
+				if streaming {
+					if err := stream.Write(r); err != nil {
+						return err
+					}
+				} else {
+					allRoutines = append(allRoutines, r)
+				}
+			}
 
 			if page >= resp.PageCount || resp.PageCount == 0 {
 				break
 			}
 			page++
 		}
+		if streaming {
+			return stream.Flush()
+		}
 	} else {
 		pageSize := listLimit
 		if pageSize > 10 {
@@ -104,7 +128,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		result := map[string]interface{}{
 			"routines": allRoutines,
 			"count":    len(allRoutines),
@@ -0,0 +1,278 @@
+package routine
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/bulk"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/organize"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	moveFolder      string
+	moveMatch       string
+	moveDryRun      bool
+	moveForce       bool
+	moveConcurrency int
+)
+
+var moveCmd = &cobra.Command{
+	Use:   "move [routine-id]",
+	Short: "Move a routine into a folder",
+	Long: `Move a routine into another folder.
+
+If the API cannot change a routine's folder in place, the routine is copied
+into the folder and the original deleted, so the moved routine gets a new ID.
+Either way the move can be reverted with 'hevycli undo'.
+
+With --match, every routine whose title matches the glob pattern (* matches
+any text) is moved. The selected routines are shown first and a single
+confirmation is asked; structured output formats get a per-item report.
+
+Examples:
+  hevycli routine move <id> --folder <folder-id>
+  hevycli routine move --match "PPL*" --folder <folder-id> --dry-run
+  hevycli routine move --match "PPL*" --folder <folder-id> --force -o json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if moveMatch != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("a routine ID cannot be combined with --match")
+			}
+			return nil
+		}
+		return cmdutil.RequireArgs(1, "<routine-id>")(cmd, args)
+	},
+	RunE: runRoutineMove,
+}
+
+func init() {
+	moveCmd.Flags().StringVar(&moveFolder, "folder", "", "Folder ID to move the routine into")
+	moveCmd.Flags().StringVar(&moveMatch, "match", "", "Move routines whose title matches a glob pattern")
+	moveCmd.Flags().BoolVar(&moveDryRun, "dry-run", false, "Show the selected routines without moving them")
+	moveCmd.Flags().BoolVarP(&moveForce, "force", "f", false, "Skip confirmation prompt for --match")
+	moveCmd.Flags().IntVar(&moveConcurrency, "concurrency", bulk.DefaultConcurrency, "Number of moves to run at once")
+	Cmd.AddCommand(moveCmd)
+
+	schema.Register("routine-move", "Per-routine results of a move", bulk.Report{})
+}
+
+func runRoutineMove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+	if moveConcurrency < 1 {
+		return cmdutil.UsageErrorf("--concurrency must be at least 1")
+	}
+
+	client := api.NewClient(apiKey)
+
+	var routineID string
+	if moveMatch == "" {
+		if len(args) > 0 {
+			routineID = args[0]
+		} else {
+			// Interactive mode - let user select from routines
+			selected, err := prompt.SearchSelect(prompt.SearchSelectConfig{
+				Title:       "Select Routine to Move",
+				Placeholder: "Search routines...",
+				Help:        "Type to filter by routine title",
+				LoadFunc: func() ([]prompt.SelectOption, error) {
+					routines, err := client.GetAllRoutines()
+					if err != nil {
+						return nil, err
+					}
+					options := make([]prompt.SelectOption, len(routines))
+					for i, r := range routines {
+						options[i] = prompt.SelectOption{
+							ID:          r.ID,
+							Title:       r.Title,
+							Description: fmt.Sprintf("%d exercises", len(r.Exercises)),
+						}
+					}
+					return options, nil
+				},
+			})
+			if err != nil {
+				return err
+			}
+			routineID = selected.ID
+		}
+	}
+
+	folder, err := moveTargetFolder(client)
+	if err != nil {
+		return err
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	if moveMatch != "" {
+		return runBulkMove(client, rec, folder, outputFmt, formatter, !cfg.Display.Color)
+	}
+
+	routine, err := client.GetRoutine(routineID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch routine: %w", err)
+	}
+
+	var (
+		moved  *api.Routine
+		method organize.Method
+	)
+	report := bulk.Run("move", []bulk.Item{{ID: routine.ID, Title: routine.Title}}, 1, func(bulk.Item) (string, error) {
+		var err error
+		moved, method, err = organize.MoveRoutine(client, rec, routine, folder.ID)
+		if method == organize.MethodCopied {
+			return moved.ID, err
+		}
+		return "", err
+	})
+	if method != organize.MethodUnchanged && report.Succeeded > 0 {
+		report.Operation = rec.ID()
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(report)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return report.Err()
+	}
+
+	if err := report.Err(); err != nil {
+		return fmt.Errorf("failed to move routine: %s", report.Results[0].Error)
+	}
+	switch method {
+	case organize.MethodUnchanged:
+		fmt.Printf("Routine '%s' is already in folder '%s'.\n", routine.Title, folder.Title)
+		return nil
+	case organize.MethodCopied:
+		fmt.Printf("Routine '%s' moved to folder '%s'.\n", routine.Title, folder.Title)
+		fmt.Printf("The API could not move it in place, so it was copied and the original deleted. New ID: %s\n", moved.ID)
+	default:
+		fmt.Printf("Routine '%s' moved to folder '%s'.\n", routine.Title, folder.Title)
+	}
+	fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
+	return nil
+}
+
+// moveTargetFolder returns the folder named by --folder, asking for one in
+// interactive mode
+func moveTargetFolder(client *api.Client) (*api.RoutineFolder, error) {
+	folderID := moveFolder
+	if folderID == "" {
+		if !cmdutil.IsInteractive() {
+			return nil, cmdutil.UsageErrorf("--folder is required")
+		}
+		selected, err := prompt.SearchSelect(prompt.SearchSelectConfig{
+			Title:       "Select Target Folder",
+			Placeholder: "Search folders...",
+			Help:        "Type to filter by folder title",
+			LoadFunc: func() ([]prompt.SelectOption, error) {
+				folders, err := client.GetAllRoutineFolders()
+				if err != nil {
+					return nil, err
+				}
+				options := make([]prompt.SelectOption, len(folders))
+				for i, f := range folders {
+					options[i] = prompt.SelectOption{
+						ID:          f.ID,
+						Title:       f.Title,
+						Description: fmt.Sprintf("Index: %d", f.Index),
+					}
+				}
+				return options, nil
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		folderID = selected.ID
+	}
+
+	folder, err := client.GetRoutineFolder(folderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch folder: %w", err)
+	}
+	return folder, nil
+}
+
+// runBulkMove moves every routine matching --match into folder
+func runBulkMove(client *api.Client, rec *journal.Recorder, folder *api.RoutineFolder, outputFmt string, formatter output.Formatter, noColor bool) error {
+	routines, err := client.GetAllRoutines()
+	if err != nil {
+		return fmt.Errorf("failed to fetch routines: %w", err)
+	}
+	folders, err := client.GetAllRoutineFolders()
+	if err != nil {
+		return fmt.Errorf("failed to fetch folders: %w", err)
+	}
+	titles := make(map[string]string, len(folders))
+	for _, f := range folders {
+		titles[f.ID] = f.Title
+	}
+
+	byID := make(map[string]*api.Routine)
+	var items []bulk.Item
+	for i := range routines {
+		r := &routines[i]
+		if !bulk.MatchTitle(moveMatch, r.Title) || organize.InFolder(r, folder.ID) {
+			continue
+		}
+		current := "-"
+		if r.FolderID != nil {
+			current = titles[*r.FolderID]
+		}
+		byID[r.ID] = r
+		items = append(items, bulk.Item{ID: r.ID, Title: r.Title, Detail: current})
+	}
+
+	return bulk.Execute(bulk.Options{
+		Action:       "move",
+		Done:         "moved",
+		Noun:         "routines",
+		DetailHeader: "Folder",
+		Items:        items,
+		Concurrency:  moveConcurrency,
+		DryRun:       moveDryRun,
+		Force:        moveForce,
+		OutputFormat: outputFmt,
+		Formatter:    formatter,
+		NoColor:      noColor,
+		Operation:    rec.ID(),
+		Do: func(item bulk.Item) (string, error) {
+			moved, method, err := organize.MoveRoutine(client, rec, byID[item.ID], folder.ID)
+			if method == organize.MethodCopied {
+				return moved.ID, err
+			}
+			return "", err
+		},
+	})
+}
@@ -13,7 +13,10 @@ Examples:
   hevycli routine get <id>              # Get routine details
   hevycli routine create --file r.json  # Create from JSON
   hevycli routine update <id> --file r.json  # Update routine
+  hevycli routine edit <id>             # Edit in $EDITOR
   hevycli routine delete <id>           # Delete routine
+  hevycli routine move <id> --folder <folder-id>  # Move into a folder
+  hevycli routine clone <id> --weight-percent 5   # Copy with heavier weights
   hevycli routine builder               # Interactive routine builder`,
 }
 
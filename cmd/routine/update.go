@@ -10,7 +10,9 @@ import (
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
@@ -44,6 +46,8 @@ func init() {
 	updateCmd.Flags().StringVarP(&routineUpdateFile, "file", "f", "", "JSON file with routine data (required)")
 	updateCmd.Flags().StringVar(&routineUpdateTitle, "title", "", "Update routine title (overrides file)")
 	updateCmd.MarkFlagRequired("file")
+
+	schema.Register("routine-update", "The updated routine", api.Routine{})
 }
 
 func runRoutineUpdate(cmd *cobra.Command, args []string) error {
@@ -54,7 +58,7 @@ func runRoutineUpdate(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -118,14 +122,25 @@ func runRoutineUpdate(cmd *cobra.Command, args []string) error {
 		req.Routine.Title = routineUpdateTitle
 	}
 
+	// Snapshot the current routine so the update can be undone
+	current, err := client.GetRoutine(routineID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch routine: %w", err)
+	}
+
 	// Update the routine
-	routine, err := client.UpdateRoutine(routineID, &req)
+	var routine *api.Routine
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	err = rec.Track(journal.ActionUpdate, current, func() error {
+		routine, err = client.UpdateRoutine(routineID, &req)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update routine: %w", err)
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(routine)
 		if err != nil {
 			return err
@@ -136,6 +151,7 @@ func runRoutineUpdate(cmd *cobra.Command, args []string) error {
 		fmt.Printf("ID: %s\n", routine.ID)
 		fmt.Printf("Title: %s\n", routine.Title)
 		fmt.Printf("Exercises: %d\n", len(routine.Exercises))
+		fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
 	}
 
 	return nil
@@ -0,0 +1,149 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+)
+
+// Cmd is the schema command
+var Cmd = &cobra.Command{
+	Use:   "schema [command...]",
+	Short: "Print JSON Schema documents for command output",
+	Long: `Print the JSON Schema document describing a command's JSON output.
+
+JSON and YAML output is wrapped in a versioned envelope:
+
+  {"schema": "hevycli/workout-list@1", "data": [...], "meta": {"count": 10}}
+
+Within a schema version fields may be added but are never removed or
+changed. Use --schema-version to pin a version, or --schema-version 0 for
+the unversioned output of earlier releases.
+
+Without arguments, the available schemas are listed.
+
+Examples:
+  hevycli schema                         # List schemas
+  hevycli schema workout list            # Schema for 'workout list' output
+  hevycli schema error                   # Schema for error output
+  hevycli schema --dir ./schemas         # Write every schema to a directory`,
+	RunE: runSchema,
+}
+
+var schemaDir string
+
+func init() {
+	Cmd.Flags().StringVar(&schemaDir, "dir", "", "write every schema document to this directory")
+
+	schema.Register("schema", "Available output schemas", []Info{})
+}
+
+// Info is the JSON representation of an available schema
+type Info struct {
+	Name        string `json:"name"`
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("schema-version") {
+		version, _ := cmd.Flags().GetInt("schema-version")
+		if version != schema.Version {
+			return fmt.Errorf("schema documents are only published for version %d", schema.Version)
+		}
+	}
+
+	if schemaDir != "" {
+		return writeSchemas(schemaDir)
+	}
+
+	if len(args) > 0 {
+		name := strings.Join(args, "-")
+		doc, err := schema.Document(name)
+		if err != nil {
+			return fmt.Errorf("%w (run 'hevycli schema' to list schemas)", err)
+		}
+		b, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	cfg, err := config.LoadUnresolved("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	var schemas []Info
+	for _, name := range schema.Names() {
+		o, _ := schema.Lookup(name)
+		schemas = append(schemas, Info{Name: name, ID: schema.ID(name, schema.Version), Description: o.Description})
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(schemas)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	}
+
+	table := output.NewSimpleTable([]string{"Command", "Schema", "Description"})
+	for _, s := range schemas {
+		table.AddRow(strings.ReplaceAll(s.Name, "-", " "), s.ID, s.Description)
+	}
+	out, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
+
+// writeSchemas writes a <name>.json document for every schema to dir
+func writeSchemas(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	for _, name := range schema.Names() {
+		doc, err := schema.Document(name)
+		if err != nil {
+			return err
+		}
+		b, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, append(b, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	fmt.Printf("Wrote %d schemas to %s\n", len(schema.Names()), dir)
+	return nil
+}
@@ -0,0 +1,193 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/calendar"
+)
+
+var (
+	calendarWeeks       int
+	calendarMetric      string
+	calendarTarget      int
+	calendarInteractive bool
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Show a training calendar heatmap",
+	Long: `Display a contribution-style heatmap of your training days.
+
+Each column is a week and each square a day, shaded by the chosen metric.
+Days follow display.timezone and weeks start on display.week_start (Monday
+by default). When a weekly target is set, every week is marked as
+hit (✓) or missed (✗) and target streaks are reported alongside day streaks.
+
+Metrics:
+  volume    - Total weight × reps (default)
+  duration  - Total workout minutes
+  count     - Number of workouts
+
+The weekly target defaults to the stats.weekly_target config value
+('hevycli config set weekly-target 4').
+
+Use --interactive to browse your full history with the keyboard.
+
+Examples:
+  hevycli stats calendar                    # Last 52 weeks by volume
+  hevycli stats calendar --weeks 12         # Last 12 weeks
+  hevycli stats calendar --metric duration  # Shade by workout time
+  hevycli stats calendar --target 3         # Mark weeks with 3+ training days
+  hevycli stats calendar --interactive      # Browse history in a TUI
+  hevycli stats calendar -o json            # Daily aggregates as JSON`,
+	RunE: runCalendar,
+}
+
+func init() {
+	calendarCmd.Flags().IntVar(&calendarWeeks, "weeks", 52, "number of weeks to show")
+	calendarCmd.Flags().StringVar(&calendarMetric, "metric", "volume",
+		"heatmap metric: volume, duration, count")
+	calendarCmd.Flags().IntVar(&calendarTarget, "target", 0,
+		"training days per week to aim for (default from config)")
+	calendarCmd.Flags().BoolVarP(&calendarInteractive, "interactive", "i", false,
+		"browse the calendar interactively")
+
+	schema.Register("stats-calendar", "Training calendar with weekly streaks", analysis.Calendar{})
+}
+
+func runCalendar(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	client := api.NewClient(apiKey)
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	metric, err := analysis.ParseCalendarMetric(calendarMetric)
+	if err != nil {
+		return err
+	}
+
+	if calendarWeeks < 1 {
+		return cmdutil.UsageErrorf("invalid --weeks: %d (must be at least 1)", calendarWeeks)
+	}
+
+	target := cfg.Stats.WeeklyTarget
+	if cmd.Flags().Changed("target") {
+		target = calendarTarget
+	}
+	if target < 0 || target > 7 {
+		return cmdutil.UsageErrorf("invalid target: %d (must be from 0 to 7)", target)
+	}
+
+	dates, err := cmdutil.NewDateParser(cfg.Display.Timezone, cfg.Display.WeekStart)
+	if err != nil {
+		return err
+	}
+
+	// Fetch all workouts
+	fmt.Fprintln(os.Stderr, "Fetching workout data...")
+	workouts, err := fetchWorkouts(client, cfg)
+	if err != nil {
+		return err
+	}
+
+	now := dates.Now
+	start := now.AddDate(0, 0, -7*(calendarWeeks-1))
+
+	if calendarInteractive {
+		// Browse the whole history, not just the requested window
+		if !cmd.Flags().Changed("weeks") {
+			for _, w := range workouts {
+				if w.StartTime.Before(start) {
+					start = w.StartTime.In(dates.Location)
+				}
+			}
+		}
+		cal := analysis.BuildCalendar(workouts, start, now, dates.WeekStart, target)
+		return calendar.Run(cal, metric, cfg.Display.Color)
+	}
+
+	cal := analysis.BuildCalendar(workouts, start, now, dates.WeekStart, target)
+
+	// Format output
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(cal)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+	} else {
+		printCalendar(cal, metric, cfg.Display.Color)
+	}
+
+	return nil
+}
+
+func printCalendar(cal *analysis.Calendar, metric analysis.CalendarMetric, color bool) {
+	fmt.Printf("\n📅 Training Calendar (%s to %s, by %s)\n\n", cal.Start, cal.End, metric)
+	fmt.Print(calendar.Render(cal, calendar.RenderOptions{Metric: metric, Color: color}))
+
+	fmt.Println("\n🔥 Streaks")
+	fmt.Printf("   Current streak:      %d days\n", cal.Streaks.CurrentDays)
+	fmt.Printf("   Longest streak:      %d days\n", cal.Streaks.LongestDays)
+	if cal.WeeklyTarget > 0 {
+		hit, missed := 0, 0
+		for _, w := range cal.Weeks {
+			switch w.Status {
+			case analysis.TargetHit:
+				hit++
+			case analysis.TargetMiss:
+				missed++
+			}
+		}
+		fmt.Printf("   Target weeks:        %d hit, %d missed (%d/week)\n", hit, missed, cal.WeeklyTarget)
+		fmt.Printf("   Current target run:  %d weeks\n", cal.Streaks.CurrentWeeks)
+		fmt.Printf("   Longest target run:  %d weeks\n", cal.Streaks.LongestWeeks)
+	}
+
+	rest := cal.Rest
+	fmt.Println("\n😴 Rest")
+	fmt.Printf("   Training days:       %d\n", rest.TrainingDays)
+	fmt.Printf("   Rest days:           %d\n", rest.RestDays)
+	fmt.Printf("   Avg rest between:    %.1f days\n", rest.AverageRestDays)
+	fmt.Printf("   Typical rest:        %d days\n", rest.MostCommonRestDays)
+	fmt.Printf("   Longest break:       %d days\n", rest.LongestRestDays)
+
+	var weekdays []string
+	first := cal.FirstDay().Weekday()
+	for i := 0; i < 7; i++ {
+		wd := (first + time.Weekday(i)) % 7
+		weekdays = append(weekdays, fmt.Sprintf("%s %d", wd.String()[:3], rest.WeekdayFrequency[wd.String()]))
+	}
+	fmt.Printf("   By weekday:          %s\n", strings.Join(weekdays, "  "))
+	fmt.Println()
+}
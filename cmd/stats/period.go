@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+)
+
+// periodHelp describes the --period, --since and --until flags
+const periodHelp = `Periods:
+  week    - Last 7 days
+  month   - Since the same day last month
+  year    - Since the same day last year
+  all     - All time
+  90d, 3w, 6m, 1y
+          - Since that long ago
+  Any date expression such as "last month", "2026-W14", "march" or
+  "2026-01..2026-03" is also accepted.
+
+--since and --until accept the same date expressions and override the
+start and end of the period.`
+
+// resolvePeriod returns the inclusive time span selected by --period,
+// --since and --until. The end never lies in the future.
+func resolvePeriod(cfg *config.Config, period, since, until string) (time.Time, time.Time, error) {
+	dates, err := cmdutil.NewDateParser(cfg.Display.Timezone, cfg.Display.WeekStart)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	now := dates.Now
+	allTime := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	start, end := time.Time{}, now
+	switch period {
+	case "week":
+		start = now.AddDate(0, 0, -7)
+	case "month":
+		start = now.AddDate(0, -1, 0)
+	case "year":
+		start = now.AddDate(-1, 0, 0)
+	case "all", "":
+		start = allTime
+	default:
+		r, err := dates.Period(period)
+		if err != nil {
+			return time.Time{}, time.Time{}, cmdutil.UsageErrorf("invalid period: %s (use week, month, year, all or a date expression such as \"last month\")", period)
+		}
+		start, end = r.Start, clampEnd(r.End, now)
+	}
+
+	if since != "" || until != "" {
+		r, err := dates.SinceUntil(since, until)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if !r.Start.IsZero() {
+			start = r.Start
+		}
+		if !r.End.IsZero() {
+			end = clampEnd(r.End, now)
+		}
+	}
+
+	if start.IsZero() {
+		start = allTime
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the selected period has not started yet")
+	}
+	return start, end, nil
+}
+
+// clampEnd converts an exclusive range end to an inclusive one, capped at now
+func clampEnd(end, now time.Time) time.Time {
+	if end.IsZero() || end.After(now) {
+		return now
+	}
+	return end.Add(-time.Nanosecond)
+}
@@ -10,16 +10,21 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/obay/hevycli/internal/analysis"
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
 var (
-	progressMetric string
-	progressPeriod string
+	progressMetric  string
+	progressPeriod  string
+	progressFormula string
+	progressSince   string
+	progressUntil   string
 )
 
 var progressCmd = &cobra.Command{
@@ -31,12 +36,22 @@ Metrics:
   weight   - Max weight used (default)
   volume   - Total volume (weight × reps)
   reps     - Max reps at heaviest weight
-  1rm      - Estimated one-rep max (Brzycki formula)
+  1rm      - Estimated one-rep max (see --formula)
+
+1RM formulas:
+  brzycki (default), epley, lombardi, mayhew, wathan, oconner, rpe
+  The rpe formula uses the RPE recorded on each set and falls back to
+  brzycki for sets without one or past the end of the RPE chart. The
+  default can be changed with 'hevycli config set one-rm-formula <formula>'.
+
+` + periodHelp + `
 
 Examples:
   hevycli stats progress "Bench Press"
   hevycli stats progress "Squat" --metric 1rm
-  hevycli stats progress "Deadlift" --metric volume --period year`,
+  hevycli stats progress "Squat" --metric 1rm --formula epley
+  hevycli stats progress "Deadlift" --metric volume --period year
+  hevycli stats progress "Squat" --since 12w`,
 	Args: cmdutil.RequireArgs(1, "<exercise-name>"),
 	RunE: runProgress,
 }
@@ -45,7 +60,13 @@ func init() {
 	progressCmd.Flags().StringVar(&progressMetric, "metric", "weight",
 		"metric to track: weight, volume, reps, 1rm")
 	progressCmd.Flags().StringVar(&progressPeriod, "period", "all",
-		"time period: week, month, year, all")
+		"time period: week, month, year, all, or a date expression")
+	progressCmd.Flags().StringVar(&progressSince, "since", "", "Start date (YYYY-MM-DD, monday, 3w, 2026-W14, ...)")
+	progressCmd.Flags().StringVar(&progressUntil, "until", "", "End date, inclusive (same formats as --since)")
+	progressCmd.Flags().StringVar(&progressFormula, "formula", "",
+		"1RM formula: "+strings.Join(analysis.FormulaNames(), ", ")+" (default from config)")
+
+	schema.Register("stats-progress", "Progress of an exercise over time", ProgressData{})
 }
 
 // ProgressData holds exercise progress data
@@ -53,14 +74,17 @@ type ProgressData struct {
 	Exercise   string           `json:"exercise"`
 	Metric     string           `json:"metric"`
 	Unit       string           `json:"unit"`
+	Formula    string           `json:"formula,omitempty"`
 	DataPoints []ProgressPoint  `json:"data_points"`
 	Analysis   ProgressAnalysis `json:"analysis"`
 }
 
 // ProgressPoint is a single data point
 type ProgressPoint struct {
-	Date  string  `json:"date"`
-	Value float64 `json:"value"`
+	Date    string  `json:"date"`
+	Value   float64 `json:"value"`
+	Reps    int     `json:"reps,omitempty"`
+	Formula string  `json:"formula,omitempty"`
 }
 
 // ProgressAnalysis contains trend analysis
@@ -85,7 +109,7 @@ func runProgress(cmd *cobra.Command, args []string) error {
 
 		apiKey := cfg.GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+			return cmdutil.ErrNoAPIKey
 		}
 
 		client := api.NewClient(apiKey)
@@ -113,7 +137,7 @@ func runProgress(cmd *cobra.Command, args []string) error {
 					options[i] = prompt.SelectOption{
 						ID:          ex.Title, // Use title as ID since we need the name
 						Title:       ex.Title,
-						Description: ex.PrimaryMuscleGroup + " • " + ex.Equipment,
+						Description: ex.Summary(),
 					}
 				}
 				return options, nil
@@ -132,7 +156,7 @@ func runProgress(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -149,38 +173,38 @@ func runProgress(cmd *cobra.Command, args []string) error {
 		Writer:  os.Stdout,
 	})
 
+	// Resolve the 1RM estimator (flag > config > default)
+	formula := cfg.Stats.OneRMFormula
+	if cmd.Flags().Changed("formula") {
+		formula = progressFormula
+	}
+	estimator, err := analysis.NewEstimator(formula)
+	if err != nil {
+		return err
+	}
+
 	// Calculate date range
-	now := time.Now()
-	var startDate time.Time
-	switch progressPeriod {
-	case "week":
-		startDate = now.AddDate(0, 0, -7)
-	case "month":
-		startDate = now.AddDate(0, -1, 0)
-	case "year":
-		startDate = now.AddDate(-1, 0, 0)
-	case "all":
-		startDate = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
-	default:
-		return fmt.Errorf("invalid period: %s", progressPeriod)
+	startDate, endDate, err := resolvePeriod(cfg, progressPeriod, progressSince, progressUntil)
+	if err != nil {
+		return err
 	}
 
 	// Fetch all workouts
 	fmt.Fprintln(os.Stderr, "Fetching workout data...")
-	allWorkouts, err := client.GetAllWorkouts()
+	allWorkouts, err := fetchWorkouts(client, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to fetch workouts: %w", err)
+		return err
 	}
 
 	// Find matching exercises and compute metric
-	progressData := computeProgress(allWorkouts, exerciseName, progressMetric, startDate, now)
+	progressData := computeProgress(allWorkouts, exerciseName, progressMetric, estimator, startDate, endDate)
 
 	if len(progressData.DataPoints) == 0 {
 		return fmt.Errorf("no data found for exercise '%s'", exerciseName)
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(progressData)
 		if err != nil {
 			return err
@@ -193,13 +217,14 @@ func runProgress(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func computeProgress(workouts []api.Workout, exerciseName, metric string, start, end time.Time) ProgressData {
+func computeProgress(workouts []api.Workout, exerciseName, metric string, estimator analysis.Estimator, start, end time.Time) ProgressData {
 	var data ProgressData
 	data.Metric = metric
 
 	switch metric {
 	case "1rm":
 		data.Unit = "kg (estimated)"
+		data.Formula = string(estimator.Formula())
 	case "volume":
 		data.Unit = "kg"
 	case "reps":
@@ -210,6 +235,7 @@ func computeProgress(workouts []api.Workout, exerciseName, metric string, start,
 
 	// Collect data points by date
 	dateValues := make(map[string]float64)
+	dateEstimates := make(map[string]analysis.Estimate)
 	var matchedExercise string
 
 	for _, w := range workouts {
@@ -238,7 +264,14 @@ func computeProgress(workouts []api.Workout, exerciseName, metric string, start,
 			case "reps":
 				value = computeMaxReps(ex.Sets)
 			case "1rm":
-				value = computeEstimated1RM(ex.Sets)
+				est, ok := analysis.BestEstimate(estimator, ex.Sets)
+				if !ok {
+					continue
+				}
+				value = est.Value
+				if value > dateValues[dateStr] {
+					dateEstimates[dateStr] = est
+				}
 			}
 
 			// Keep the best value for each date
@@ -261,10 +294,15 @@ func computeProgress(workouts []api.Workout, exerciseName, metric string, start,
 	sort.Strings(dates)
 
 	for _, date := range dates {
-		data.DataPoints = append(data.DataPoints, ProgressPoint{
+		point := ProgressPoint{
 			Date:  date,
 			Value: math.Round(dateValues[date]*100) / 100,
-		})
+		}
+		if est, ok := dateEstimates[date]; ok {
+			point.Reps = est.Reps
+			point.Formula = string(est.Formula)
+		}
+		data.DataPoints = append(data.DataPoints, point)
 	}
 
 	// Compute analysis
@@ -326,32 +364,13 @@ func computeMaxReps(sets []api.Set) float64 {
 	return float64(maxReps)
 }
 
-// computeEstimated1RM uses the Brzycki formula: 1RM = weight × (36 / (37 - reps))
-func computeEstimated1RM(sets []api.Set) float64 {
-	var max1RM float64
-	for _, set := range sets {
-		if set.WeightKg == nil || set.Reps == nil || *set.Reps == 0 {
-			continue
-		}
-		weight := *set.WeightKg
-		reps := *set.Reps
-
-		// Brzycki formula works best for reps <= 10
-		if reps > 10 {
-			continue
-		}
-
-		estimated := weight * (36.0 / (37.0 - float64(reps)))
-		if estimated > max1RM {
-			max1RM = estimated
-		}
-	}
-	return max1RM
-}
-
 func printProgressTable(data ProgressData) {
 	fmt.Printf("\n📈 Progress: %s\n", data.Exercise)
-	fmt.Printf("   Metric: %s (%s)\n\n", data.Metric, data.Unit)
+	fmt.Printf("   Metric: %s (%s)\n", data.Metric, data.Unit)
+	if data.Formula != "" {
+		fmt.Printf("   Formula: %s\n", data.Formula)
+	}
+	fmt.Println()
 
 	// Show data points
 	fmt.Println("   Date         Value")
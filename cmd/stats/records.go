@@ -2,20 +2,25 @@ package stats
 
 import (
 	"fmt"
-	"math"
 	"os"
-	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/obay/hevycli/internal/analysis"
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
 	recordsExercise string
 	recordsLimit    int
+	recordsFormula  string
+	recordsType     string
+	recordsHistory  bool
 )
 
 var recordsCmd = &cobra.Command{
@@ -24,14 +29,26 @@ var recordsCmd = &cobra.Command{
 	Long: `Display your personal records across all exercises or for a specific exercise.
 
 Record types:
-  - Max weight lifted
-  - Estimated 1RM (one-rep max)
-  - Max volume in a single session
+  weight          Max weight lifted
+  estimated_1rm   Estimated 1RM (one-rep max, see --formula)
+  rep_max         Best weight for exactly 1-12 reps (rep-max table)
+  session_volume  Max volume (weight × reps) in a single session
+  set_volume      Max volume in a single set
+  duration        Longest set duration
+  distance        Longest set distance
+  pace            Fastest pace (for distance + duration sets)
+
+Records are grouped by exercise, strongest exercises first. Use --history
+to show every time each record was improved.
 
 Examples:
-  hevycli stats records                        # Top 10 PRs
-  hevycli stats records --limit 20             # Top 20 PRs
-  hevycli stats records --exercise "Bench"     # Bench press PRs only`,
+  hevycli stats records                        # PRs for the top 10 exercises
+  hevycli stats records --limit 20             # PRs for the top 20 exercises
+  hevycli stats records --exercise "Bench"     # Bench press PRs only
+  hevycli stats records --type rep_max         # Rep-max table only
+  hevycli stats records --type weight,pace     # Selected record types
+  hevycli stats records --exercise "Squat" --history  # How each PR evolved
+  hevycli stats records --formula rpe          # Use RPE-based 1RM estimates`,
 	RunE: runRecords,
 }
 
@@ -39,23 +56,20 @@ func init() {
 	recordsCmd.Flags().StringVar(&recordsExercise, "exercise", "",
 		"filter by exercise name")
 	recordsCmd.Flags().IntVar(&recordsLimit, "limit", 10,
-		"number of records to show")
-}
-
-// PersonalRecord represents a personal record
-type PersonalRecord struct {
-	Exercise   string  `json:"exercise"`
-	RecordType string  `json:"record_type"`
-	Value      float64 `json:"value"`
-	Unit       string  `json:"unit"`
-	Reps       int     `json:"reps,omitempty"`
-	Date       string  `json:"date"`
-	WorkoutID  string  `json:"workout_id"`
+		"number of exercises to show (0 for all)")
+	recordsCmd.Flags().StringVar(&recordsFormula, "formula", "",
+		"1RM formula: "+strings.Join(analysis.FormulaNames(), ", ")+" (default from config)")
+	recordsCmd.Flags().StringVar(&recordsType, "type", "",
+		"comma-separated record types to show (default: all)")
+	recordsCmd.Flags().BoolVar(&recordsHistory, "history", false,
+		"show how each record evolved over time")
+
+	schema.Register("stats-records", "Personal records", RecordsData{})
 }
 
 // RecordsData holds all personal records
 type RecordsData struct {
-	PersonalRecords []PersonalRecord `json:"personal_records"`
+	PersonalRecords []analysis.Record `json:"personal_records"`
 }
 
 func runRecords(cmd *cobra.Command, args []string) error {
@@ -66,7 +80,7 @@ func runRecords(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -83,15 +97,34 @@ func runRecords(cmd *cobra.Command, args []string) error {
 		Writer:  os.Stdout,
 	})
 
+	// Resolve the 1RM estimator (flag > config > default)
+	formula := cfg.Stats.OneRMFormula
+	if cmd.Flags().Changed("formula") {
+		formula = recordsFormula
+	}
+	estimator, err := analysis.NewEstimator(formula)
+	if err != nil {
+		return err
+	}
+
+	types, err := analysis.ParseRecordTypes(recordsType)
+	if err != nil {
+		return err
+	}
+
 	// Fetch all workouts
 	fmt.Fprintln(os.Stderr, "Fetching workout data...")
-	allWorkouts, err := client.GetAllWorkouts()
+	allWorkouts, err := fetchWorkouts(client, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to fetch workouts: %w", err)
+		return err
 	}
 
 	// Compute records
-	records := computeRecords(allWorkouts, recordsExercise, recordsLimit)
+	book := analysis.ComputeRecords(allWorkouts, estimator)
+	records := RecordsData{
+		PersonalRecords: analysis.LimitExercises(
+			book.Records(recordsExercise, types, recordsHistory), recordsLimit),
+	}
 
 	if len(records.PersonalRecords) == 0 {
 		fmt.Println("No personal records found.")
@@ -99,210 +132,40 @@ func runRecords(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(records)
 		if err != nil {
 			return err
 		}
 		fmt.Println(out)
 	} else {
-		printRecordsTable(records)
+		printRecordsTable(records, cfg.Display.Units == "imperial")
 	}
 
 	return nil
 }
 
-type exerciseRecord struct {
-	maxWeight     float64
-	maxWeightReps int
-	maxWeightDate string
-	maxWeightWID  string
-
-	max1RM     float64
-	max1RMReps int
-	max1RMDate string
-	max1RMWID  string
-
-	maxVolume     float64
-	maxVolumeDate string
-	maxVolumeWID  string
-}
-
-func computeRecords(workouts []api.Workout, exerciseFilter string, limit int) RecordsData {
-	// Track best records per exercise
-	exerciseRecords := make(map[string]*exerciseRecord)
-
-	for _, w := range workouts {
-		dateStr := w.StartTime.Format("2006-01-02")
-
-		for _, ex := range w.Exercises {
-			// Apply exercise filter if specified
-			if exerciseFilter != "" {
-				if !containsIgnoreCase(ex.Title, exerciseFilter) {
-					continue
-				}
-			}
-
-			if exerciseRecords[ex.Title] == nil {
-				exerciseRecords[ex.Title] = &exerciseRecord{}
-			}
-			rec := exerciseRecords[ex.Title]
-
-			// Calculate session metrics
-			var sessionVolume float64
-			var sessionMaxWeight float64
-			var sessionMaxWeightReps int
-			var session1RM float64
-			var session1RMReps int
-
-			for _, set := range ex.Sets {
-				if set.WeightKg == nil {
-					continue
-				}
-				weight := *set.WeightKg
-				reps := 0
-				if set.Reps != nil {
-					reps = *set.Reps
-				}
-
-				// Max weight
-				if weight > sessionMaxWeight {
-					sessionMaxWeight = weight
-					sessionMaxWeightReps = reps
-				}
-
-				// Volume
-				if reps > 0 {
-					sessionVolume += weight * float64(reps)
-				}
-
-				// Estimated 1RM (Brzycki formula)
-				if reps > 0 && reps <= 10 {
-					estimated := weight * (36.0 / (37.0 - float64(reps)))
-					if estimated > session1RM {
-						session1RM = estimated
-						session1RMReps = reps
-					}
-				}
-			}
-
-			// Update records
-			if sessionMaxWeight > rec.maxWeight {
-				rec.maxWeight = sessionMaxWeight
-				rec.maxWeightReps = sessionMaxWeightReps
-				rec.maxWeightDate = dateStr
-				rec.maxWeightWID = w.ID
-			}
-
-			if session1RM > rec.max1RM {
-				rec.max1RM = session1RM
-				rec.max1RMReps = session1RMReps
-				rec.max1RMDate = dateStr
-				rec.max1RMWID = w.ID
-			}
-
-			if sessionVolume > rec.maxVolume {
-				rec.maxVolume = sessionVolume
-				rec.maxVolumeDate = dateStr
-				rec.maxVolumeWID = w.ID
-			}
-		}
-	}
-
-	// Convert to PersonalRecord slice
-	var allRecords []PersonalRecord
-
-	for exercise, rec := range exerciseRecords {
-		if rec.maxWeight > 0 {
-			allRecords = append(allRecords, PersonalRecord{
-				Exercise:   exercise,
-				RecordType: "weight",
-				Value:      math.Round(rec.maxWeight*10) / 10,
-				Unit:       "kg",
-				Reps:       rec.maxWeightReps,
-				Date:       rec.maxWeightDate,
-				WorkoutID:  rec.maxWeightWID,
-			})
-		}
-
-		if rec.max1RM > 0 {
-			allRecords = append(allRecords, PersonalRecord{
-				Exercise:   exercise,
-				RecordType: "estimated_1rm",
-				Value:      math.Round(rec.max1RM*10) / 10,
-				Unit:       "kg",
-				Reps:       rec.max1RMReps,
-				Date:       rec.max1RMDate,
-				WorkoutID:  rec.max1RMWID,
-			})
-		}
-	}
-
-	// Sort by value descending
-	sort.Slice(allRecords, func(i, j int) bool {
-		return allRecords[i].Value > allRecords[j].Value
-	})
-
-	// Apply limit
-	if len(allRecords) > limit {
-		allRecords = allRecords[:limit]
-	}
-
-	return RecordsData{PersonalRecords: allRecords}
-}
-
-func containsIgnoreCase(s, substr string) bool {
-	return len(s) >= len(substr) &&
-		(s == substr ||
-			len(substr) == 0 ||
-			(len(s) > 0 && containsLower(toLower(s), toLower(substr))))
-}
-
-func containsLower(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
-func toLower(s string) string {
-	b := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			c += 'a' - 'A'
-		}
-		b[i] = c
-	}
-	return string(b)
-}
-
-func printRecordsTable(data RecordsData) {
+func printRecordsTable(data RecordsData, imperial bool) {
 	fmt.Println("\n🏆 Personal Records")
 
-	fmt.Println("   Exercise                          Type          Value        Date")
-	fmt.Println("   ─────────────────────────────────────────────────────────────────────")
-
+	currentExercise := ""
 	for _, pr := range data.PersonalRecords {
-		exercise := pr.Exercise
-		if len(exercise) > 32 {
-			exercise = exercise[:29] + "..."
+		if pr.Exercise != currentExercise {
+			currentExercise = pr.Exercise
+			fmt.Printf("\n   %s\n", pr.Exercise)
+			fmt.Println("   ─────────────────────────────────────────────────────────────")
 		}
 
-		recordType := pr.RecordType
-		if recordType == "estimated_1rm" {
-			recordType = "est. 1RM"
-		}
+		fmt.Printf("   %-16s  %-22s  %s\n",
+			analysis.RecordLabel(pr), analysis.FormatRecordValue(pr.RecordType, pr.Value, pr.Reps, imperial), pr.Date)
 
-		valueStr := fmt.Sprintf("%.1f %s", pr.Value, pr.Unit)
-		if pr.Reps > 0 && pr.RecordType == "weight" {
-			valueStr = fmt.Sprintf("%.1f %s × %d", pr.Value, pr.Unit, pr.Reps)
+		for i, h := range pr.History {
+			if i == len(pr.History)-1 {
+				break
+			}
+			fmt.Printf("     %-14s  %-22s  %s\n",
+				"↳", analysis.FormatRecordValue(pr.RecordType, h.Value, h.Reps, imperial), h.Date)
 		}
-
-		fmt.Printf("   %-32s  %-12s  %-12s  %s\n",
-			exercise, recordType, valueStr, pr.Date)
 	}
 	fmt.Println()
 }
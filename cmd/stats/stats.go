@@ -1,6 +1,10 @@
 package stats
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/query"
+)
 
 // Cmd is the stats command
 var Cmd = &cobra.Command{
@@ -12,11 +16,22 @@ Examples:
   hevycli stats summary                    # Monthly workout summary
   hevycli stats summary --period week      # Weekly summary
   hevycli stats progress "Bench Press"     # Track bench press progress
-  hevycli stats records                    # View personal records`,
+  hevycli stats records                    # View personal records
+  hevycli stats calendar                   # Training heatmap and streaks
+  hevycli stats summary --where 'muscle ~ legs'  # Only leg sessions
+
+--where limits every stats command to workouts matching an expression.
+Conditions compare fields using = != ~ (contains) !~ > >= < <= and are
+combined with and, or, not and parentheses. Fields:
+` + query.FieldHelp(),
 }
 
 func init() {
+	Cmd.PersistentFlags().StringVar(&statsWhere, "where", "",
+		"only include workouts matching an expression, e.g. 'exercise ~ squat and duration > 60m'")
+
 	Cmd.AddCommand(summaryCmd)
 	Cmd.AddCommand(progressCmd)
 	Cmd.AddCommand(recordsCmd)
+	Cmd.AddCommand(calendarCmd)
 }
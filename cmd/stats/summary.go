@@ -8,13 +8,18 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/obay/hevycli/internal/analysis"
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
 	summaryPeriod string
+	summarySince  string
+	summaryUntil  string
 )
 
 var summaryCmd = &cobra.Command{
@@ -22,22 +27,25 @@ var summaryCmd = &cobra.Command{
 	Short: "Show workout summary statistics",
 	Long: `Display summary statistics for your workouts over a specified period.
 
-Periods:
-  week    - Last 7 days
-  month   - Last 30 days (default)
-  year    - Last 365 days
-  all     - All time
+` + periodHelp + `
 
 Examples:
-  hevycli stats summary                # Last 30 days
+  hevycli stats summary                # Since the same day last month
   hevycli stats summary --period week  # Last 7 days
-  hevycli stats summary --period all   # All time statistics`,
+  hevycli stats summary --period all   # All time statistics
+  hevycli stats summary --period 90d   # Last 90 days
+  hevycli stats summary --period "last month"
+  hevycli stats summary --since 2026-01 --until 2026-03`,
 	RunE: runSummary,
 }
 
 func init() {
 	summaryCmd.Flags().StringVar(&summaryPeriod, "period", "month",
-		"time period: week, month, year, all")
+		"time period: week, month, year, all, or a date expression")
+	summaryCmd.Flags().StringVar(&summarySince, "since", "", "Start date (YYYY-MM-DD, monday, 3w, 2026-W14, ...)")
+	summaryCmd.Flags().StringVar(&summaryUntil, "until", "", "End date, inclusive (same formats as --since)")
+
+	schema.Register("stats-summary", "Training summary for a period", SummaryStats{})
 }
 
 // SummaryStats holds computed statistics
@@ -81,7 +89,7 @@ func runSummary(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -99,41 +107,31 @@ func runSummary(cmd *cobra.Command, args []string) error {
 	})
 
 	// Calculate date range based on period
-	now := time.Now()
-	var startDate time.Time
-	switch summaryPeriod {
-	case "week":
-		startDate = now.AddDate(0, 0, -7)
-	case "month":
-		startDate = now.AddDate(0, -1, 0)
-	case "year":
-		startDate = now.AddDate(-1, 0, 0)
-	case "all":
-		startDate = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
-	default:
-		return fmt.Errorf("invalid period: %s (use week, month, year, or all)", summaryPeriod)
+	startDate, endDate, err := resolvePeriod(cfg, summaryPeriod, summarySince, summaryUntil)
+	if err != nil {
+		return err
 	}
 
 	// Fetch all workouts
 	fmt.Fprintln(os.Stderr, "Fetching workout data...")
-	allWorkouts, err := client.GetAllWorkouts()
+	allWorkouts, err := fetchWorkouts(client, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to fetch workouts: %w", err)
+		return err
 	}
 
 	// Filter workouts by date range
 	var workouts []api.Workout
 	for _, w := range allWorkouts {
-		if w.StartTime.After(startDate) && w.StartTime.Before(now) {
+		if !w.StartTime.Before(startDate) && !w.StartTime.After(endDate) {
 			workouts = append(workouts, w)
 		}
 	}
 
 	// Compute statistics
-	stats := computeSummaryStats(workouts, startDate, now)
+	stats := computeSummaryStats(workouts, startDate, endDate)
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(stats)
 		if err != nil {
 			return err
@@ -228,67 +226,11 @@ func computeSummaryStats(workouts []api.Workout, start, end time.Time) SummarySt
 	}
 
 	// Calculate streaks
-	stats.Consistency.LongestStreakDays, stats.Consistency.CurrentStreakDays = calculateStreaks(workoutDates, end)
+	stats.Consistency.LongestStreakDays, stats.Consistency.CurrentStreakDays = analysis.DayStreaks(workoutDates, end)
 
 	return stats
 }
 
-func calculateStreaks(workoutDates map[string]bool, endDate time.Time) (longest, current int) {
-	if len(workoutDates) == 0 {
-		return 0, 0
-	}
-
-	// Convert to sorted slice of dates
-	var dates []time.Time
-	for dateStr := range workoutDates {
-		t, err := time.Parse("2006-01-02", dateStr)
-		if err == nil {
-			dates = append(dates, t)
-		}
-	}
-	sort.Slice(dates, func(i, j int) bool {
-		return dates[i].Before(dates[j])
-	})
-
-	// Calculate longest streak
-	currentStreak := 1
-	longestStreak := 1
-
-	for i := 1; i < len(dates); i++ {
-		diff := dates[i].Sub(dates[i-1]).Hours() / 24
-		if diff <= 1 {
-			currentStreak++
-			if currentStreak > longestStreak {
-				longestStreak = currentStreak
-			}
-		} else {
-			currentStreak = 1
-		}
-	}
-
-	// Calculate current streak (from today going back)
-	today := endDate.Format("2006-01-02")
-	yesterday := endDate.AddDate(0, 0, -1).Format("2006-01-02")
-
-	if !workoutDates[today] && !workoutDates[yesterday] {
-		return longestStreak, 0
-	}
-
-	currentActiveStreak := 0
-	checkDate := endDate
-	for {
-		dateStr := checkDate.Format("2006-01-02")
-		if workoutDates[dateStr] {
-			currentActiveStreak++
-			checkDate = checkDate.AddDate(0, 0, -1)
-		} else {
-			break
-		}
-	}
-
-	return longestStreak, currentActiveStreak
-}
-
 func printSummaryTable(stats SummaryStats, formatter output.Formatter) {
 	fmt.Printf("\n📊 Workout Summary (%s to %s)\n\n", stats.Period.Start, stats.Period.End)
 
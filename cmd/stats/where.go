@@ -0,0 +1,43 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/query"
+)
+
+var statsWhere string
+
+// fetchWorkouts fetches all workouts and applies the --where filter
+func fetchWorkouts(client *api.Client, cfg *config.Config) ([]api.Workout, error) {
+	var where *query.Query
+	if statsWhere != "" {
+		dates, err := cmdutil.NewDateParser(cfg.Display.Timezone, cfg.Display.WeekStart)
+		if err != nil {
+			return nil, err
+		}
+		where, err = query.Parse(statsWhere, query.Options{Dates: dates})
+		if err != nil {
+			return nil, cmdutil.UsageErrorf("invalid --where expression: %w", err)
+		}
+		if where.NeedsTemplates() {
+			templates, err := client.GetAllExerciseTemplates()
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch exercise templates: %w", err)
+			}
+			where.SetTemplates(templates)
+		}
+	}
+
+	workouts, err := client.GetAllWorkouts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workouts: %w", err)
+	}
+	if where != nil {
+		workouts = where.Filter(workouts)
+	}
+	return workouts, nil
+}
@@ -0,0 +1,95 @@
+package workout
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/tui/common"
+)
+
+// workoutWithAchievements is the JSON output of a saved workout with its post-save analysis
+type workoutWithAchievements struct {
+	*api.Workout
+	Achievements *analysis.Achievements `json:"achievements,omitempty"`
+}
+
+// analyzeSavedWorkout compares a freshly saved workout against the user's history.
+// Errors are reported as warnings since the workout itself was saved successfully.
+func analyzeSavedWorkout(client *api.Client, cfg *config.Config, saved *api.Workout) *analysis.Achievements {
+	estimator, err := analysis.NewEstimator(cfg.Stats.OneRMFormula)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping PR analysis: %v\n", err)
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Checking for personal records...")
+	history, err := client.GetAllWorkouts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping PR analysis: %v\n", err)
+		return nil
+	}
+
+	// Prefer the server copy, which includes exercise titles
+	workout := *saved
+	for _, w := range history {
+		if w.ID == saved.ID {
+			workout = w
+			break
+		}
+	}
+
+	return analysis.AnalyzeWorkout(history, workout, estimator)
+}
+
+// printAchievements renders the post-save summary panel
+func printAchievements(a *analysis.Achievements, cfg *config.Config) {
+	if a == nil {
+		return
+	}
+
+	imperial := cfg.Display.Units == "imperial"
+	var b strings.Builder
+	if a.HasAny() {
+		b.WriteString("🎉 Workout Achievements\n")
+	} else {
+		b.WriteString("Workout Summary\n")
+	}
+
+	fmt.Fprintf(&b, "\nVolume: %s", analysis.FormatWeight(a.VolumeKg, imperial))
+	if prev := a.PreviousSession; prev != nil {
+		fmt.Fprintf(&b, "\nvs. last session (%s): %s (%+.1f%%)",
+			prev.Date, analysis.FormatWeightChange(prev.VolumeChangeKg, imperial), prev.PercentChange)
+	}
+
+	if len(a.NewRecords) > 0 {
+		fmt.Fprintf(&b, "\n\n🏆 New personal records (%d)", len(a.NewRecords))
+		for _, imp := range a.NewRecords {
+			rec := imp.Record
+			line := fmt.Sprintf("\n  %s: %s %s", rec.Exercise, analysis.RecordLabel(rec),
+				analysis.FormatRecordValue(rec.RecordType, rec.Value, rec.Reps, imperial))
+			if imp.Previous != nil {
+				line += fmt.Sprintf(" (was %s)",
+					analysis.FormatRecordValue(rec.RecordType, imp.Previous.Value, imp.Previous.Reps, imperial))
+			}
+			b.WriteString(line)
+		}
+	}
+
+	if len(a.FirstTimeExercises) > 0 {
+		b.WriteString("\n\n✨ First time performed")
+		for _, ex := range a.FirstTimeExercises {
+			fmt.Fprintf(&b, "\n  %s", ex)
+		}
+	}
+
+	fmt.Println()
+	if cfg.Display.Color {
+		fmt.Println(common.FocusedBoxStyle.Render(b.String()))
+	} else {
+		fmt.Println(b.String())
+	}
+}
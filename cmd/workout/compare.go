@@ -0,0 +1,287 @@
+package workout
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	compareLastOfRoutine string
+	compareFormula       string
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <workout-id-1> <workout-id-2>",
+	Short: "Compare two workouts",
+	Long: `Show the differences between two workouts.
+
+Exercises are matched by exercise template and sets by their position.
+For each exercise the per-set weight and reps changes, the volume change and
+the estimated 1RM change are shown, along with exercises that were added or
+removed and the change in workout duration. Changes are relative to the
+first workout.
+
+Use --last-of-routine to compare the two most recent workouts of a routine.
+
+Examples:
+  hevycli workout compare abc123 def456           # Compare two workouts
+  hevycli workout compare --last-of-routine r123  # Latest two of a routine
+  hevycli workout compare abc123 def456 -o json   # Output as JSON`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if compareLastOfRoutine != "" {
+			if len(args) > 0 {
+				return cmdutil.UsageErrorf("workout IDs cannot be combined with --last-of-routine")
+			}
+			return nil
+		}
+		return cmdutil.RequireArgs(2, "<workout-id-1> <workout-id-2>")(cmd, args)
+	},
+	RunE: runCompare,
+}
+
+func init() {
+	compareCmd.Flags().StringVar(&compareLastOfRoutine, "last-of-routine", "",
+		"compare the two most recent workouts of this routine ID")
+	compareCmd.Flags().StringVar(&compareFormula, "formula", "",
+		"1RM formula: "+strings.Join(analysis.FormulaNames(), ", ")+" (default from config)")
+	Cmd.AddCommand(compareCmd)
+
+	schema.Register("workout-compare", "Comparison of two workouts", analysis.WorkoutComparison{})
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	client := api.NewClient(apiKey)
+
+	formula := cfg.Stats.OneRMFormula
+	if cmd.Flags().Changed("formula") {
+		formula = compareFormula
+	}
+	estimator, err := analysis.NewEstimator(formula)
+	if err != nil {
+		return err
+	}
+
+	var base, target *api.Workout
+	if compareLastOfRoutine != "" {
+		base, target, err = lastTwoOfRoutine(client, compareLastOfRoutine)
+		if err != nil {
+			return err
+		}
+	} else {
+		ids := append([]string(nil), args...)
+		for len(ids) < 2 {
+			// Interactive mode - let user select the missing workouts
+			title := "Select the first workout"
+			if len(ids) == 1 {
+				title = "Select the workout to compare against"
+			}
+			selected, err := prompt.SearchSelect(prompt.SearchSelectConfig{
+				Title:       title,
+				Placeholder: "Search workouts...",
+				Help:        "Type to filter by workout title",
+				LoadFunc: func() ([]prompt.SelectOption, error) {
+					workouts, err := client.GetWorkouts(1, 20)
+					if err != nil {
+						return nil, err
+					}
+					options := make([]prompt.SelectOption, len(workouts.Workouts))
+					for i, w := range workouts.Workouts {
+						options[i] = prompt.SelectOption{
+							ID:          w.ID,
+							Title:       w.Title,
+							Description: w.StartTime.Format("Jan 2, 2006") + " • " + fmt.Sprintf("%d exercises", len(w.Exercises)),
+						}
+					}
+					return options, nil
+				},
+			})
+			if err != nil {
+				return err
+			}
+			ids = append(ids, selected.ID)
+		}
+
+		if base, err = client.GetWorkout(ids[0]); err != nil {
+			return fmt.Errorf("failed to fetch workout %s: %w", ids[0], err)
+		}
+		if target, err = client.GetWorkout(ids[1]); err != nil {
+			return fmt.Errorf("failed to fetch workout %s: %w", ids[1], err)
+		}
+	}
+
+	comparison := analysis.CompareWorkouts(*base, *target, estimator)
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(comparison)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+	} else {
+		printComparison(comparison, cfg, formatter)
+	}
+
+	return nil
+}
+
+// lastTwoOfRoutine finds the two most recent workouts performed from a routine,
+// matching by routine ID and falling back to the routine title
+func lastTwoOfRoutine(client *api.Client, routineID string) (*api.Workout, *api.Workout, error) {
+	fmt.Fprintln(os.Stderr, "Fetching workout data...")
+	workouts, err := client.GetAllWorkouts()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch workouts: %w", err)
+	}
+
+	var matches []api.Workout
+	for _, w := range workouts {
+		if w.RoutineID == routineID {
+			matches = append(matches, w)
+		}
+	}
+
+	if len(matches) < 2 {
+		routine, err := client.GetRoutine(routineID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch routine: %w", err)
+		}
+		matches = matches[:0]
+		for _, w := range workouts {
+			if strings.EqualFold(w.Title, routine.Title) {
+				matches = append(matches, w)
+			}
+		}
+	}
+
+	if len(matches) < 2 {
+		return nil, nil, fmt.Errorf("need at least two workouts of routine %s to compare, found %d", routineID, len(matches))
+	}
+
+	sorted := analysis.SortedByStart(matches)
+	return &sorted[len(sorted)-2], &sorted[len(sorted)-1], nil
+}
+
+func printComparison(c *analysis.WorkoutComparison, cfg *config.Config, formatter output.Formatter) {
+	imperial := cfg.Display.Units == "imperial"
+	fmt.Printf("Comparing: %s (%s) → %s (%s)\n", c.Base.Title, c.Base.Date, c.Target.Title, c.Target.Date)
+	fmt.Printf("Duration: %s → %s (%s)\n",
+		formatDuration(seconds(c.Base.DurationSeconds)), formatDuration(seconds(c.Target.DurationSeconds)),
+		signedDuration(c.DurationChangeSeconds))
+	fmt.Printf("Volume: %s → %s (%s, %+.1f%%)\n",
+		analysis.FormatWeight(c.Base.VolumeKg, imperial), analysis.FormatWeight(c.Target.VolumeKg, imperial),
+		analysis.FormatWeightChange(c.VolumeChangeKg, imperial), c.VolumePercentChange)
+
+	if len(c.Added) > 0 {
+		fmt.Printf("Added: %s\n", strings.Join(c.Added, ", "))
+	}
+	if len(c.Removed) > 0 {
+		fmt.Printf("Removed: %s\n", strings.Join(c.Removed, ", "))
+	}
+
+	fmt.Println(strings.Repeat("-", 60))
+
+	for _, ex := range c.Exercises {
+		fmt.Printf("\n%s", ex.Exercise)
+		switch ex.Status {
+		case analysis.ExerciseAdded:
+			fmt.Print(" (added)")
+		case analysis.ExerciseRemoved:
+			fmt.Print(" (removed)")
+		}
+		fmt.Println()
+
+		summary := fmt.Sprintf("   Volume: %s", analysis.FormatWeightChange(ex.VolumeChangeKg, imperial))
+		if ex.E1RMChange != nil {
+			summary += fmt.Sprintf("   e1RM: %s → %s (%s)",
+				analysis.FormatWeight(*ex.BaseE1RM, imperial), analysis.FormatWeight(*ex.TargetE1RM, imperial), analysis.FormatWeightChange(*ex.E1RMChange, imperial))
+		}
+		fmt.Println(summary)
+
+		table := output.NewSimpleTable([]string{"Set", "Before", "After", "Weight Δ", "Reps Δ"})
+		for _, s := range ex.Sets {
+			weightDelta, repsDelta := "-", "-"
+			if s.WeightChangeKg != nil {
+				weightDelta = analysis.FormatWeightChange(*s.WeightChangeKg, imperial)
+			}
+			if s.RepsChange != nil {
+				repsDelta = fmt.Sprintf("%+d", *s.RepsChange)
+			}
+			table.AddRow(fmt.Sprintf("%d", s.Index+1), formatSetValues(s.Base, imperial), formatSetValues(s.Target, imperial), weightDelta, repsDelta)
+		}
+
+		out, _ := formatter.Format(table)
+		// Indent the table
+		for _, line := range strings.Split(out, "\n") {
+			fmt.Printf("   %s\n", line)
+		}
+	}
+}
+
+func formatSetValues(s *analysis.SetValues, imperial bool) string {
+	if s == nil {
+		return "-"
+	}
+	var parts []string
+	if s.WeightKg != nil && s.Reps != nil {
+		parts = append(parts, fmt.Sprintf("%s × %d", analysis.FormatWeight(*s.WeightKg, imperial), *s.Reps))
+	} else if s.Reps != nil {
+		parts = append(parts, fmt.Sprintf("%d reps", *s.Reps))
+	} else if s.WeightKg != nil {
+		parts = append(parts, analysis.FormatWeight(*s.WeightKg, imperial))
+	}
+	if s.DistanceMeters != nil {
+		parts = append(parts, fmt.Sprintf("%.0f m", *s.DistanceMeters))
+	}
+	if s.DurationSeconds != nil {
+		parts = append(parts, analysis.FormatSeconds(float64(*s.DurationSeconds)))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func signedDuration(s int) string {
+	if s < 0 {
+		return "-" + formatDuration(seconds(-s))
+	}
+	return "+" + formatDuration(seconds(s))
+}
+
+func seconds(s int) time.Duration {
+	return time.Duration(s) * time.Second
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var countCmd = &cobra.Command{
@@ -22,6 +24,10 @@ Examples:
 	RunE: runCount,
 }
 
+func init() {
+	schema.Register("workout-count", "Total number of workouts", api.WorkoutCountResponse{})
+}
+
 func runCount(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load("")
 	if err != nil {
@@ -30,7 +36,7 @@ func runCount(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -52,11 +58,8 @@ func runCount(cmd *cobra.Command, args []string) error {
 		Writer:  os.Stdout,
 	})
 
-	if outputFmt == "json" {
-		result := map[string]interface{}{
-			"workout_count": count,
-		}
-		out, err := formatter.Format(result)
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(api.WorkoutCountResponse{WorkoutCount: count})
 		if err != nil {
 			return err
 		}
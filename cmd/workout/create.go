@@ -8,15 +8,19 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/obay/hevycli/internal/analysis"
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
 	createFile      string
 	createTitle     string
 	createPrivate   bool
+	createNoAnalyze bool
 )
 
 var createCmd = &cobra.Command{
@@ -45,9 +49,14 @@ The JSON file should contain the workout data in the following format:
   }
 }
 
+After the workout is created it is compared against your history to detect
+new personal records and the volume change since the previous session of
+the same routine. Use --no-analyze to skip this step.
+
 Examples:
   hevycli workout create --file workout.json           # Create from JSON file
-  hevycli workout create --file workout.json -o json   # Output as JSON`,
+  hevycli workout create --file workout.json -o json   # Output as JSON
+  hevycli workout create --file workout.json --no-analyze  # Skip PR detection`,
 	RunE: runCreate,
 }
 
@@ -55,7 +64,10 @@ func init() {
 	createCmd.Flags().StringVarP(&createFile, "file", "f", "", "JSON file with workout data (required)")
 	createCmd.Flags().StringVar(&createTitle, "title", "", "Workout title (overrides file)")
 	createCmd.Flags().BoolVar(&createPrivate, "private", false, "Make workout private")
+	createCmd.Flags().BoolVar(&createNoAnalyze, "no-analyze", false, "Skip personal record detection after saving")
 	createCmd.MarkFlagRequired("file")
+
+	schema.Register("workout-create", "The created workout and any records it set", workoutWithAchievements{})
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
@@ -66,7 +78,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -110,9 +122,15 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create workout: %w", err)
 	}
 
+	// Compare against history for new PRs
+	var achievements *analysis.Achievements
+	if !createNoAnalyze {
+		achievements = analyzeSavedWorkout(client, cfg, workout)
+	}
+
 	// Format output
-	if outputFmt == "json" {
-		out, err := formatter.Format(workout)
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(workoutWithAchievements{Workout: workout, Achievements: achievements})
 		if err != nil {
 			return err
 		}
@@ -124,6 +142,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Start: %s\n", workout.StartTime.Format(time.RFC3339))
 		fmt.Printf("End: %s\n", workout.EndTime.Format(time.RFC3339))
 		fmt.Printf("Exercises: %d\n", len(workout.Exercises))
+		printAchievements(achievements, cfg)
 	}
 
 	return nil
@@ -9,32 +9,73 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/bulk"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/query"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
 var (
-	deleteForce bool
+	deleteForce       bool
+	deleteWhere       string
+	deleteSince       string
+	deleteUntil       string
+	deleteMatch       string
+	deleteDryRun      bool
+	deleteConcurrency int
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete <workout-id>",
+	Use:   "delete [workout-id]",
 	Short: "Delete a workout",
-	Long: `Delete a workout by ID.
+	Long: `Delete a workout by ID, or many workouts selected with filters.
 
 By default, you will be prompted to confirm the deletion.
 Use --force to skip the confirmation prompt.
 
+With --where, --since, --until or --match, every matching workout is deleted.
+The selected workouts are shown first and a single confirmation is asked.
+Deletions run a few at a time (--concurrency) and the outcome for each
+workout is reported; structured output formats get a per-item report.
+--match selects titles with a glob pattern where * matches any text.
+
 Examples:
   hevycli workout delete <id>           # Delete with confirmation
-  hevycli workout delete <id> --force   # Delete without confirmation`,
-	Args: cmdutil.RequireArgs(1, "<workout-id>"),
+  hevycli workout delete <id> --force   # Delete without confirmation
+  hevycli workout delete --where 'title ~ "test"' --since 2025-01-01
+  hevycli workout delete --match "Imported*" --dry-run   # Preview only
+  hevycli workout delete --match "Test *" --force -o json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if deleteBulk() {
+			if len(args) > 0 {
+				return fmt.Errorf("a workout ID cannot be combined with --where, --since, --until or --match")
+			}
+			return nil
+		}
+		return cmdutil.RequireArgs(1, "<workout-id>")(cmd, args)
+	},
 	RunE: runDelete,
 }
 
 func init() {
 	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "Skip confirmation prompt")
+	deleteCmd.Flags().StringVar(&deleteWhere, "where", "", "Delete workouts matching an expression (see 'workout list --help')")
+	deleteCmd.Flags().StringVar(&deleteSince, "since", "", "Delete workouts since date")
+	deleteCmd.Flags().StringVar(&deleteUntil, "until", "", "Delete workouts until date, inclusive")
+	deleteCmd.Flags().StringVar(&deleteMatch, "match", "", "Delete workouts whose title matches a glob pattern")
+	deleteCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "Show the selected workouts without deleting them")
+	deleteCmd.Flags().IntVar(&deleteConcurrency, "concurrency", bulk.DefaultConcurrency, "Number of deletions to run at once")
+
+	schema.Register("workout-delete", "Per-workout results of a bulk delete", bulk.Report{})
+}
+
+// deleteBulk reports whether workouts are selected with filters
+func deleteBulk() bool {
+	return deleteWhere != "" || deleteSince != "" || deleteUntil != "" || deleteMatch != ""
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
@@ -45,11 +86,15 @@ func runDelete(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
 
+	if deleteBulk() {
+		return runBulkDelete(cmd, cfg, client)
+	}
+
 	var workoutID string
 	if len(args) > 0 {
 		workoutID = args[0]
@@ -90,7 +135,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	// Confirm deletion unless --force is used
 	if !deleteForce {
 		fmt.Printf("Are you sure you want to delete workout '%s' (%s)?\n", workout.Title, workout.ID)
-		fmt.Printf("This action cannot be undone.\n")
+		fmt.Printf("It can be restored later with 'hevycli undo'.\n")
 		fmt.Print("Type 'yes' to confirm: ")
 
 		reader := bufio.NewReader(os.Stdin)
@@ -107,10 +152,116 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Delete the workout
-	if err := client.DeleteWorkout(workoutID); err != nil {
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	err = rec.Track(journal.ActionDelete, workout, func() error {
+		return client.DeleteWorkout(workoutID)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete workout: %w", err)
 	}
 
 	fmt.Printf("Workout '%s' deleted successfully.\n", workout.Title)
+	fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
 	return nil
 }
+
+// runBulkDelete deletes every workout selected by the filter flags
+func runBulkDelete(cmd *cobra.Command, cfg *config.Config, client *api.Client) error {
+	if deleteConcurrency < 1 {
+		return cmdutil.UsageErrorf("--concurrency must be at least 1")
+	}
+
+	dates, err := cmdutil.NewDateParser(cfg.Display.Timezone, cfg.Display.WeekStart)
+	if err != nil {
+		return err
+	}
+	dateRange, err := dates.SinceUntil(deleteSince, deleteUntil)
+	if err != nil {
+		return err
+	}
+
+	var where *query.Query
+	if deleteWhere != "" {
+		where, err = query.Parse(deleteWhere, query.Options{Dates: dates})
+		if err != nil {
+			return cmdutil.UsageErrorf("invalid --where expression: %w", err)
+		}
+		if where.NeedsTemplates() {
+			templates, err := client.GetAllExerciseTemplates()
+			if err != nil {
+				return fmt.Errorf("failed to fetch exercise templates: %w", err)
+			}
+			where.SetTemplates(templates)
+		}
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	// Workouts are returned newest first, so paging stops once they are
+	// older than --since
+	var items []bulk.Item
+	page := 1
+	for {
+		resp, err := client.GetWorkouts(page, 10)
+		if err != nil {
+			return fmt.Errorf("failed to fetch workouts: %w", err)
+		}
+		olderThanSince := false
+		for _, w := range resp.Workouts {
+			if !dateRange.Start.IsZero() && w.StartTime.Before(dateRange.Start) {
+				olderThanSince = true
+				continue
+			}
+			if !dateRange.Contains(w.StartTime) || (where != nil && !where.Match(&w)) {
+				continue
+			}
+			if deleteMatch != "" && !bulk.MatchTitle(deleteMatch, w.Title) {
+				continue
+			}
+			items = append(items, bulk.Item{
+				ID:     w.ID,
+				Title:  w.Title,
+				Detail: w.StartTime.In(dates.Location).Format(cfg.Display.DateFormat),
+			})
+		}
+		if olderThanSince || page >= resp.PageCount || len(resp.Workouts) == 0 {
+			break
+		}
+		page++
+	}
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	return bulk.Execute(bulk.Options{
+		Action:       "delete",
+		Done:         "deleted",
+		Noun:         "workouts",
+		DetailHeader: "Date",
+		Items:        items,
+		Concurrency:  deleteConcurrency,
+		DryRun:       deleteDryRun,
+		Force:        deleteForce,
+		OutputFormat: outputFmt,
+		Formatter:    formatter,
+		NoColor:      !cfg.Display.Color,
+		Operation:    rec.ID(),
+		Do: func(item bulk.Item) (string, error) {
+			workout, err := client.GetWorkout(item.ID)
+			if err != nil {
+				return "", err
+			}
+			return "", rec.Track(journal.ActionDelete, workout, func() error {
+				return client.DeleteWorkout(item.ID)
+			})
+		},
+	})
+}
@@ -0,0 +1,182 @@
+package workout
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/edit"
+	"github.com/obay/hevycli/internal/journal"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	editFormat string
+	editEditor string
+	editYes    bool
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit [workout-id]",
+	Short: "Edit a workout in your editor",
+	Long: `Open a workout in $VISUAL or $EDITOR as a YAML (or JSON) document.
+
+Exercise titles are shown as comments next to their template IDs. When you
+save and close the editor the document is validated, the changes are shown
+as a diff against the current workout, and after confirmation the workout is
+updated. Invalid documents are reopened with the problems listed at the top;
+save an empty file to cancel.
+
+Examples:
+  hevycli workout edit <id>                     # Edit as YAML
+  hevycli workout edit <id> --format json       # Edit as JSON
+  hevycli workout edit <id> --editor "code --wait"
+  hevycli workout edit <id> --yes               # Save without confirming the diff`,
+	Args: cmdutil.RequireArgs(1, "<workout-id>"),
+	RunE: runEdit,
+}
+
+func init() {
+	editCmd.Flags().StringVar(&editFormat, "format", "yaml", "Document format: yaml or json")
+	editCmd.Flags().StringVar(&editEditor, "editor", "", "Editor command (default: $VISUAL, $EDITOR or vi)")
+	editCmd.Flags().BoolVarP(&editYes, "yes", "y", false, "Save without confirming the diff")
+	Cmd.AddCommand(editCmd)
+
+	schema.Register("workout-edit", "The edited workout", api.Workout{})
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	format, err := edit.ParseFormat(editFormat)
+	if err != nil {
+		return cmdutil.UsageErrorf("%w", err)
+	}
+
+	client := api.NewClient(apiKey)
+
+	var workoutID string
+	if len(args) > 0 {
+		workoutID = args[0]
+	} else {
+		// Interactive mode - let user select from recent workouts
+		selected, err := prompt.SearchSelect(prompt.SearchSelectConfig{
+			Title:       "Select Workout to Edit",
+			Placeholder: "Search workouts...",
+			Help:        "Type to filter by workout title",
+			LoadFunc: func() ([]prompt.SelectOption, error) {
+				workouts, err := client.GetWorkouts(1, 20)
+				if err != nil {
+					return nil, err
+				}
+				options := make([]prompt.SelectOption, len(workouts.Workouts))
+				for i, w := range workouts.Workouts {
+					options[i] = prompt.SelectOption{
+						ID:          w.ID,
+						Title:       w.Title,
+						Description: w.StartTime.Format("Jan 2, 2006") + " • " + fmt.Sprintf("%d exercises", len(w.Exercises)),
+					}
+				}
+				return options, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		workoutID = selected.ID
+	}
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	current, err := client.GetWorkout(workoutID)
+	if err != nil {
+		return fmt.Errorf("failed to get workout: %w", err)
+	}
+
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	var updated *api.Workout
+	session := &edit.Session[*edit.WorkoutDoc]{
+		Format: format,
+		Header: []string{
+			fmt.Sprintf("Editing workout %s (%s).", current.ID, current.Title),
+			"Weights are in kg and times in RFC 3339. Set types: normal, warmup, dropset, failure.",
+			"Save and close to update the workout, or save an empty file to cancel.",
+		},
+		Titles: edit.Titles(current.Exercises),
+		Edit: func(text string) (string, error) {
+			return cmdutil.EditTextWith(editEditor, text, "hevycli-workout-*"+format.Ext())
+		},
+		New:    func() *edit.WorkoutDoc { return &edit.WorkoutDoc{} },
+		Lookup: edit.TemplateLookup(client),
+		Confirm: func(diff string) (bool, error) {
+			if editYes {
+				return true, nil
+			}
+			return edit.ConfirmDiff(diff)
+		},
+		Save: func(doc *edit.WorkoutDoc) error {
+			return rec.Track(journal.ActionUpdate, current, func() error {
+				var err error
+				updated, err = client.UpdateWorkout(workoutID, doc.Request())
+				return err
+			})
+		},
+	}
+
+	result, err := session.Run(edit.FromWorkout(current))
+	if err != nil {
+		return fmt.Errorf("failed to update workout: %w", err)
+	}
+	switch result {
+	case edit.Unchanged:
+		fmt.Fprintln(os.Stderr, "No changes")
+		return nil
+	case edit.Cancelled:
+		fmt.Fprintln(os.Stderr, "Cancelled")
+		return nil
+	}
+
+	// Format output
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(updated)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+	} else {
+		fmt.Println("Workout updated successfully!")
+		fmt.Printf("ID: %s\n", updated.ID)
+		fmt.Printf("Title: %s\n", updated.Title)
+		fmt.Printf("Start: %s\n", updated.StartTime.Format(time.RFC3339))
+		fmt.Printf("End: %s\n", updated.EndTime.Format(time.RFC3339))
+		fmt.Printf("Exercises: %d\n", len(updated.Exercises))
+		fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
+	}
+
+	return nil
+}
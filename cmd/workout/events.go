@@ -10,8 +10,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
@@ -31,17 +33,20 @@ This is useful for syncing workout data or detecting changes.
 Examples:
   hevycli workout events --since 2024-01-01
   hevycli workout events --since 2024-01-01 --type updated
+  hevycli workout events --since yesterday
   hevycli workout events --since 2024-01-01 -o json`,
 	RunE: runEvents,
 }
 
 func init() {
-	eventsCmd.Flags().StringVar(&eventsSince, "since", "", "Get events since date (YYYY-MM-DD)")
+	eventsCmd.Flags().StringVar(&eventsSince, "since", "", "Get events since date (YYYY-MM-DD, yesterday, 3d, last week, ...)")
 	eventsCmd.Flags().StringVar(&eventsType, "type", "", "Filter by event type: updated, deleted")
 	eventsCmd.Flags().IntVar(&eventsLimit, "limit", 10, "Number of events to fetch")
 	eventsCmd.Flags().IntVar(&eventsPage, "page", 1, "Page number")
 	eventsCmd.MarkFlagRequired("since")
 	Cmd.AddCommand(eventsCmd)
+
+	schema.Register("workout-events", "Workout update and delete events; meta holds pagination", []api.WorkoutEvent{})
 }
 
 func runEvents(cmd *cobra.Command, args []string) error {
@@ -52,15 +57,23 @@ func runEvents(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
 
 	// Parse the since date
-	sinceTime, err := time.Parse("2006-01-02", eventsSince)
+	dates, err := cmdutil.NewDateParser(cfg.Display.Timezone, cfg.Display.WeekStart)
+	if err != nil {
+		return err
+	}
+	dateRange, err := dates.Parse(eventsSince)
 	if err != nil {
-		return fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
+		return err
+	}
+	sinceTime := dateRange.Start
+	if sinceTime.IsZero() {
+		return cmdutil.UsageErrorf("--since needs a start date")
 	}
 
 	// Determine output format
@@ -94,7 +107,7 @@ func runEvents(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		result := map[string]interface{}{
 			"events": filteredEvents,
 			"pagination": map[string]interface{}{
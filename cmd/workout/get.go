@@ -7,10 +7,12 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/obay/hevycli/internal/analysis"
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
@@ -26,6 +28,10 @@ Examples:
 	RunE: runGet,
 }
 
+func init() {
+	schema.Register("workout-get", "A workout", api.Workout{})
+}
+
 func runGet(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load("")
 	if err != nil {
@@ -34,7 +40,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -87,7 +93,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 		Writer:  os.Stdout,
 	})
 
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(workout)
 		if err != nil {
 			return err
@@ -130,11 +136,7 @@ func printWorkoutDetails(w *api.Workout, cfg *config.Config, formatter output.Fo
 
 			weight := "-"
 			if set.WeightKg != nil {
-				if cfg.Display.Units == "imperial" {
-					weight = fmt.Sprintf("%.1f lbs", *set.WeightKg*2.20462)
-				} else {
-					weight = fmt.Sprintf("%.1f kg", *set.WeightKg)
-				}
+				weight = analysis.FormatWeight(*set.WeightKg, cfg.Display.Units == "imperial")
 			}
 
 			reps := "-"
@@ -8,8 +8,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/query"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 var (
@@ -18,6 +21,7 @@ var (
 	listAll   bool
 	listSince string
 	listUntil string
+	listWhere string
 )
 
 var listCmd = &cobra.Command{
@@ -25,11 +29,25 @@ var listCmd = &cobra.Command{
 	Short: "List workouts",
 	Long: `List your Hevy workouts with pagination support.
 
+When --since, --until or --where is given, pages are fetched until --limit
+matching workouts are found (or all of them with --all), stopping early
+once workouts are older than --since.
+
+--where filters workouts with an expression that compares fields using
+= != ~ (contains) !~ > >= < <= and combines them with and, or, not and
+parentheses. Fields:
+` + query.FieldHelp() + `
+
 Examples:
   hevycli workout list                    # List recent workouts (default: 10)
   hevycli workout list --limit 5          # List 5 workouts
   hevycli workout list --all              # List all workouts
   hevycli workout list --since 2024-01-01 # List workouts since date
+  hevycli workout list --all --since "last week"   # Relative dates
+  hevycli workout list --all --since 2026-01..2026-03  # Explicit range
+  hevycli workout list --where 'exercise ~ "squat" and duration > 60m'
+  hevycli workout list --all --where 'volume > 10000kg and title ~ "Leg"'
+  hevycli workout list --where 'muscle ~ chest and weekday = monday'
   hevycli workout list -o json            # Output as JSON`,
 	RunE: runList,
 }
@@ -38,8 +56,11 @@ func init() {
 	listCmd.Flags().IntVar(&listLimit, "limit", 10, "Number of workouts to fetch (max 10 per page)")
 	listCmd.Flags().IntVar(&listPage, "page", 1, "Page number for pagination")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "Fetch all workouts (auto-pagination)")
-	listCmd.Flags().StringVar(&listSince, "since", "", "Filter workouts since date (YYYY-MM-DD)")
-	listCmd.Flags().StringVar(&listUntil, "until", "", "Filter workouts until date (YYYY-MM-DD)")
+	listCmd.Flags().StringVar(&listSince, "since", "", "Filter workouts since date (YYYY-MM-DD, monday, 3w, 2026-W14, ...)")
+	listCmd.Flags().StringVar(&listUntil, "until", "", "Filter workouts until date, inclusive (same formats as --since)")
+	listCmd.Flags().StringVar(&listWhere, "where", "", "Filter workouts with an expression, e.g. 'exercise ~ squat and duration > 60m'")
+
+	schema.Register("workout-list", "Workouts, newest first", []api.Workout{})
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -50,40 +71,99 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
 
+	dates, err := cmdutil.NewDateParser(cfg.Display.Timezone, cfg.Display.WeekStart)
+	if err != nil {
+		return err
+	}
+	dateRange, err := dates.SinceUntil(listSince, listUntil)
+	if err != nil {
+		return err
+	}
+
 	// Determine output format
 	outputFmt := cfg.Display.OutputFormat
 	if cmd.Flags().Changed("output") {
 		outputFmt, _ = cmd.Flags().GetString("output")
 	}
 
-	formatter := output.NewFormatter(output.Options{
+	opts := output.Options{
 		Format:  output.FormatType(outputFmt),
 		NoColor: !cfg.Display.Color,
 		Writer:  os.Stdout,
-	})
+	}
+	formatter := output.NewFormatter(opts)
+
+	var where *query.Query
+	if listWhere != "" {
+		where, err = query.Parse(listWhere, query.Options{Dates: dates})
+		if err != nil {
+			return cmdutil.UsageErrorf("invalid --where expression: %w", err)
+		}
+		if where.NeedsTemplates() {
+			templates, err := client.GetAllExerciseTemplates()
+			if err != nil {
+				return fmt.Errorf("failed to fetch exercise templates: %w", err)
+			}
+			where.SetTemplates(templates)
+		}
+	}
+	filtering := where != nil || listSince != "" || listUntil != ""
 
 	var allWorkouts []api.Workout
 
-	if listAll {
-		// Fetch all workouts with pagination
+	if listAll || filtering {
+		// With --all, ndjson and csv write each page as it arrives
+		var stream output.Stream
+		streaming := false
+		if listAll {
+			stream, streaming = output.NewStream(opts)
+		}
+
+		// Fetch pages until enough workouts match
 		page := 1
 		for {
 			resp, err := client.GetWorkouts(page, 10)
 			if err != nil {
 				return fmt.Errorf("failed to fetch workouts: %w", err)
 			}
-			allWorkouts = append(allWorkouts, resp.Workouts...)
 
-			if page >= resp.PageCount {
+			// The API returns workouts newest first, so once a page reaches
+			// past --since no later page can match
+			olderThanSince := false
+			for _, w := range resp.Workouts {
+				if !dateRange.Start.IsZero() && w.StartTime.Before(dateRange.Start) {
+					olderThanSince = true
+					continue
+				}
+				if !dateRange.Contains(w.StartTime) || (where != nil && !where.Match(&w)) {
+					continue
+				}
+				if streaming {
+					if err := stream.Write(w); err != nil {
+						return err
+					}
+				} else {
+					allWorkouts = append(allWorkouts, w)
+				}
+			}
+
+			if !listAll && len(allWorkouts) >= listLimit {
+				allWorkouts = allWorkouts[:listLimit]
+				break
+			}
+			if olderThanSince || page >= resp.PageCount || len(resp.Workouts) == 0 {
 				break
 			}
 			page++
 		}
+		if streaming {
+			return stream.Flush()
+		}
 	} else {
 		// Fetch single page
 		pageSize := listLimit
@@ -98,13 +178,8 @@ func runList(cmd *cobra.Command, args []string) error {
 		allWorkouts = resp.Workouts
 	}
 
-	// Filter by date if specified
-	if listSince != "" || listUntil != "" {
-		allWorkouts = filterByDate(allWorkouts, listSince, listUntil)
-	}
-
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		result := map[string]interface{}{
 			"workouts": allWorkouts,
 			"count":    len(allWorkouts),
@@ -144,39 +219,6 @@ func runList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func filterByDate(workouts []api.Workout, since, until string) []api.Workout {
-	var filtered []api.Workout
-
-	var sinceDate, untilDate time.Time
-	var hasSince, hasUntil bool
-
-	if since != "" {
-		if t, err := time.Parse("2006-01-02", since); err == nil {
-			sinceDate = t
-			hasSince = true
-		}
-	}
-
-	if until != "" {
-		if t, err := time.Parse("2006-01-02", until); err == nil {
-			untilDate = t.Add(24 * time.Hour) // Include the entire day
-			hasUntil = true
-		}
-	}
-
-	for _, w := range workouts {
-		if hasSince && w.StartTime.Before(sinceDate) {
-			continue
-		}
-		if hasUntil && w.StartTime.After(untilDate) {
-			continue
-		}
-		filtered = append(filtered, w)
-	}
-
-	return filtered
-}
-
 func formatDuration(d time.Duration) string {
 	hours := int(d.Hours())
 	minutes := int(d.Minutes()) % 60
@@ -0,0 +1,439 @@
+package workout
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/notation"
+	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
+	"github.com/obay/hevycli/internal/tui/prompt"
+)
+
+var (
+	logFile      string
+	logEdit      bool
+	logTitle     string
+	logStart     string
+	logDuration  time.Duration
+	logPrivate   bool
+	logYes       bool
+	logDryRun    bool
+	logNoInfer   bool
+	logNoAnalyze bool
+)
+
+// logTemplate is shown in the editor when logging with --edit
+const logTemplate = `# Log a workout: one exercise per line, sets separated by commas.
+#   Bench 60x10 wu, 100x8x3 @8
+#   a1 Pull-up BW+10x6x3 "slow negatives"
+#   a2 Dip BWx12x3
+#   Plank 60s x3
+# Lines starting with # are ignored. Save an empty file to cancel.
+title:
+`
+
+// LogCmd is the log command. It is added to the root command because
+// logging a workout is common enough to deserve a short path.
+var LogCmd = &cobra.Command{
+	Use:   "log [notation...]",
+	Short: "Log a workout from compact notation",
+	Long: `Log a workout written in compact notation instead of a full JSON document.
+
+Exercises are separated by semicolons or newlines and sets by commas:
+
+  Bench 60x10 wu, 100x8x3 @8; Pull-up BW+10x6x3; Plank 60s x3
+
+Sets:
+  100x8        weight × reps (kg or lb suffix; bare weights use your units)
+  100x8x3      weight × reps × sets
+  BWx10        bodyweight; BW+10x6 adds weight, BW-20x8 is assistance
+               (only for assisted exercises)
+  12           reps only
+  60s 2min 1:30
+               duration
+  400m 5km 3mi distance
+  x3           repeat the set
+  @8           RPE
+  wu drop fail warmup, drop set or failure set
+
+Prefix exercises with a label such as a1 and a2 to make them a superset and
+add notes in double quotes. A line "title: Push Day" sets the title.
+
+Exercise names are matched against the exercise library: an exact title or
+template ID is used directly, otherwise the closest title containing every
+word is chosen (you are asked to pick when several match interactively).
+Leading sets at half the heaviest weight or less are marked as warmups
+unless the exercise has explicit set types or --no-infer is given.
+
+The notation is read from the arguments, from --file (- for stdin), from
+stdin when it is piped, or from your editor with --edit. A preview is shown
+before saving; use --yes to skip the confirmation or --dry-run to only
+preview. By default the workout ends now and lasts --duration.
+
+Examples:
+  hevycli log "Bench 60x10 wu, 100x8x3 @8; Pull-up BW+10x6x3"
+  hevycli log --edit --start "yesterday 18:00" --duration 75m
+  echo "Squat 140x5x5" | hevycli log --yes --title "Leg Day"
+  hevycli log "Row 5km 21:30" --dry-run -o json`,
+	RunE: runLog,
+}
+
+func init() {
+	LogCmd.Flags().StringVarP(&logFile, "file", "f", "", "Read notation from a file (- for stdin)")
+	LogCmd.Flags().BoolVarP(&logEdit, "edit", "e", false, "Write the notation in $EDITOR")
+	LogCmd.Flags().StringVar(&logTitle, "title", "", "Workout title (default: Morning/Afternoon/Evening Workout)")
+	LogCmd.Flags().StringVar(&logStart, "start", "", "Start time: HH:MM, YYYY-MM-DD HH:MM, RFC 3339 or a date such as yesterday (default: now minus --duration)")
+	LogCmd.Flags().DurationVar(&logDuration, "duration", time.Hour, "Workout duration")
+	LogCmd.Flags().BoolVar(&logPrivate, "private", false, "Make workout private")
+	LogCmd.Flags().BoolVarP(&logYes, "yes", "y", false, "Save without confirmation")
+	LogCmd.Flags().BoolVar(&logDryRun, "dry-run", false, "Show the workout without saving it")
+	LogCmd.Flags().BoolVar(&logNoInfer, "no-infer", false, "Do not infer warmup sets from their weight")
+	LogCmd.Flags().BoolVar(&logNoAnalyze, "no-analyze", false, "Skip personal record detection after saving")
+
+	schema.Register("log", "The logged workout and any records it set", workoutWithAchievements{})
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	apiKey := cfg.GetAPIKey()
+	if apiKey == "" {
+		return cmdutil.ErrNoAPIKey
+	}
+
+	client := api.NewClient(apiKey)
+
+	// Determine output format
+	outputFmt := cfg.Display.OutputFormat
+	if cmd.Flags().Changed("output") {
+		outputFmt, _ = cmd.Flags().GetString("output")
+	}
+
+	formatter := output.NewFormatter(output.Options{
+		Format:  output.FormatType(outputFmt),
+		NoColor: !cfg.Display.Color,
+		Writer:  os.Stdout,
+	})
+
+	if logDuration <= 0 {
+		return cmdutil.UsageErrorf("--duration must be positive")
+	}
+
+	src, fromStdin, err := readLogNotation(args)
+	if err != nil {
+		return err
+	}
+
+	imperial := cfg.Display.Units == "imperial"
+	parsed, err := notation.Parse(src, notation.Options{Imperial: imperial, NoInfer: logNoInfer})
+	if err != nil {
+		return cmdutil.UsageErrorf("invalid notation: %w", err)
+	}
+
+	start, err := parseLogStart(logStart, cfg)
+	if err != nil {
+		return err
+	}
+	end := start.Add(logDuration)
+
+	if logTitle != "" {
+		parsed.Title = logTitle
+	}
+	if parsed.Title == "" {
+		parsed.Title = defaultWorkoutTitle(start)
+	}
+
+	// Resolve exercise names against the exercise library
+	templates, err := client.GetAllExerciseTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to fetch exercises: %w", err)
+	}
+	canPrompt := cmdutil.IsInteractive() && !fromStdin
+	resolved := make(map[string]api.ExerciseTemplate, len(parsed.Exercises))
+	titles := make([]string, len(parsed.Exercises))
+	for i := range parsed.Exercises {
+		ex := &parsed.Exercises[i]
+		t, err := resolveLogExercise(ex.Name, templates, resolved, canPrompt)
+		if err != nil {
+			return err
+		}
+		if err := ex.CheckTemplate(t); err != nil {
+			return cmdutil.UsageErrorf("invalid notation: %w", err)
+		}
+		ex.TemplateID = t.ID
+		titles[i] = t.Title
+	}
+
+	req, err := parsed.Request(start, end)
+	if err != nil {
+		return err
+	}
+	req.Workout.IsPrivate = logPrivate
+
+	if logDryRun {
+		if output.IsStructured(outputFmt) {
+			out, err := formatter.Format(req)
+			if err != nil {
+				return err
+			}
+			fmt.Println(out)
+			return nil
+		}
+		printLogPreview(os.Stdout, parsed, titles, start, end, imperial)
+		return nil
+	}
+
+	if !logYes {
+		if !canPrompt {
+			return cmdutil.UsageErrorf("confirmation required: use --yes to save without a prompt or --dry-run to preview")
+		}
+		printLogPreview(os.Stderr, parsed, titles, start, end, imperial)
+		fmt.Fprint(os.Stderr, "Save this workout? [y/N]: ")
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintln(os.Stderr, "Cancelled")
+			return nil
+		}
+	}
+
+	workout, err := client.CreateWorkout(req)
+	if err != nil {
+		return fmt.Errorf("failed to create workout: %w", err)
+	}
+
+	// Compare against history for new PRs
+	var achievements *analysis.Achievements
+	if !logNoAnalyze {
+		achievements = analyzeSavedWorkout(client, cfg, workout)
+	}
+
+	if output.IsStructured(outputFmt) {
+		out, err := formatter.Format(workoutWithAchievements{Workout: workout, Achievements: achievements})
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+	} else {
+		fmt.Println("Workout logged successfully!")
+		fmt.Printf("ID: %s\n", workout.ID)
+		fmt.Printf("Title: %s\n", workout.Title)
+		fmt.Printf("Start: %s\n", workout.StartTime.Format(time.RFC3339))
+		fmt.Printf("Exercises: %d\n", len(workout.Exercises))
+		printAchievements(achievements, cfg)
+	}
+
+	return nil
+}
+
+// readLogNotation returns the notation from the arguments, a file, stdin or
+// the editor, and whether it was read from stdin
+func readLogNotation(args []string) (string, bool, error) {
+	sources := 0
+	for _, set := range []bool{len(args) > 0, logFile != "", logEdit} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "", false, cmdutil.UsageErrorf("use only one of notation arguments, --file or --edit")
+	}
+
+	switch {
+	case len(args) > 0:
+		return strings.Join(args, " "), false, nil
+
+	case logFile == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return string(data), true, nil
+
+	case logFile != "":
+		data, err := os.ReadFile(logFile)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read file: %w", err)
+		}
+		return string(data), false, nil
+
+	case logEdit || cmdutil.IsInteractive():
+		text, err := cmdutil.EditText(logTemplate, "hevycli-log-*.txt")
+		if err != nil {
+			return "", false, err
+		}
+		body := strings.TrimSpace(stripLogComments(text))
+		if strings.TrimSpace(strings.TrimPrefix(body, "title:")) == "" {
+			return "", false, fmt.Errorf("cancelled: no workout entered")
+		}
+		return text, false, nil
+
+	default:
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return string(data), true, nil
+	}
+}
+
+// stripLogComments removes comment lines from editor input
+func stripLogComments(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var clockTime = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+
+// parseLogStart parses --start. Without a time of day, a date uses the
+// current time of day, so "yesterday" means a workout ending this time yesterday.
+func parseLogStart(s string, cfg *config.Config) (time.Time, error) {
+	dates, err := cmdutil.NewDateParser(cfg.Display.Timezone, cfg.Display.WeekStart)
+	if err != nil {
+		return time.Time{}, err
+	}
+	now := dates.Now
+	defaultStart := now.Add(-logDuration)
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return defaultStart, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	hour, minute := defaultStart.Hour(), defaultStart.Minute()
+	day := defaultStart
+	datePart := s
+	fields := strings.Fields(s)
+	if m := clockTime.FindStringSubmatch(fields[len(fields)-1]); m != nil {
+		fmt.Sscanf(m[1], "%d", &hour)
+		fmt.Sscanf(m[2], "%d", &minute)
+		if hour > 23 || minute > 59 {
+			return time.Time{}, cmdutil.UsageErrorf("invalid --start time: %q", fields[len(fields)-1])
+		}
+		day = now
+		datePart = strings.Join(fields[:len(fields)-1], " ")
+	}
+
+	if datePart != "" {
+		r, err := dates.Parse(datePart)
+		if err != nil {
+			return time.Time{}, cmdutil.UsageErrorf("invalid --start: %w", err)
+		}
+		day = r.Start
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, dates.Location), nil
+}
+
+// defaultWorkoutTitle names a workout after the time of day, like the Hevy app
+func defaultWorkoutTitle(start time.Time) string {
+	switch h := start.Hour(); {
+	case h < 12:
+		return "Morning Workout"
+	case h < 17:
+		return "Afternoon Workout"
+	default:
+		return "Evening Workout"
+	}
+}
+
+// resolveLogExercise finds the exercise template for a name, asking the user
+// to choose when several templates match and prompting is possible. Without
+// a prompt, a name that only partly matches several templates is an error.
+// Choices are remembered in resolved so a repeated name is asked once.
+func resolveLogExercise(name string, templates []api.ExerciseTemplate, resolved map[string]api.ExerciseTemplate, canPrompt bool) (api.ExerciseTemplate, error) {
+	key := strings.ToLower(name)
+	if t, ok := resolved[key]; ok {
+		return t, nil
+	}
+
+	matches := notation.Match(name, templates)
+	if len(matches) == 0 {
+		return api.ExerciseTemplate{}, cmdutil.UsageErrorf("no exercise matches %q (try 'hevycli exercise search %s')", name, name)
+	}
+
+	choice := matches[0]
+	if len(matches) > 1 && !canPrompt && !notation.Exact(name, choice) {
+		return api.ExerciseTemplate{}, cmdutil.UsageErrorf("%q matches several exercises, use the full name or ID of one of:\n%s", name, describeCandidates(matches))
+	}
+	if len(matches) > 1 && canPrompt {
+		options := make([]prompt.SelectOption, len(matches))
+		for i, t := range matches {
+			options[i] = prompt.SelectOption{ID: t.ID, Title: t.Title, Description: t.Summary()}
+		}
+		selected, err := prompt.Select(fmt.Sprintf("Which exercise is %q?", name), options, "Choose an exercise...")
+		if err != nil {
+			return api.ExerciseTemplate{}, err
+		}
+		for _, t := range matches {
+			if t.ID == selected.ID {
+				choice = t
+			}
+		}
+	}
+	resolved[key] = choice
+	return choice, nil
+}
+
+// maxCandidates is how many matching exercises an ambiguity error lists
+const maxCandidates = 10
+
+// describeCandidates lists matching exercises, one per line
+func describeCandidates(matches []api.ExerciseTemplate) string {
+	var lines []string
+	for i, t := range matches {
+		if i == maxCandidates {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(matches)-maxCandidates))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  %s (%s)", t.Title, t.ID))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// printLogPreview prints the parsed workout with resolved exercise titles
+func printLogPreview(w io.Writer, parsed *notation.Workout, titles []string, start, end time.Time, imperial bool) {
+	fmt.Fprintf(w, "%s\n", parsed.Title)
+	fmt.Fprintf(w, "%s – %s (%s)\n", start.Format("Mon 2006-01-02 15:04"), end.Format("15:04"), end.Sub(start))
+	for i, ex := range parsed.Exercises {
+		fmt.Fprintln(w)
+		header := titles[i]
+		if !strings.EqualFold(ex.Name, titles[i]) {
+			header += fmt.Sprintf("  (from %q)", ex.Name)
+		}
+		if ex.Superset != "" {
+			header = fmt.Sprintf("[superset %s] %s", ex.Superset, header)
+		}
+		fmt.Fprintln(w, header)
+		if ex.Notes != "" {
+			fmt.Fprintf(w, "  Notes: %s\n", ex.Notes)
+		}
+		for j, s := range ex.Sets {
+			fmt.Fprintf(w, "  %2d  %-8s %s\n", j+1, s.Type, s.String(imperial))
+		}
+	}
+	fmt.Fprintln(w)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
 	tuiWorkout "github.com/obay/hevycli/internal/tui/workout"
 )
@@ -48,7 +49,7 @@ func runStart(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -139,6 +140,8 @@ func runStart(cmd *cobra.Command, args []string) error {
 				}
 				fmt.Printf("\nWorkout saved successfully!\n")
 				fmt.Printf("ID: %s\n", workout.ID)
+
+				printAchievements(analyzeSavedWorkout(client, cfg, workout), cfg)
 			} else {
 				fmt.Println("\nWorkout not saved.")
 			}
@@ -11,7 +11,9 @@ import (
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/cmdutil"
 	"github.com/obay/hevycli/internal/config"
+	"github.com/obay/hevycli/internal/journal"
 	"github.com/obay/hevycli/internal/output"
+	"github.com/obay/hevycli/internal/schema"
 	"github.com/obay/hevycli/internal/tui/prompt"
 )
 
@@ -47,6 +49,8 @@ func init() {
 	updateCmd.Flags().StringVarP(&updateFile, "file", "f", "", "JSON file with workout data (required)")
 	updateCmd.Flags().StringVar(&updateTitle, "title", "", "Update workout title (overrides file)")
 	updateCmd.MarkFlagRequired("file")
+
+	schema.Register("workout-update", "The updated workout", api.Workout{})
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
@@ -57,7 +61,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	apiKey := cfg.GetAPIKey()
 	if apiKey == "" {
-		return fmt.Errorf("API key not configured. Run 'hevycli config init' to set up")
+		return cmdutil.ErrNoAPIKey
 	}
 
 	client := api.NewClient(apiKey)
@@ -121,14 +125,25 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		req.Workout.Title = updateTitle
 	}
 
+	// Snapshot the current workout so the update can be undone
+	current, err := client.GetWorkout(workoutID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch workout: %w", err)
+	}
+
 	// Update the workout
-	workout, err := client.UpdateWorkout(workoutID, &req)
+	var workout *api.Workout
+	rec := journal.Open(cfg.JournalDir()).Begin(cmd.CommandPath())
+	err = rec.Track(journal.ActionUpdate, current, func() error {
+		workout, err = client.UpdateWorkout(workoutID, &req)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update workout: %w", err)
 	}
 
 	// Format output
-	if outputFmt == "json" {
+	if output.IsStructured(outputFmt) {
 		out, err := formatter.Format(workout)
 		if err != nil {
 			return err
@@ -141,6 +156,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Start: %s\n", workout.StartTime.Format(time.RFC3339))
 		fmt.Printf("End: %s\n", workout.EndTime.Format(time.RFC3339))
 		fmt.Printf("Exercises: %d\n", len(workout.Exercises))
+		fmt.Printf("Undo with 'hevycli undo %s'.\n", rec.ID())
 	}
 
 	return nil
@@ -15,7 +15,9 @@ Examples:
   hevycli workout count             # Get total workout count
   hevycli workout create --file w.json  # Create from JSON
   hevycli workout update <id> --file w.json  # Update workout
+  hevycli workout edit <id>         # Edit in $EDITOR
   hevycli workout delete <id>       # Delete workout
+  hevycli workout compare <id1> <id2>  # Compare two workouts
   hevycli workout start             # Start interactive session
   hevycli workout events --since 2024-01-01  # Get change events`,
 }
@@ -0,0 +1,253 @@
+// Package alias parses and expands user-defined command aliases and macros
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StepSeparator separates the steps of a multi-step macro
+const StepSeparator = "&&"
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateName checks that an alias name can be stored in the config file
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias name: %q (use lowercase letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+// Parse splits an alias expansion into steps of shell-style words.
+// Steps are separated by a bare "&&".
+func Parse(expansion string) ([][]string, error) {
+	tokens, err := split(expansion)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps [][]string
+	var current []string
+	for _, t := range tokens {
+		if t.text == StepSeparator && !t.quoted {
+			if len(current) == 0 {
+				return nil, fmt.Errorf("empty step in alias: %q", expansion)
+			}
+			steps = append(steps, current)
+			current = nil
+			continue
+		}
+		current = append(current, t.text)
+	}
+	if len(current) == 0 {
+		return nil, fmt.Errorf("empty step in alias: %q", expansion)
+	}
+	return append(steps, current), nil
+}
+
+// Split breaks a string into words, honouring single quotes, double quotes
+// and backslash escapes like a POSIX shell (without expansion)
+func Split(s string) ([]string, error) {
+	tokens, err := split(s)
+	if err != nil {
+		return nil, err
+	}
+	words := make([]string, len(tokens))
+	for i, t := range tokens {
+		words[i] = t.text
+	}
+	return words, nil
+}
+
+// token is a word and whether any part of it was quoted or escaped
+type token struct {
+	text   string
+	quoted bool
+}
+
+func split(s string) ([]token, error) {
+	var tokens []token
+	var b strings.Builder
+	inWord, quoted := false, false
+	var quote rune
+
+	flush := func() {
+		if inWord {
+			tokens = append(tokens, token{text: b.String(), quoted: quoted})
+		}
+		b.Reset()
+		inWord, quoted = false, false
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' && i+1 < len(runes) {
+				i++
+				b.WriteRune(runes[i])
+			} else {
+				b.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord, quoted = r, true, true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			b.WriteRune(runes[i])
+			inWord, quoted = true, true
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			b.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in alias: %q", s)
+	}
+	flush()
+	return tokens, nil
+}
+
+// Join quotes words where needed so that Split returns them unchanged
+func Join(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		if w == "" || w == StepSeparator || strings.ContainsAny(w, " \t\n'\"\\") {
+			quoted[i] = "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+		} else {
+			quoted[i] = w
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+var (
+	argPattern  = regexp.MustCompile(`\$(@|[1-9])`)
+	prevPattern = regexp.MustCompile(`\$\{(prev|step[1-9][0-9]*)((?:\.[^.}]+)*)\}`)
+)
+
+// UsesArgs reports whether any step references positional arguments
+func UsesArgs(steps [][]string) bool {
+	for _, step := range steps {
+		for _, w := range step {
+			if argPattern.MatchString(w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ExpandArgs substitutes $1..$9 and $@ with alias arguments.
+// $@ as a whole word expands to all arguments as separate words.
+func ExpandArgs(step []string, args []string) ([]string, error) {
+	var out []string
+	for _, w := range step {
+		if w == "$@" {
+			out = append(out, args...)
+			continue
+		}
+		var missing error
+		expanded := argPattern.ReplaceAllStringFunc(w, func(m string) string {
+			if m == "$@" {
+				return strings.Join(args, " ")
+			}
+			n, _ := strconv.Atoi(m[1:])
+			if n > len(args) {
+				missing = fmt.Errorf("alias expects argument %s", m)
+				return ""
+			}
+			return args[n-1]
+		})
+		if missing != nil {
+			return nil, missing
+		}
+		out = append(out, expanded)
+	}
+	return out, nil
+}
+
+// ExpandOutputs substitutes ${prev.path} with values from the previous step's
+// JSON output and ${stepN.path} with values from step N (1-based).
+// outputs holds the decoded output of every completed step.
+func ExpandOutputs(step []string, outputs []any) ([]string, error) {
+	out := make([]string, len(step))
+	for i, w := range step {
+		var expandErr error
+		out[i] = prevPattern.ReplaceAllStringFunc(w, func(m string) string {
+			parts := prevPattern.FindStringSubmatch(m)
+			index := len(outputs) - 1
+			if parts[1] != "prev" {
+				n, _ := strconv.Atoi(strings.TrimPrefix(parts[1], "step"))
+				index = n - 1
+			}
+			if index < 0 || index >= len(outputs) {
+				expandErr = fmt.Errorf("%s refers to a step that has not run", m)
+				return ""
+			}
+
+			value, err := Lookup(outputs[index], strings.TrimPrefix(parts[2], "."))
+			if err != nil {
+				expandErr = fmt.Errorf("%s: %w", m, err)
+				return ""
+			}
+			return value
+		})
+		if expandErr != nil {
+			return nil, expandErr
+		}
+	}
+	return out, nil
+}
+
+// Lookup follows a dot-separated path through decoded JSON and returns the
+// value as a string. Numeric segments index arrays. Objects and arrays are
+// returned as compact JSON.
+func Lookup(data any, path string) (string, error) {
+	current := data
+	if path != "" {
+		for _, key := range strings.Split(path, ".") {
+			switch v := current.(type) {
+			case map[string]any:
+				next, ok := v[key]
+				if !ok {
+					return "", fmt.Errorf("field %q not found", key)
+				}
+				current = next
+			case []any:
+				i, err := strconv.Atoi(key)
+				if err != nil || i < 0 || i >= len(v) {
+					return "", fmt.Errorf("index %q out of range", key)
+				}
+				current = v[i]
+			default:
+				return "", fmt.Errorf("cannot look up %q in a scalar value", key)
+			}
+		}
+	}
+
+	switch v := current.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
@@ -0,0 +1,88 @@
+package alias
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	steps, err := Parse(`workout list --limit 1 && workout get ${prev.workouts.0.id}`)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"workout", "list", "--limit", "1"},
+		{"workout", "get", "${prev.workouts.0.id}"},
+	}, steps)
+
+	steps, err = Parse(`exercise search "bench press" '&&'`)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"exercise", "search", "bench press", "&&"}}, steps)
+
+	_, err = Parse("version &&")
+	assert.Error(t, err)
+	_, err = Parse(`exercise search "bench`)
+	assert.Error(t, err)
+}
+
+func TestJoin_RoundTrip(t *testing.T) {
+	words := []string{"exercise", "search", "bench press", "it's", "&&", ""}
+	got, err := Split(Join(words))
+	require.NoError(t, err)
+	assert.Equal(t, words, got)
+
+	steps, err := Parse(Join(words))
+	require.NoError(t, err)
+	assert.Len(t, steps, 1)
+}
+
+func TestValidateName(t *testing.T) {
+	assert.NoError(t, ValidateName("push-day_2"))
+	assert.Error(t, ValidateName("Push"))
+	assert.Error(t, ValidateName("two words"))
+	assert.Error(t, ValidateName(""))
+}
+
+func TestExpandArgs(t *testing.T) {
+	steps, err := Parse(`exercise search $1 --limit $2 && stats progress "$@"`)
+	require.NoError(t, err)
+	assert.True(t, UsesArgs(steps))
+
+	got, err := ExpandArgs(steps[0], []string{"squat", "5"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exercise", "search", "squat", "--limit", "5"}, got)
+
+	got, err = ExpandArgs(steps[1], []string{"squat", "5"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stats", "progress", "squat", "5"}, got)
+
+	_, err = ExpandArgs(steps[0], []string{"squat"})
+	assert.Error(t, err)
+
+	steps, err = Parse("routine get 1234")
+	require.NoError(t, err)
+	assert.False(t, UsesArgs(steps))
+}
+
+func TestExpandOutputs(t *testing.T) {
+	var first, second any
+	require.NoError(t, json.Unmarshal([]byte(`{"workouts":[{"id":"w1","title":"Push"}],"count":1}`), &first))
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"r9","exercises":[{"sets":3}]}`), &second))
+	outputs := []any{first, second}
+
+	got, err := ExpandOutputs([]string{"routine", "get", "${prev.id}", "--note=${step1.workouts.0.title}"}, outputs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"routine", "get", "r9", "--note=Push"}, got)
+
+	got, err = ExpandOutputs([]string{"${step1.count}", "${prev.exercises}"}, outputs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", `[{"sets":3}]`}, got)
+
+	_, err = ExpandOutputs([]string{"${prev.missing}"}, outputs)
+	assert.Error(t, err)
+	_, err = ExpandOutputs([]string{"${step3.id}"}, outputs)
+	assert.Error(t, err)
+	_, err = ExpandOutputs([]string{"${prev.id}"}, nil)
+	assert.Error(t, err)
+}
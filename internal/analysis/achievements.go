@@ -0,0 +1,156 @@
+package analysis
+
+import (
+	"math"
+	"strings"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// Achievements summarizes what a single workout accomplished relative to history
+type Achievements struct {
+	WorkoutID          string             `json:"workout_id"`
+	NewRecords         []Improvement      `json:"new_records"`
+	FirstTimeExercises []string           `json:"first_time_exercises,omitempty"`
+	VolumeKg           float64            `json:"volume_kg"`
+	PreviousSession    *SessionComparison `json:"previous_session,omitempty"`
+}
+
+// SessionComparison compares a workout to the previous session of the same routine
+type SessionComparison struct {
+	WorkoutID      string  `json:"workout_id"`
+	Date           string  `json:"date"`
+	MatchedBy      string  `json:"matched_by"`
+	VolumeKg       float64 `json:"volume_kg"`
+	VolumeChangeKg float64 `json:"volume_change_kg"`
+	PercentChange  float64 `json:"percent_change"`
+}
+
+// HasAny reports whether there is anything worth celebrating
+func (a *Achievements) HasAny() bool {
+	return len(a.NewRecords) > 0 || len(a.FirstTimeExercises) > 0 ||
+		(a.PreviousSession != nil && a.PreviousSession.VolumeChangeKg > 0)
+}
+
+// WorkoutVolume returns the total weight × reps across all sets of a workout
+func WorkoutVolume(w api.Workout) float64 {
+	var volume float64
+	for _, ex := range w.Exercises {
+		volume += ExerciseVolume(ex)
+	}
+	return volume
+}
+
+// ExerciseVolume returns the total weight × reps across the sets of an exercise
+func ExerciseVolume(ex api.Exercise) float64 {
+	var volume float64
+	for _, set := range ex.Sets {
+		if set.WeightKg != nil && set.Reps != nil {
+			volume += *set.WeightKg * float64(*set.Reps)
+		}
+	}
+	return volume
+}
+
+// AnalyzeWorkout compares a workout against the rest of the history.
+// Only workouts that started before it are treated as history; the workout
+// itself is skipped if present in the list.
+func AnalyzeWorkout(history []api.Workout, w api.Workout, estimator Estimator) *Achievements {
+	var earlier []api.Workout
+	for _, h := range history {
+		if h.ID == w.ID || !h.StartTime.Before(w.StartTime) {
+			continue
+		}
+		earlier = append(earlier, h)
+	}
+	earlier = SortedByStart(earlier)
+
+	book := NewRecordBook(estimator)
+	for _, h := range earlier {
+		book.AddWorkout(h)
+	}
+	known := make(map[string]bool, len(book.titles))
+	for key := range book.titles {
+		known[key] = true
+	}
+	repMaxes := make(map[recordKey]float64)
+	for key, rec := range book.records {
+		if key.recordType == RecordRepMax {
+			repMaxes[key] = rec.Value
+		}
+	}
+
+	result := &Achievements{
+		WorkoutID:  w.ID,
+		NewRecords: []Improvement{},
+		VolumeKg:   round(WorkoutVolume(w), 2),
+	}
+
+	// A record without a previous value is only a first time when the
+	// exercise itself is new. A rep max is only a record when no earlier set
+	// lifted as much for as many reps: after 100 kg × 5, a first 90 kg × 3
+	// is not a 3RM worth celebrating
+	firstTime := make(map[string]bool)
+	for _, imp := range book.AddWorkout(w) {
+		key := exerciseKey(api.Exercise{Title: imp.Record.Exercise, ExerciseTemplateID: imp.Record.ExerciseTemplateID})
+		if imp.Previous == nil && !known[key] {
+			if !firstTime[key] {
+				firstTime[key] = true
+				result.FirstTimeExercises = append(result.FirstTimeExercises, imp.Record.Exercise)
+			}
+			continue
+		}
+		if imp.Record.RecordType == RecordRepMax && repMaxCovered(repMaxes, key, imp.Record.Reps, imp.Record.Value) {
+			continue
+		}
+		result.NewRecords = append(result.NewRecords, imp)
+	}
+
+	if prev, matchedBy := previousSession(earlier, w); prev != nil {
+		prevVolume := WorkoutVolume(*prev)
+		cmp := &SessionComparison{
+			WorkoutID:      prev.ID,
+			Date:           prev.StartTime.Format("2006-01-02"),
+			MatchedBy:      matchedBy,
+			VolumeKg:       round(prevVolume, 2),
+			VolumeChangeKg: round(result.VolumeKg-prevVolume, 2),
+		}
+		if prevVolume > 0 {
+			cmp.PercentChange = math.Round((result.VolumeKg-prevVolume)/prevVolume*10000) / 100
+		}
+		result.PreviousSession = cmp
+	}
+
+	return result
+}
+
+// repMaxCovered reports whether an earlier rep max of the exercise has at
+// least the given weight for at least the given reps
+func repMaxCovered(repMaxes map[recordKey]float64, exercise string, reps int, weight float64) bool {
+	for r := reps; r <= RepMaxLimit; r++ {
+		if prev, ok := repMaxes[recordKey{exercise: exercise, recordType: RecordRepMax, reps: r}]; ok && prev >= weight {
+			return true
+		}
+	}
+	return false
+}
+
+// previousSession finds the most recent earlier workout of the same routine,
+// matching by routine ID when available and by title otherwise.
+// earlier must be sorted oldest first.
+func previousSession(earlier []api.Workout, w api.Workout) (*api.Workout, string) {
+	for i := len(earlier) - 1; i >= 0; i-- {
+		if w.RoutineID != "" && earlier[i].RoutineID == w.RoutineID {
+			return &earlier[i], "routine_id"
+		}
+	}
+	if w.Title == "" {
+		return nil, ""
+	}
+	for i := len(earlier) - 1; i >= 0; i-- {
+		if strings.EqualFold(earlier[i].Title, w.Title) {
+			return &earlier[i], "title"
+		}
+	}
+	return nil, ""
+}
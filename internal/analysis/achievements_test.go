@@ -0,0 +1,90 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func TestAnalyzeWorkout(t *testing.T) {
+	estimator, _ := NewEstimator("brzycki")
+	bench := func(sets ...api.Set) api.Exercise {
+		return api.Exercise{Title: "Bench Press", ExerciseTemplateID: "BP", Sets: sets}
+	}
+
+	w1 := testWorkout("w1", 1, bench(weightSet(100, 5)))
+	w1.Title = "Push Day"
+	w2 := testWorkout("w2", 3, bench(weightSet(80, 5)))
+	w3 := testWorkout("w3", 5, bench(weightSet(105, 5)),
+		api.Exercise{Title: "Dips", ExerciseTemplateID: "DP", Sets: []api.Set{weightSet(20, 10)}})
+	w3.Title = "push day"
+	later := testWorkout("w4", 9, bench(weightSet(200, 1)))
+
+	a := AnalyzeWorkout([]api.Workout{later, w3, w2, w1}, w3, estimator)
+
+	assert.Equal(t, "w3", a.WorkoutID)
+	assert.Equal(t, []string{"Dips"}, a.FirstTimeExercises)
+	assert.Equal(t, 725.0, a.VolumeKg)
+	assert.True(t, a.HasAny())
+
+	weight := findImprovement(a.NewRecords, RecordWeight)
+	require.NotNil(t, weight)
+	assert.Equal(t, 105.0, weight.Record.Value)
+	require.NotNil(t, weight.Previous)
+	assert.Equal(t, 100.0, weight.Previous.Value)
+
+	require.NotNil(t, a.PreviousSession)
+	assert.Equal(t, "w1", a.PreviousSession.WorkoutID)
+	assert.Equal(t, "title", a.PreviousSession.MatchedBy)
+	assert.Equal(t, 225.0, a.PreviousSession.VolumeChangeKg)
+	assert.Equal(t, 45.0, a.PreviousSession.PercentChange)
+}
+
+func TestAnalyzeWorkout_RoutineMatch(t *testing.T) {
+	w1 := testWorkout("w1", 1)
+	w1.RoutineID = "r1"
+	w2 := testWorkout("w2", 2)
+	w3 := testWorkout("w3", 3)
+	w3.RoutineID = "r1"
+
+	a := AnalyzeWorkout([]api.Workout{w1, w2}, w3, nil)
+	require.NotNil(t, a.PreviousSession)
+	assert.Equal(t, "w1", a.PreviousSession.WorkoutID)
+	assert.Equal(t, "routine_id", a.PreviousSession.MatchedBy)
+	assert.Empty(t, a.NewRecords)
+	assert.False(t, a.HasAny())
+}
+
+func TestAnalyzeWorkout_FirstRepMax(t *testing.T) {
+	estimator, _ := NewEstimator("brzycki")
+	bench := func(sets ...api.Set) api.Exercise {
+		return api.Exercise{Title: "Bench Press", ExerciseTemplateID: "BP", Sets: sets}
+	}
+	w1 := testWorkout("w1", 1, bench(weightSet(100, 5)))
+	w2 := testWorkout("w2", 2, bench(weightSet(90, 3)))
+
+	// 100 kg × 5 already beats 90 kg × 3: no record and not a first time
+	a := AnalyzeWorkout([]api.Workout{w1}, w2, estimator)
+	assert.Empty(t, a.FirstTimeExercises)
+	assert.Empty(t, a.NewRecords)
+
+	// A heavier first 3RM is a record
+	w3 := testWorkout("w3", 3, bench(weightSet(105, 3)))
+	a = AnalyzeWorkout([]api.Workout{w1, w2}, w3, estimator)
+	repMax := findImprovement(a.NewRecords, RecordRepMax)
+	require.NotNil(t, repMax)
+	assert.Equal(t, 3, repMax.Record.Reps)
+	assert.Equal(t, 105.0, repMax.Record.Value)
+}
+
+func findImprovement(improvements []Improvement, t RecordType) *Improvement {
+	for i := range improvements {
+		if improvements[i].Record.RecordType == t {
+			return &improvements[i]
+		}
+	}
+	return nil
+}
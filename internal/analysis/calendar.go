@@ -0,0 +1,365 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+const dateLayout = "2006-01-02"
+
+// CalendarMetric selects the value that drives heatmap intensity
+type CalendarMetric string
+
+const (
+	MetricVolume   CalendarMetric = "volume"
+	MetricDuration CalendarMetric = "duration"
+	MetricCount    CalendarMetric = "count"
+)
+
+// CalendarMetrics returns all heatmap metrics
+func CalendarMetrics() []CalendarMetric {
+	return []CalendarMetric{MetricVolume, MetricDuration, MetricCount}
+}
+
+// ParseCalendarMetric parses a heatmap metric name
+func ParseCalendarMetric(s string) (CalendarMetric, error) {
+	for _, m := range CalendarMetrics() {
+		if strings.EqualFold(s, string(m)) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("invalid metric: %s (must be volume, duration, or count)", s)
+}
+
+// Week target statuses
+const (
+	TargetHit        = "hit"
+	TargetMiss       = "miss"
+	TargetInProgress = "in_progress"
+)
+
+// DayAggregate holds the training totals for a single day
+type DayAggregate struct {
+	Date            string   `json:"date"`
+	Weekday         string   `json:"weekday"`
+	Workouts        int      `json:"workouts"`
+	VolumeKg        float64  `json:"volume_kg"`
+	DurationMinutes float64  `json:"duration_minutes"`
+	Sets            int      `json:"sets"`
+	WorkoutIDs      []string `json:"workout_ids,omitempty"`
+}
+
+// Value returns the aggregate's value for a heatmap metric
+func (d DayAggregate) Value(metric CalendarMetric) float64 {
+	switch metric {
+	case MetricDuration:
+		return d.DurationMinutes
+	case MetricCount:
+		return float64(d.Workouts)
+	default:
+		return d.VolumeKg
+	}
+}
+
+// WeekSummary holds the training totals for one calendar week
+type WeekSummary struct {
+	Start        string  `json:"start"`
+	Workouts     int     `json:"workouts"`
+	TrainingDays int     `json:"training_days"`
+	VolumeKg     float64 `json:"volume_kg"`
+	Target       int     `json:"target,omitempty"`
+	Status       string  `json:"status,omitempty"`
+}
+
+// Streaks holds consecutive-day and consecutive-week training streaks
+type Streaks struct {
+	LongestDays  int `json:"longest_days"`
+	CurrentDays  int `json:"current_days"`
+	LongestWeeks int `json:"longest_target_weeks,omitempty"`
+	CurrentWeeks int `json:"current_target_weeks,omitempty"`
+}
+
+// RestPattern describes the gaps between training days
+type RestPattern struct {
+	TrainingDays       int            `json:"training_days"`
+	RestDays           int            `json:"rest_days"`
+	AverageRestDays    float64        `json:"average_rest_days"`
+	LongestRestDays    int            `json:"longest_rest_days"`
+	MostCommonRestDays int            `json:"most_common_rest_days"`
+	WeekdayFrequency   map[string]int `json:"weekday_frequency"`
+}
+
+// Calendar is a day-by-day view of training over a date range
+type Calendar struct {
+	Start        string         `json:"start"`
+	End          string         `json:"end"`
+	WeeklyTarget int            `json:"weekly_target,omitempty"`
+	Streaks      Streaks        `json:"streaks"`
+	Rest         RestPattern    `json:"rest"`
+	Weeks        []WeekSummary  `json:"weeks"`
+	Days         []DayAggregate `json:"days"`
+
+	start time.Time
+}
+
+// WeekStart returns the first day of the week containing t, at midnight,
+// for weeks starting on firstDay
+func WeekStart(t time.Time, firstDay time.Weekday) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(t.Weekday()) - int(firstDay) + 7) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+// BuildCalendar aggregates workouts into days and weeks between start and end.
+// Days are taken in start's timezone, and the range is extended back to the
+// beginning of start's week, with weeks starting on firstDay, so every week
+// is complete. A weeklyTarget of zero disables target tracking.
+func BuildCalendar(workouts []api.Workout, start, end time.Time, firstDay time.Weekday, weeklyTarget int) *Calendar {
+	loc := start.Location()
+	first := WeekStart(start, firstDay)
+	end = end.In(loc)
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+	if last.Before(first) {
+		last = first
+	}
+
+	cal := &Calendar{
+		Start:        first.Format(dateLayout),
+		End:          last.Format(dateLayout),
+		WeeklyTarget: weeklyTarget,
+		start:        first,
+	}
+
+	index := make(map[string]int)
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		index[d.Format(dateLayout)] = len(cal.Days)
+		cal.Days = append(cal.Days, DayAggregate{Date: d.Format(dateLayout), Weekday: d.Weekday().String()})
+	}
+
+	for _, w := range workouts {
+		i, ok := index[w.StartTime.In(loc).Format(dateLayout)]
+		if !ok {
+			continue
+		}
+		day := &cal.Days[i]
+		day.Workouts++
+		day.WorkoutIDs = append(day.WorkoutIDs, w.ID)
+		day.VolumeKg = round(day.VolumeKg+WorkoutVolume(w), 2)
+		day.DurationMinutes = round(day.DurationMinutes+w.EndTime.Sub(w.StartTime).Minutes(), 1)
+		for _, ex := range w.Exercises {
+			day.Sets += len(ex.Sets)
+		}
+	}
+
+	cal.Weeks = buildWeeks(cal.Days, weeklyTarget)
+	cal.Streaks = calendarStreaks(cal, last)
+	cal.Rest = restPattern(cal.Days)
+	return cal
+}
+
+// Day returns the aggregate for a date, if it is within the calendar
+func (c *Calendar) Day(date time.Time) (DayAggregate, bool) {
+	i := c.dayIndex(date)
+	if i < 0 || i >= len(c.Days) {
+		return DayAggregate{}, false
+	}
+	return c.Days[i], true
+}
+
+// Week returns the summary of the week containing date, if it is within the calendar
+func (c *Calendar) Week(date time.Time) (WeekSummary, bool) {
+	i := c.dayIndex(date)
+	if i < 0 || i >= len(c.Days) {
+		return WeekSummary{}, false
+	}
+	return c.Weeks[i/7], true
+}
+
+// FirstDay returns the first date covered by the calendar
+func (c *Calendar) FirstDay() time.Time {
+	return c.start
+}
+
+// MaxValue returns the highest daily value for a metric
+func (c *Calendar) MaxValue(metric CalendarMetric) float64 {
+	var max float64
+	for _, d := range c.Days {
+		if v := d.Value(metric); v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// Level buckets a day's metric value into an intensity from 0 (rest) to 4
+func Level(d DayAggregate, metric CalendarMetric, max float64) int {
+	if d.Workouts == 0 {
+		return 0
+	}
+	v := d.Value(metric)
+	if max <= 0 || v <= 0 {
+		return 1
+	}
+	level := int(math.Ceil(v / max * 4))
+	if level < 1 {
+		level = 1
+	}
+	if level > 4 {
+		level = 4
+	}
+	return level
+}
+
+func (c *Calendar) dayIndex(date time.Time) int {
+	d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, c.start.Location())
+	return int(math.Round(d.Sub(c.start).Hours() / 24))
+}
+
+// buildWeeks groups days into weeks and marks them against the target
+func buildWeeks(days []DayAggregate, target int) []WeekSummary {
+	var weeks []WeekSummary
+	for i := 0; i < len(days); i += 7 {
+		week := WeekSummary{Start: days[i].Date, Target: target}
+		end := i + 7
+		complete := end <= len(days)
+		if !complete {
+			end = len(days)
+		}
+		for _, d := range days[i:end] {
+			week.Workouts += d.Workouts
+			week.VolumeKg = round(week.VolumeKg+d.VolumeKg, 2)
+			if d.Workouts > 0 {
+				week.TrainingDays++
+			}
+		}
+		if target > 0 {
+			switch {
+			case week.TrainingDays >= target:
+				week.Status = TargetHit
+			case complete:
+				week.Status = TargetMiss
+			default:
+				week.Status = TargetInProgress
+			}
+		}
+		weeks = append(weeks, week)
+	}
+	return weeks
+}
+
+// calendarStreaks computes day streaks and, when a target is set, week streaks
+func calendarStreaks(c *Calendar, end time.Time) Streaks {
+	dates := make(map[string]bool)
+	for _, d := range c.Days {
+		if d.Workouts > 0 {
+			dates[d.Date] = true
+		}
+	}
+
+	var s Streaks
+	s.LongestDays, s.CurrentDays = DayStreaks(dates, end)
+
+	if c.WeeklyTarget > 0 {
+		run := 0
+		for _, w := range c.Weeks {
+			switch w.Status {
+			case TargetHit:
+				run++
+				if run > s.LongestWeeks {
+					s.LongestWeeks = run
+				}
+			case TargetMiss:
+				run = 0
+			}
+		}
+		s.CurrentWeeks = run
+	}
+	return s
+}
+
+// DayStreaks returns the longest run of consecutive training days and the
+// run ending today (or yesterday, so a streak isn't broken before the day is over)
+func DayStreaks(dates map[string]bool, end time.Time) (longest, current int) {
+	if len(dates) == 0 {
+		return 0, 0
+	}
+
+	var sorted []time.Time
+	for s := range dates {
+		if t, err := time.Parse(dateLayout, s); err == nil {
+			sorted = append(sorted, t)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+
+	run := 0
+	for i, t := range sorted {
+		if i > 0 && t.Sub(sorted[i-1]).Hours() <= 24 {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	check := end
+	if !dates[check.Format(dateLayout)] {
+		check = check.AddDate(0, 0, -1)
+	}
+	for dates[check.Format(dateLayout)] {
+		current++
+		check = check.AddDate(0, 0, -1)
+	}
+
+	return longest, current
+}
+
+// restPattern measures the gaps between training days
+func restPattern(days []DayAggregate) RestPattern {
+	p := RestPattern{WeekdayFrequency: make(map[string]int)}
+	for _, wd := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		p.WeekdayFrequency[wd.String()] = 0
+	}
+
+	gaps := make(map[int]int)
+	totalGap, gapCount, last := 0, 0, -1
+	for i, d := range days {
+		if d.Workouts == 0 {
+			p.RestDays++
+			continue
+		}
+		p.TrainingDays++
+		p.WeekdayFrequency[d.Weekday]++
+		if last >= 0 {
+			gap := i - last - 1
+			gaps[gap]++
+			totalGap += gap
+			gapCount++
+			if gap > p.LongestRestDays {
+				p.LongestRestDays = gap
+			}
+		}
+		last = i
+	}
+
+	if gapCount > 0 {
+		p.AverageRestDays = round(float64(totalGap)/float64(gapCount), 2)
+		best := -1
+		for gap, n := range gaps {
+			if n > best || (n == best && gap < p.MostCommonRestDays) {
+				best = n
+				p.MostCommonRestDays = gap
+			}
+		}
+	}
+	return p
+}
@@ -0,0 +1,124 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func TestWeekStart(t *testing.T) {
+	// 2024-01-10 is a Wednesday
+	got := WeekStart(time.Date(2024, 1, 10, 18, 30, 0, 0, time.UTC), time.Monday)
+	assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), got)
+
+	// Sunday belongs to the week that started the previous Monday
+	got = WeekStart(time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), time.Monday)
+	assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), got)
+
+	// ...or starts its own week when weeks start on Sunday
+	got = WeekStart(time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), time.Sunday)
+	assert.Equal(t, time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), got)
+	got = WeekStart(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Sunday)
+	assert.Equal(t, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), got)
+}
+
+func TestBuildCalendar(t *testing.T) {
+	bench := api.Exercise{Title: "Bench Press", Sets: []api.Set{weightSet(100, 5), weightSet(100, 5)}}
+	workouts := []api.Workout{
+		testWorkout("a", 1, bench), // Monday
+		testWorkout("b", 2, bench),
+		testWorkout("c", 3, bench),
+		testWorkout("d", 3, bench), // second workout the same day
+		testWorkout("e", 9, bench),
+		testWorkout("f", 17, bench),
+		testWorkout("old", 31, bench), // outside the range
+	}
+	end := time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC)
+
+	cal := BuildCalendar(workouts, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), end, time.Monday, 2)
+
+	assert.Equal(t, "2024-01-01", cal.Start)
+	assert.Equal(t, "2024-01-17", cal.End)
+	require.Len(t, cal.Days, 17)
+	require.Len(t, cal.Weeks, 3)
+
+	day, ok := cal.Day(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, 2, day.Workouts)
+	assert.Equal(t, 2000.0, day.VolumeKg)
+	assert.Equal(t, 120.0, day.DurationMinutes)
+	assert.Equal(t, 4, day.Sets)
+	assert.Equal(t, []string{"c", "d"}, day.WorkoutIDs)
+	assert.Equal(t, "Wednesday", day.Weekday)
+
+	assert.Equal(t, TargetHit, cal.Weeks[0].Status)
+	assert.Equal(t, 3, cal.Weeks[0].TrainingDays)
+	assert.Equal(t, TargetMiss, cal.Weeks[1].Status)
+	assert.Equal(t, TargetInProgress, cal.Weeks[2].Status)
+
+	assert.Equal(t, 3, cal.Streaks.LongestDays)
+	assert.Equal(t, 1, cal.Streaks.CurrentDays)
+	assert.Equal(t, 1, cal.Streaks.LongestWeeks)
+	assert.Equal(t, 0, cal.Streaks.CurrentWeeks)
+
+	assert.Equal(t, 5, cal.Rest.TrainingDays)
+	assert.Equal(t, 12, cal.Rest.RestDays)
+	assert.Equal(t, 7, cal.Rest.LongestRestDays)
+	assert.Equal(t, 0, cal.Rest.MostCommonRestDays)
+	assert.Equal(t, 2, cal.Rest.WeekdayFrequency["Wednesday"])
+}
+
+func TestBuildCalendar_Timezone(t *testing.T) {
+	// 23:30 on Saturday in New York is already Sunday in UTC
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	w := api.Workout{
+		ID:        "late",
+		StartTime: time.Date(2024, 1, 14, 4, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 1, 14, 5, 30, 0, 0, time.UTC),
+	}
+	start := time.Date(2024, 1, 7, 12, 0, 0, 0, ny)
+	end := time.Date(2024, 1, 20, 12, 0, 0, 0, ny)
+
+	cal := BuildCalendar([]api.Workout{w}, start, end, time.Sunday, 0)
+	assert.Equal(t, "2024-01-07", cal.Start)
+	day, ok := cal.Day(time.Date(2024, 1, 13, 0, 0, 0, 0, ny))
+	require.True(t, ok)
+	assert.Equal(t, 1, day.Workouts)
+	assert.Equal(t, "Saturday", day.Weekday)
+	require.Len(t, cal.Weeks, 2)
+	assert.Equal(t, 1, cal.Weeks[0].Workouts)
+}
+
+func TestDayStreaks(t *testing.T) {
+	dates := map[string]bool{
+		"2024-01-01": true,
+		"2024-01-02": true,
+		"2024-01-03": true,
+		"2024-01-07": true,
+		"2024-01-08": true,
+	}
+
+	longest, current := DayStreaks(dates, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 3, longest)
+	assert.Equal(t, 2, current)
+
+	// A streak is still current until the day after the last workout is over
+	_, current = DayStreaks(dates, time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 2, current)
+
+	_, current = DayStreaks(dates, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 0, current)
+}
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, 0, Level(DayAggregate{}, MetricVolume, 1000))
+	assert.Equal(t, 1, Level(DayAggregate{Workouts: 1, VolumeKg: 100}, MetricVolume, 1000))
+	assert.Equal(t, 2, Level(DayAggregate{Workouts: 1, VolumeKg: 500}, MetricVolume, 1000))
+	assert.Equal(t, 4, Level(DayAggregate{Workouts: 1, VolumeKg: 1000}, MetricVolume, 1000))
+	assert.Equal(t, 1, Level(DayAggregate{Workouts: 1}, MetricVolume, 1000))
+}
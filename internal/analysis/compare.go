@@ -0,0 +1,208 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// Exercise comparison statuses
+const (
+	ExerciseMatched = "matched"
+	ExerciseAdded   = "added"
+	ExerciseRemoved = "removed"
+)
+
+// WorkoutComparison describes how a workout differs from an earlier one.
+// All changes are target minus base.
+type WorkoutComparison struct {
+	Base                  WorkoutRef           `json:"base"`
+	Target                WorkoutRef           `json:"target"`
+	DurationChangeSeconds int                  `json:"duration_change_seconds"`
+	VolumeChangeKg        float64              `json:"volume_change_kg"`
+	VolumePercentChange   float64              `json:"volume_percent_change"`
+	Exercises             []ExerciseComparison `json:"exercises"`
+	Added                 []string             `json:"added_exercises"`
+	Removed               []string             `json:"removed_exercises"`
+}
+
+// WorkoutRef summarizes one side of a comparison
+type WorkoutRef struct {
+	ID              string  `json:"id"`
+	Title           string  `json:"title"`
+	Date            string  `json:"date"`
+	DurationSeconds int     `json:"duration_seconds"`
+	VolumeKg        float64 `json:"volume_kg"`
+}
+
+// ExerciseComparison compares one exercise across two workouts
+type ExerciseComparison struct {
+	Exercise           string          `json:"exercise"`
+	ExerciseTemplateID string          `json:"exercise_template_id,omitempty"`
+	Status             string          `json:"status"`
+	BaseVolumeKg       float64         `json:"base_volume_kg"`
+	TargetVolumeKg     float64         `json:"target_volume_kg"`
+	VolumeChangeKg     float64         `json:"volume_change_kg"`
+	BaseE1RM           *float64        `json:"base_e1rm,omitempty"`
+	TargetE1RM         *float64        `json:"target_e1rm,omitempty"`
+	E1RMChange         *float64        `json:"e1rm_change,omitempty"`
+	Sets               []SetComparison `json:"sets"`
+}
+
+// SetComparison compares the sets at the same index of an exercise
+type SetComparison struct {
+	Index          int        `json:"index"`
+	Base           *SetValues `json:"base,omitempty"`
+	Target         *SetValues `json:"target,omitempty"`
+	WeightChangeKg *float64   `json:"weight_change_kg,omitempty"`
+	RepsChange     *int       `json:"reps_change,omitempty"`
+}
+
+// SetValues holds the measured values of a set
+type SetValues struct {
+	SetType         api.SetType `json:"type,omitempty"`
+	WeightKg        *float64    `json:"weight_kg,omitempty"`
+	Reps            *int        `json:"reps,omitempty"`
+	DurationSeconds *int        `json:"duration_seconds,omitempty"`
+	DistanceMeters  *float64    `json:"distance_meters,omitempty"`
+}
+
+// CompareWorkouts aligns exercises by template ID and sets by index and
+// reports the differences from base to target.
+// A nil estimator omits the e1RM comparison.
+func CompareWorkouts(base, target api.Workout, estimator Estimator) *WorkoutComparison {
+	c := &WorkoutComparison{
+		Base:      workoutRef(base),
+		Target:    workoutRef(target),
+		Exercises: []ExerciseComparison{},
+		Added:     []string{},
+		Removed:   []string{},
+	}
+	c.DurationChangeSeconds = c.Target.DurationSeconds - c.Base.DurationSeconds
+	c.VolumeChangeKg = round(c.Target.VolumeKg-c.Base.VolumeKg, 2)
+	if c.Base.VolumeKg > 0 {
+		c.VolumePercentChange = math.Round(c.VolumeChangeKg/c.Base.VolumeKg*10000) / 100
+	}
+
+	// Queue base exercises per key so repeated exercises pair up in order
+	baseByKey := make(map[string][]int)
+	for i, ex := range base.Exercises {
+		key := exerciseKey(ex)
+		baseByKey[key] = append(baseByKey[key], i)
+	}
+	matchedBase := make(map[int]bool)
+
+	for _, ex := range target.Exercises {
+		key := exerciseKey(ex)
+		if queue := baseByKey[key]; len(queue) > 0 {
+			bi := queue[0]
+			baseByKey[key] = queue[1:]
+			matchedBase[bi] = true
+			c.Exercises = append(c.Exercises, compareExercise(&base.Exercises[bi], &ex, estimator))
+			continue
+		}
+		c.Exercises = append(c.Exercises, compareExercise(nil, &ex, estimator))
+		c.Added = append(c.Added, ex.Title)
+	}
+
+	for i := range base.Exercises {
+		if matchedBase[i] {
+			continue
+		}
+		c.Exercises = append(c.Exercises, compareExercise(&base.Exercises[i], nil, estimator))
+		c.Removed = append(c.Removed, base.Exercises[i].Title)
+	}
+
+	return c
+}
+
+func workoutRef(w api.Workout) WorkoutRef {
+	return WorkoutRef{
+		ID:              w.ID,
+		Title:           w.Title,
+		Date:            w.StartTime.Format(dateLayout),
+		DurationSeconds: int(w.Duration().Seconds()),
+		VolumeKg:        round(WorkoutVolume(w), 2),
+	}
+}
+
+// compareExercise compares two instances of an exercise; either side may be nil
+func compareExercise(base, target *api.Exercise, estimator Estimator) ExerciseComparison {
+	var ec ExerciseComparison
+	var baseSets, targetSets []api.Set
+
+	switch {
+	case base == nil:
+		ec.Status = ExerciseAdded
+	case target == nil:
+		ec.Status = ExerciseRemoved
+	default:
+		ec.Status = ExerciseMatched
+	}
+
+	if base != nil {
+		ec.Exercise, ec.ExerciseTemplateID = base.Title, base.ExerciseTemplateID
+		baseSets = base.Sets
+		ec.BaseVolumeKg = round(ExerciseVolume(*base), 2)
+		if estimator != nil {
+			if est, ok := BestEstimate(estimator, base.Sets); ok {
+				v := round(est.Value, 2)
+				ec.BaseE1RM = &v
+			}
+		}
+	}
+	if target != nil {
+		ec.Exercise, ec.ExerciseTemplateID = target.Title, target.ExerciseTemplateID
+		targetSets = target.Sets
+		ec.TargetVolumeKg = round(ExerciseVolume(*target), 2)
+		if estimator != nil {
+			if est, ok := BestEstimate(estimator, target.Sets); ok {
+				v := round(est.Value, 2)
+				ec.TargetE1RM = &v
+			}
+		}
+	}
+	ec.VolumeChangeKg = round(ec.TargetVolumeKg-ec.BaseVolumeKg, 2)
+	if ec.BaseE1RM != nil && ec.TargetE1RM != nil {
+		change := round(*ec.TargetE1RM-*ec.BaseE1RM, 2)
+		ec.E1RMChange = &change
+	}
+
+	n := len(baseSets)
+	if len(targetSets) > n {
+		n = len(targetSets)
+	}
+	ec.Sets = make([]SetComparison, 0, n)
+	for i := 0; i < n; i++ {
+		sc := SetComparison{Index: i}
+		if i < len(baseSets) {
+			sc.Base = setValues(baseSets[i])
+		}
+		if i < len(targetSets) {
+			sc.Target = setValues(targetSets[i])
+		}
+		if sc.Base != nil && sc.Target != nil {
+			if sc.Base.WeightKg != nil && sc.Target.WeightKg != nil {
+				change := round(*sc.Target.WeightKg-*sc.Base.WeightKg, 2)
+				sc.WeightChangeKg = &change
+			}
+			if sc.Base.Reps != nil && sc.Target.Reps != nil {
+				change := *sc.Target.Reps - *sc.Base.Reps
+				sc.RepsChange = &change
+			}
+		}
+		ec.Sets = append(ec.Sets, sc)
+	}
+
+	return ec
+}
+
+func setValues(s api.Set) *SetValues {
+	return &SetValues{
+		SetType:         s.SetType,
+		WeightKg:        s.WeightKg,
+		Reps:            s.Reps,
+		DurationSeconds: s.DurationSeconds,
+		DistanceMeters:  s.DistanceMeters,
+	}
+}
@@ -0,0 +1,72 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func TestCompareWorkouts(t *testing.T) {
+	estimator, _ := NewEstimator("epley")
+
+	base := testWorkout("a", 1,
+		api.Exercise{Title: "Squat", ExerciseTemplateID: "SQ", Sets: []api.Set{weightSet(100, 5), weightSet(100, 5)}},
+		api.Exercise{Title: "Leg Curl", ExerciseTemplateID: "LC", Sets: []api.Set{weightSet(40, 10)}},
+	)
+	target := testWorkout("b", 8,
+		api.Exercise{Title: "Squat", ExerciseTemplateID: "SQ", Sets: []api.Set{weightSet(105, 5), weightSet(100, 4), weightSet(100, 3)}},
+		api.Exercise{Title: "Lunge", ExerciseTemplateID: "LU", Sets: []api.Set{weightSet(20, 10)}},
+	)
+	target.EndTime = target.EndTime.Add(-10 * time.Minute)
+
+	c := CompareWorkouts(base, target, estimator)
+
+	assert.Equal(t, "a", c.Base.ID)
+	assert.Equal(t, "b", c.Target.ID)
+	assert.Equal(t, -600, c.DurationChangeSeconds)
+	assert.Equal(t, []string{"Lunge"}, c.Added)
+	assert.Equal(t, []string{"Leg Curl"}, c.Removed)
+	assert.Equal(t, 25.0, c.VolumeChangeKg)
+
+	require.Len(t, c.Exercises, 3)
+	squat := c.Exercises[0]
+	assert.Equal(t, ExerciseMatched, squat.Status)
+	assert.Equal(t, 1000.0, squat.BaseVolumeKg)
+	assert.Equal(t, 1225.0, squat.TargetVolumeKg)
+	require.NotNil(t, squat.E1RMChange)
+	assert.InDelta(t, 5.83, *squat.E1RMChange, 0.01)
+
+	require.Len(t, squat.Sets, 3)
+	require.NotNil(t, squat.Sets[0].WeightChangeKg)
+	assert.Equal(t, 5.0, *squat.Sets[0].WeightChangeKg)
+	assert.Equal(t, 0, *squat.Sets[0].RepsChange)
+	assert.Equal(t, -1, *squat.Sets[1].RepsChange)
+	assert.Nil(t, squat.Sets[2].Base)
+	assert.Nil(t, squat.Sets[2].RepsChange)
+
+	assert.Equal(t, ExerciseAdded, c.Exercises[1].Status)
+	assert.Nil(t, c.Exercises[1].E1RMChange)
+	assert.Equal(t, ExerciseRemoved, c.Exercises[2].Status)
+	assert.Equal(t, -400.0, c.Exercises[2].VolumeChangeKg)
+}
+
+func TestCompareWorkouts_RepeatedExercise(t *testing.T) {
+	base := testWorkout("a", 1,
+		api.Exercise{Title: "Bench", ExerciseTemplateID: "BP", Sets: []api.Set{weightSet(100, 5)}},
+		api.Exercise{Title: "Bench", ExerciseTemplateID: "BP", Sets: []api.Set{weightSet(60, 12)}},
+	)
+	target := testWorkout("b", 2,
+		api.Exercise{Title: "Bench", ExerciseTemplateID: "BP", Sets: []api.Set{weightSet(102.5, 5)}},
+	)
+
+	c := CompareWorkouts(base, target, nil)
+
+	require.Len(t, c.Exercises, 2)
+	assert.Equal(t, 2.5, *c.Exercises[0].Sets[0].WeightChangeKg)
+	assert.Equal(t, ExerciseRemoved, c.Exercises[1].Status)
+	assert.Equal(t, []string{"Bench"}, c.Removed)
+}
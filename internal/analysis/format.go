@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// RecordLabel returns a short human-readable label for a record
+func RecordLabel(pr Record) string {
+	switch pr.RecordType {
+	case RecordEstimated1RM:
+		if pr.Formula != "" {
+			return fmt.Sprintf("est. 1RM (%s)", pr.Formula)
+		}
+		return "est. 1RM"
+	case RecordRepMax:
+		return fmt.Sprintf("%dRM", pr.Reps)
+	case RecordSessionVolume:
+		return "session volume"
+	case RecordSetVolume:
+		return "set volume"
+	default:
+		return string(pr.RecordType)
+	}
+}
+
+// FormatRecordValue renders a record value with its unit, with weights and
+// volumes in lbs if imperial is set
+func FormatRecordValue(t RecordType, value float64, reps int, imperial bool) string {
+	switch t {
+	case RecordWeight:
+		if reps > 0 {
+			return fmt.Sprintf("%s × %d", FormatWeight(value, imperial), reps)
+		}
+		return FormatWeight(value, imperial)
+	case RecordSetVolume:
+		if reps > 0 {
+			return fmt.Sprintf("%s (%d reps)", FormatWeight(value, imperial), reps)
+		}
+		return FormatWeight(value, imperial)
+	case RecordSessionVolume:
+		return FormatWeight(value, imperial)
+	case RecordDuration:
+		return FormatSeconds(value)
+	case RecordDistance:
+		if value >= 1000 {
+			return fmt.Sprintf("%.2f km", value/1000)
+		}
+		return fmt.Sprintf("%.0f m", value)
+	case RecordPace:
+		return FormatSeconds(value) + " /km"
+	default:
+		return FormatWeight(value, imperial)
+	}
+}
+
+// FormatSeconds renders seconds as m:ss or h:mm:ss
+func FormatSeconds(seconds float64) string {
+	total := int(math.Round(seconds))
+	h := total / 3600
+	m := (total % 3600) / 60
+	sec := total % 60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, sec)
+	}
+	return fmt.Sprintf("%d:%02d", m, sec)
+}
+
+// KgPerLb is the number of kilograms in a pound
+const KgPerLb = 0.45359237
+
+// FormatWeight renders a weight in kg, or in lbs if imperial is set
+func FormatWeight(kg float64, imperial bool) string {
+	if imperial {
+		return fmt.Sprintf("%.1f lbs", kg/KgPerLb)
+	}
+	return fmt.Sprintf("%.1f kg", kg)
+}
+
+// FormatWeightChange renders a weight difference with its sign, e.g. "+2.5 kg"
+func FormatWeightChange(kg float64, imperial bool) string {
+	if imperial {
+		return fmt.Sprintf("%+.1f lbs", kg/KgPerLb)
+	}
+	return fmt.Sprintf("%+.1f kg", kg)
+}
+
+// FormatSet renders the values of a set, e.g. "100.0 kg × 5 @8"
+func FormatSet(s api.Set, imperial bool) string {
+	var parts []string
+	switch {
+	case s.WeightKg != nil && s.Reps != nil:
+		parts = append(parts, fmt.Sprintf("%s × %d", FormatWeight(*s.WeightKg, imperial), *s.Reps))
+	case s.Reps != nil:
+		parts = append(parts, fmt.Sprintf("%d reps", *s.Reps))
+	case s.WeightKg != nil:
+		parts = append(parts, FormatWeight(*s.WeightKg, imperial))
+	}
+	if s.DistanceMeters != nil {
+		parts = append(parts, fmt.Sprintf("%.0f m", *s.DistanceMeters))
+	}
+	if s.DurationSeconds != nil {
+		parts = append(parts, FormatSeconds(float64(*s.DurationSeconds)))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	text := strings.Join(parts, ", ")
+	if s.RPE != nil {
+		text += fmt.Sprintf(" @%g", *s.RPE)
+	}
+	return text
+}
+
+// sparkBlocks are the characters of a sparkline from lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as block characters scaled between their
+// minimum and maximum
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	out := make([]rune, len(values))
+	for i, v := range values {
+		level := len(sparkBlocks) / 2
+		if hi > lo {
+			level = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+		}
+		out[i] = sparkBlocks[level]
+	}
+	return string(out)
+}
@@ -0,0 +1,172 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// ExerciseInsights summarizes the history of one exercise
+type ExerciseInsights struct {
+	ExerciseTemplateID string            `json:"exercise_template_id"`
+	Exercise           string            `json:"exercise"`
+	TimesPerformed     int               `json:"times_performed"`
+	TotalSets          int               `json:"total_sets"`
+	FirstPerformed     string            `json:"first_performed,omitempty"`
+	LastPerformed      string            `json:"last_performed,omitempty"`
+	TypicalReps        *RepSummary       `json:"typical_reps,omitempty"`
+	RepMaxes           []Record          `json:"rep_maxes"`
+	Formula            string            `json:"formula,omitempty"`
+	E1RMTrend          []TrendPoint      `json:"e1rm_trend"`
+	RecentSessions     []ExerciseSession `json:"recent_sessions"`
+	Routines           []RoutineRef      `json:"routines"`
+}
+
+// RepSummary describes the reps of working sets: the middle half of them
+// lies between Low and High
+type RepSummary struct {
+	Low        int `json:"low"`
+	High       int `json:"high"`
+	MostCommon int `json:"most_common"`
+}
+
+// TrendPoint is the best estimated 1RM of one session
+type TrendPoint struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+	Reps  int     `json:"reps"`
+}
+
+// ExerciseSession is the sets of an exercise done in one workout
+type ExerciseSession struct {
+	Date         string    `json:"date"`
+	WorkoutID    string    `json:"workout_id"`
+	WorkoutTitle string    `json:"workout_title"`
+	Sets         []api.Set `json:"sets"`
+}
+
+// RoutineRef names a routine
+type RoutineRef struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// BuildExerciseInsights collects the history of the exercise with the given
+// template ID from workouts, and the routines that include it. recent is the
+// number of latest sessions to keep.
+func BuildExerciseInsights(templateID, title string, workouts []api.Workout, routines []api.Routine, estimator Estimator, recent int) *ExerciseInsights {
+	ins := &ExerciseInsights{
+		ExerciseTemplateID: templateID,
+		Exercise:           title,
+		RepMaxes:           []Record{},
+		E1RMTrend:          []TrendPoint{},
+		RecentSessions:     []ExerciseSession{},
+		Routines:           []RoutineRef{},
+	}
+	if estimator != nil {
+		ins.Formula = string(estimator.Formula())
+	}
+
+	// One session per workout, merging the exercise if it was done twice
+	var sessions []api.Workout
+	var reps []int
+	for _, w := range SortedByStart(workouts) {
+		var sets []api.Set
+		for _, ex := range w.Exercises {
+			if ex.ExerciseTemplateID == templateID {
+				sets = append(sets, ex.Sets...)
+			}
+		}
+		if len(sets) == 0 {
+			continue
+		}
+		for _, s := range sets {
+			if s.SetType != api.SetTypeWarmup && s.Reps != nil && *s.Reps > 0 {
+				reps = append(reps, *s.Reps)
+			}
+		}
+		session := w
+		session.Exercises = []api.Exercise{{Title: title, ExerciseTemplateID: templateID, Sets: sets}}
+		sessions = append(sessions, session)
+		ins.TotalSets += len(sets)
+	}
+
+	ins.TimesPerformed = len(sessions)
+	if len(sessions) == 0 {
+		ins.Routines = routinesWith(routines, templateID)
+		return ins
+	}
+	ins.FirstPerformed = sessions[0].StartTime.Format("2006-01-02")
+	ins.LastPerformed = sessions[len(sessions)-1].StartTime.Format("2006-01-02")
+	ins.TypicalReps = summarizeReps(reps)
+
+	book := ComputeRecords(sessions, nil)
+	ins.RepMaxes = book.Records("", []RecordType{RecordRepMax}, false)
+	if ins.RepMaxes == nil {
+		ins.RepMaxes = []Record{}
+	}
+
+	for _, s := range sessions {
+		if estimator == nil {
+			break
+		}
+		if est, ok := BestEstimate(estimator, s.Exercises[0].Sets); ok {
+			ins.E1RMTrend = append(ins.E1RMTrend, TrendPoint{
+				Date:  s.StartTime.Format("2006-01-02"),
+				Value: round(est.Value, 1),
+				Reps:  est.Reps,
+			})
+		}
+	}
+
+	for i := len(sessions) - 1; i >= 0 && len(ins.RecentSessions) < recent; i-- {
+		s := sessions[i]
+		ins.RecentSessions = append(ins.RecentSessions, ExerciseSession{
+			Date:         s.StartTime.Format("2006-01-02"),
+			WorkoutID:    s.ID,
+			WorkoutTitle: s.Title,
+			Sets:         s.Exercises[0].Sets,
+		})
+	}
+
+	ins.Routines = routinesWith(routines, templateID)
+	return ins
+}
+
+// summarizeReps returns the quartiles and most common value of reps
+func summarizeReps(reps []int) *RepSummary {
+	if len(reps) == 0 {
+		return nil
+	}
+	sorted := make([]int, len(reps))
+	copy(sorted, reps)
+	sort.Ints(sorted)
+
+	counts := make(map[int]int)
+	mostCommon := sorted[0]
+	for _, r := range sorted {
+		counts[r]++
+		if counts[r] > counts[mostCommon] {
+			mostCommon = r
+		}
+	}
+	quartile := func(q float64) int {
+		return sorted[int(math.Round(q*float64(len(sorted)-1)))]
+	}
+	return &RepSummary{Low: quartile(0.25), High: quartile(0.75), MostCommon: mostCommon}
+}
+
+// routinesWith returns the routines that include the exercise
+func routinesWith(routines []api.Routine, templateID string) []RoutineRef {
+	refs := []RoutineRef{}
+	for _, r := range routines {
+		for _, ex := range r.Exercises {
+			if ex.ExerciseTemplateID == templateID {
+				refs = append(refs, RoutineRef{ID: r.ID, Title: r.Title})
+				break
+			}
+		}
+	}
+	return refs
+}
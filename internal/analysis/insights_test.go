@@ -0,0 +1,80 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func TestBuildExerciseInsights(t *testing.T) {
+	estimator, _ := NewEstimator("epley")
+	bench := func(sets ...api.Set) api.Exercise {
+		return api.Exercise{Title: "Bench Press", ExerciseTemplateID: "BP", Sets: sets}
+	}
+	warmup := api.Set{SetType: api.SetTypeWarmup, WeightKg: floatPtr(40), Reps: intPtr(15)}
+	workouts := []api.Workout{
+		testWorkout("w3", 15, bench(weightSet(105, 5)), api.Exercise{Title: "Squat", ExerciseTemplateID: "SQ"}, bench(weightSet(110, 3))),
+		testWorkout("w1", 1, bench(warmup, weightSet(100, 8), weightSet(100, 8))),
+		testWorkout("w2", 8, api.Exercise{Title: "Squat", ExerciseTemplateID: "SQ", Sets: []api.Set{weightSet(140, 5)}}),
+		testWorkout("w4", 22, bench(weightSet(100, 10))),
+	}
+	routines := []api.Routine{
+		{ID: "r1", Title: "Push", Exercises: []api.Exercise{bench(), bench()}},
+		{ID: "r2", Title: "Legs", Exercises: []api.Exercise{{ExerciseTemplateID: "SQ"}}},
+	}
+
+	ins := BuildExerciseInsights("BP", "Bench Press", workouts, routines, estimator, 2)
+
+	assert.Equal(t, 3, ins.TimesPerformed)
+	assert.Equal(t, 6, ins.TotalSets)
+	assert.Equal(t, "2024-01-01", ins.FirstPerformed)
+	assert.Equal(t, "2024-01-22", ins.LastPerformed)
+	assert.Equal(t, "epley", ins.Formula)
+
+	// Warmups are left out of the typical reps
+	assert.Equal(t, &RepSummary{Low: 5, High: 8, MostCommon: 8}, ins.TypicalReps)
+
+	require.Len(t, ins.RepMaxes, 4)
+	assert.Equal(t, 3, ins.RepMaxes[0].Reps)
+	assert.Equal(t, 110.0, ins.RepMaxes[0].Value)
+	assert.Equal(t, "w3", ins.RepMaxes[0].WorkoutID)
+
+	require.Len(t, ins.E1RMTrend, 3)
+	assert.Equal(t, "2024-01-01", ins.E1RMTrend[0].Date)
+	assert.Equal(t, 126.7, ins.E1RMTrend[0].Value)
+	assert.Equal(t, 122.5, ins.E1RMTrend[1].Value)
+
+	// Newest first, with both bench entries of w3 merged
+	require.Len(t, ins.RecentSessions, 2)
+	assert.Equal(t, "w4", ins.RecentSessions[0].WorkoutID)
+	assert.Equal(t, "w3", ins.RecentSessions[1].WorkoutID)
+	assert.Len(t, ins.RecentSessions[1].Sets, 2)
+
+	assert.Equal(t, []RoutineRef{{ID: "r1", Title: "Push"}}, ins.Routines)
+}
+
+func TestBuildExerciseInsights_NeverPerformed(t *testing.T) {
+	ins := BuildExerciseInsights("XX", "Curl", nil, nil, nil, 5)
+	assert.Equal(t, 0, ins.TimesPerformed)
+	assert.Nil(t, ins.TypicalReps)
+	assert.Empty(t, ins.RepMaxes)
+	assert.NotNil(t, ins.Routines)
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", Sparkline(nil))
+	assert.Equal(t, "▁▅█", Sparkline([]float64{100, 110, 120}))
+	assert.Equal(t, "▅▅", Sparkline([]float64{5, 5}))
+}
+
+func TestFormatSet(t *testing.T) {
+	set := weightSet(100, 5)
+	set.RPE = floatPtr(8.5)
+	assert.Equal(t, "100.0 kg × 5 @8.5", FormatSet(set, false))
+	assert.Equal(t, "220.5 lbs × 5 @8.5", FormatSet(set, true))
+	assert.Equal(t, "1:30", FormatSet(api.Set{DurationSeconds: intPtr(90)}, false))
+	assert.Equal(t, "-", FormatSet(api.Set{}, false))
+}
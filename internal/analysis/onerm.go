@@ -0,0 +1,230 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// Formula identifies a one-rep max estimation formula
+type Formula string
+
+const (
+	FormulaBrzycki  Formula = "brzycki"
+	FormulaEpley    Formula = "epley"
+	FormulaLombardi Formula = "lombardi"
+	FormulaMayhew   Formula = "mayhew"
+	FormulaWathan   Formula = "wathan"
+	FormulaOConner  Formula = "oconner"
+	FormulaRPE      Formula = "rpe"
+)
+
+// DefaultFormula is used when no formula is configured
+const DefaultFormula = FormulaBrzycki
+
+// Formulas returns all supported formulas in display order
+func Formulas() []Formula {
+	return []Formula{
+		FormulaBrzycki,
+		FormulaEpley,
+		FormulaLombardi,
+		FormulaMayhew,
+		FormulaWathan,
+		FormulaOConner,
+		FormulaRPE,
+	}
+}
+
+// FormulaNames returns the supported formula names as strings
+func FormulaNames() []string {
+	formulas := Formulas()
+	names := make([]string, len(formulas))
+	for i, f := range formulas {
+		names[i] = string(f)
+	}
+	return names
+}
+
+// Estimate is a single estimated one-rep max along with the formula that produced it
+type Estimate struct {
+	Value   float64 `json:"value"`
+	Reps    int     `json:"reps"`
+	Formula Formula `json:"formula"`
+}
+
+// Estimator estimates a one-rep max from a performed set
+type Estimator interface {
+	// Formula returns the formula this estimator is configured for
+	Formula() Formula
+
+	// MaxReps returns the highest rep count the estimator gives a value for
+	MaxReps() int
+
+	// Estimate returns the estimated 1RM for the set, or false if the
+	// set has no usable weight/reps or is outside the estimator's range
+	Estimate(set api.Set) (Estimate, bool)
+}
+
+// NewEstimator returns the estimator for the named formula.
+// An empty name selects DefaultFormula.
+func NewEstimator(name string) (Estimator, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer("'", "", "-", "", "_", "").Replace(name)
+	if name == "" {
+		name = string(DefaultFormula)
+	}
+
+	switch Formula(name) {
+	case FormulaBrzycki:
+		return repFormula{name: FormulaBrzycki, maxReps: 15, fn: brzycki}, nil
+	case FormulaEpley:
+		return repFormula{name: FormulaEpley, maxReps: 15, fn: epley}, nil
+	case FormulaLombardi:
+		return repFormula{name: FormulaLombardi, maxReps: 15, fn: lombardi}, nil
+	case FormulaMayhew:
+		return repFormula{name: FormulaMayhew, maxReps: 15, fn: mayhew}, nil
+	case FormulaWathan:
+		return repFormula{name: FormulaWathan, maxReps: 15, fn: wathan}, nil
+	case FormulaOConner:
+		return repFormula{name: FormulaOConner, maxReps: 15, fn: oconner}, nil
+	case FormulaRPE:
+		fallback, _ := NewEstimator(string(DefaultFormula))
+		return rpeTable{fallback: fallback}, nil
+	default:
+		return nil, fmt.Errorf("unknown 1RM formula: %s (must be one of: %s)",
+			name, strings.Join(FormulaNames(), ", "))
+	}
+}
+
+// BestEstimate returns the highest estimate across the given sets
+func BestEstimate(e Estimator, sets []api.Set) (Estimate, bool) {
+	var best Estimate
+	found := false
+	for _, set := range sets {
+		est, ok := e.Estimate(set)
+		if !ok {
+			continue
+		}
+		if !found || est.Value > best.Value {
+			best = est
+			found = true
+		}
+	}
+	return best, found
+}
+
+// setWeightReps extracts weight and reps from a set, reporting whether both are usable
+func setWeightReps(set api.Set) (float64, int, bool) {
+	if set.WeightKg == nil || set.Reps == nil {
+		return 0, 0, false
+	}
+	if *set.WeightKg <= 0 || *set.Reps <= 0 {
+		return 0, 0, false
+	}
+	return *set.WeightKg, *set.Reps, true
+}
+
+// repFormula is an estimator based on a closed-form weight/reps formula
+type repFormula struct {
+	name    Formula
+	maxReps int
+	fn      func(weight float64, reps int) float64
+}
+
+func (f repFormula) Formula() Formula { return f.name }
+func (f repFormula) MaxReps() int     { return f.maxReps }
+
+func (f repFormula) Estimate(set api.Set) (Estimate, bool) {
+	weight, reps, ok := setWeightReps(set)
+	if !ok || reps > f.maxReps {
+		return Estimate{}, false
+	}
+	value := weight
+	if reps > 1 {
+		value = f.fn(weight, reps)
+	}
+	return Estimate{Value: value, Reps: reps, Formula: f.name}, true
+}
+
+// brzycki: 1RM = weight × 36 / (37 - reps)
+func brzycki(weight float64, reps int) float64 {
+	return weight * 36.0 / (37.0 - float64(reps))
+}
+
+// epley: 1RM = weight × (1 + reps / 30)
+func epley(weight float64, reps int) float64 {
+	return weight * (1 + float64(reps)/30.0)
+}
+
+// lombardi: 1RM = weight × reps^0.10
+func lombardi(weight float64, reps int) float64 {
+	return weight * math.Pow(float64(reps), 0.10)
+}
+
+// mayhew: 1RM = 100 × weight / (52.2 + 41.9 × e^(-0.055 × reps))
+func mayhew(weight float64, reps int) float64 {
+	return 100 * weight / (52.2 + 41.9*math.Exp(-0.055*float64(reps)))
+}
+
+// wathan: 1RM = 100 × weight / (48.8 + 53.8 × e^(-0.075 × reps))
+func wathan(weight float64, reps int) float64 {
+	return 100 * weight / (48.8 + 53.8*math.Exp(-0.075*float64(reps)))
+}
+
+// oconner: 1RM = weight × (1 + 0.025 × reps)
+func oconner(weight float64, reps int) float64 {
+	return weight * (1 + 0.025*float64(reps))
+}
+
+// rpePercentages holds the percentage of 1RM for 1-12 reps taken to
+// failure (RPE 10), following the common RTS chart. Lower RPEs are
+// handled by adding the reps in reserve to the rep count.
+var rpePercentages = []float64{
+	100.0, 95.5, 92.2, 89.2, 86.3, 83.7, 81.1, 78.6, 76.2, 73.9, 70.7, 68.0,
+}
+
+// rpeTable estimates 1RM from reps and RPE using the RTS percentage chart.
+// Sets without an RPE, or whose reps plus reps in reserve go past the end
+// of the chart, are estimated with the fallback formula.
+type rpeTable struct {
+	fallback Estimator
+}
+
+func (t rpeTable) Formula() Formula { return FormulaRPE }
+func (t rpeTable) MaxReps() int     { return len(rpePercentages) }
+
+func (t rpeTable) Estimate(set api.Set) (Estimate, bool) {
+	if set.RPE == nil || *set.RPE < 6 || *set.RPE > 10 {
+		return t.fallback.Estimate(set)
+	}
+
+	weight, reps, ok := setWeightReps(set)
+	if !ok {
+		return Estimate{}, false
+	}
+
+	// Reps in reserve extend the set to an equivalent all-out effort
+	effectiveReps := float64(reps) + (10 - *set.RPE)
+	pct, ok := rpePercentage(effectiveReps)
+	if !ok {
+		return t.fallback.Estimate(set)
+	}
+
+	return Estimate{Value: weight * 100 / pct, Reps: reps, Formula: FormulaRPE}, true
+}
+
+// rpePercentage interpolates the RTS chart for a fractional rep count
+func rpePercentage(effectiveReps float64) (float64, bool) {
+	if effectiveReps < 1 || effectiveReps > float64(len(rpePercentages)) {
+		return 0, false
+	}
+	lower := int(math.Floor(effectiveReps))
+	frac := effectiveReps - float64(lower)
+	pct := rpePercentages[lower-1]
+	if frac > 0 && lower < len(rpePercentages) {
+		pct += (rpePercentages[lower] - pct) * frac
+	}
+	return pct, true
+}
@@ -0,0 +1,138 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+func intPtr(v int) *int           { return &v }
+
+func TestNewEstimator(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Formula
+	}{
+		{name: "empty uses default", input: "", expected: DefaultFormula},
+		{name: "brzycki", input: "brzycki", expected: FormulaBrzycki},
+		{name: "case insensitive", input: "Epley", expected: FormulaEpley},
+		{name: "apostrophe", input: "O'Conner", expected: FormulaOConner},
+		{name: "rpe", input: "rpe", expected: FormulaRPE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEstimator(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, e.Formula())
+		})
+	}
+
+	_, err := NewEstimator("magic")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown 1RM formula")
+}
+
+func TestEstimatorFormulas(t *testing.T) {
+	set := api.Set{WeightKg: floatPtr(100), Reps: intPtr(5)}
+
+	tests := []struct {
+		formula  Formula
+		expected float64
+	}{
+		{FormulaBrzycki, 112.5},
+		{FormulaEpley, 116.67},
+		{FormulaLombardi, 117.46},
+		{FormulaMayhew, 119.0},
+		{FormulaWathan, 116.61},
+		{FormulaOConner, 112.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.formula), func(t *testing.T) {
+			e, err := NewEstimator(string(tt.formula))
+			require.NoError(t, err)
+
+			est, ok := e.Estimate(set)
+			require.True(t, ok)
+			assert.InDelta(t, tt.expected, est.Value, 0.05)
+			assert.Equal(t, 5, est.Reps)
+			assert.Equal(t, tt.formula, est.Formula)
+		})
+	}
+}
+
+func TestEstimatorSingleRep(t *testing.T) {
+	for _, f := range Formulas() {
+		e, err := NewEstimator(string(f))
+		require.NoError(t, err)
+
+		est, ok := e.Estimate(api.Set{WeightKg: floatPtr(140), Reps: intPtr(1)})
+		require.True(t, ok, string(f))
+		assert.InDelta(t, 140, est.Value, 0.001, string(f))
+	}
+}
+
+func TestEstimatorRepLimits(t *testing.T) {
+	brzycki, _ := NewEstimator("brzycki")
+	_, ok := brzycki.Estimate(api.Set{WeightKg: floatPtr(60), Reps: intPtr(12)})
+	assert.True(t, ok)
+	_, ok = brzycki.Estimate(api.Set{WeightKg: floatPtr(60), Reps: intPtr(16)})
+	assert.False(t, ok)
+
+	epley, _ := NewEstimator("epley")
+	_, ok = epley.Estimate(api.Set{WeightKg: floatPtr(60), Reps: intPtr(12)})
+	assert.True(t, ok)
+
+	_, ok = epley.Estimate(api.Set{WeightKg: floatPtr(60)})
+	assert.False(t, ok)
+}
+
+func TestRPEEstimator(t *testing.T) {
+	e, err := NewEstimator("rpe")
+	require.NoError(t, err)
+
+	// 100kg x 3 @ RPE 10 is 92.2% of 1RM
+	est, ok := e.Estimate(api.Set{WeightKg: floatPtr(100), Reps: intPtr(3), RPE: floatPtr(10)})
+	require.True(t, ok)
+	assert.InDelta(t, 108.46, est.Value, 0.01)
+	assert.Equal(t, FormulaRPE, est.Formula)
+
+	// 100kg x 3 @ RPE 8 is equivalent to 5 reps to failure (86.3%)
+	est, ok = e.Estimate(api.Set{WeightKg: floatPtr(100), Reps: intPtr(3), RPE: floatPtr(8)})
+	require.True(t, ok)
+	assert.InDelta(t, 115.87, est.Value, 0.01)
+
+	// Without RPE falls back to the default formula
+	est, ok = e.Estimate(api.Set{WeightKg: floatPtr(100), Reps: intPtr(5)})
+	require.True(t, ok)
+	assert.Equal(t, DefaultFormula, est.Formula)
+
+	// So does a set beyond the chart: 11 reps @ RPE 8 is 13 reps to failure
+	est, ok = e.Estimate(api.Set{WeightKg: floatPtr(60), Reps: intPtr(11), RPE: floatPtr(8)})
+	require.True(t, ok)
+	assert.Equal(t, DefaultFormula, est.Formula)
+}
+
+func TestBestEstimate(t *testing.T) {
+	e, _ := NewEstimator("brzycki")
+	sets := []api.Set{
+		{WeightKg: floatPtr(100), Reps: intPtr(5)},
+		{WeightKg: floatPtr(110), Reps: intPtr(3)},
+		{WeightKg: floatPtr(60), Reps: intPtr(20)},
+		{Reps: intPtr(10)},
+	}
+
+	best, ok := BestEstimate(e, sets)
+	require.True(t, ok)
+	assert.Equal(t, 3, best.Reps)
+	assert.InDelta(t, 116.47, best.Value, 0.01)
+
+	_, ok = BestEstimate(e, nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,408 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// RecordType identifies a kind of personal record
+type RecordType string
+
+const (
+	RecordWeight        RecordType = "weight"
+	RecordEstimated1RM  RecordType = "estimated_1rm"
+	RecordRepMax        RecordType = "rep_max"
+	RecordSessionVolume RecordType = "session_volume"
+	RecordSetVolume     RecordType = "set_volume"
+	RecordDuration      RecordType = "duration"
+	RecordDistance      RecordType = "distance"
+	RecordPace          RecordType = "pace"
+)
+
+// RepMaxLimit is the highest rep count tracked in the rep-max table
+const RepMaxLimit = 12
+
+// RecordTypes returns all record types in display order
+func RecordTypes() []RecordType {
+	return []RecordType{
+		RecordWeight,
+		RecordEstimated1RM,
+		RecordRepMax,
+		RecordSessionVolume,
+		RecordSetVolume,
+		RecordDuration,
+		RecordDistance,
+		RecordPace,
+	}
+}
+
+// ParseRecordTypes parses a comma-separated list of record types.
+// An empty string selects all types.
+func ParseRecordTypes(s string) ([]RecordType, error) {
+	if strings.TrimSpace(s) == "" {
+		return RecordTypes(), nil
+	}
+
+	var types []RecordType
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		found := false
+		for _, t := range RecordTypes() {
+			if string(t) == part {
+				types = append(types, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			names := make([]string, 0, len(RecordTypes()))
+			for _, t := range RecordTypes() {
+				names = append(names, string(t))
+			}
+			return nil, fmt.Errorf("unknown record type: %s (must be one of: %s)", part, strings.Join(names, ", "))
+		}
+	}
+	return types, nil
+}
+
+// lowerIsBetter reports whether smaller values improve the record
+func (t RecordType) lowerIsBetter() bool {
+	return t == RecordPace
+}
+
+// Unit returns the unit the record value is expressed in
+func (t RecordType) Unit() string {
+	switch t {
+	case RecordDuration:
+		return "s"
+	case RecordDistance:
+		return "m"
+	case RecordPace:
+		return "s/km"
+	default:
+		return "kg"
+	}
+}
+
+// Record is the current best value of one record type for one exercise
+type Record struct {
+	Exercise           string        `json:"exercise"`
+	ExerciseTemplateID string        `json:"exercise_template_id,omitempty"`
+	RecordType         RecordType    `json:"record_type"`
+	Value              float64       `json:"value"`
+	Unit               string        `json:"unit"`
+	Reps               int           `json:"reps,omitempty"`
+	Date               string        `json:"date"`
+	WorkoutID          string        `json:"workout_id"`
+	Formula            string        `json:"formula,omitempty"`
+	History            []RecordEntry `json:"history,omitempty"`
+}
+
+// RecordEntry is one point in the evolution of a record
+type RecordEntry struct {
+	Value     float64 `json:"value"`
+	Reps      int     `json:"reps,omitempty"`
+	Date      string  `json:"date"`
+	WorkoutID string  `json:"workout_id"`
+	Formula   string  `json:"formula,omitempty"`
+}
+
+// Improvement describes a record that was set or beaten by a workout
+type Improvement struct {
+	Record   Record       `json:"record"`
+	Previous *RecordEntry `json:"previous,omitempty"`
+}
+
+// recordKey uniquely identifies a record within a RecordBook
+type recordKey struct {
+	exercise   string
+	recordType RecordType
+	reps       int
+}
+
+// RecordBook accumulates personal records across workouts
+type RecordBook struct {
+	estimator Estimator
+	records   map[recordKey]*Record
+	titles    map[string]string
+}
+
+// NewRecordBook creates an empty record book using the given 1RM estimator
+func NewRecordBook(estimator Estimator) *RecordBook {
+	return &RecordBook{
+		estimator: estimator,
+		records:   make(map[recordKey]*Record),
+		titles:    make(map[string]string),
+	}
+}
+
+// ComputeRecords builds a record book from the given workouts in chronological order
+func ComputeRecords(workouts []api.Workout, estimator Estimator) *RecordBook {
+	book := NewRecordBook(estimator)
+	for _, w := range SortedByStart(workouts) {
+		book.AddWorkout(w)
+	}
+	return book
+}
+
+// SortedByStart returns a copy of the workouts ordered oldest first
+func SortedByStart(workouts []api.Workout) []api.Workout {
+	sorted := make([]api.Workout, len(workouts))
+	copy(sorted, workouts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+	return sorted
+}
+
+// exerciseKey groups exercises by template, falling back to the title
+func exerciseKey(ex api.Exercise) string {
+	if ex.ExerciseTemplateID != "" {
+		return ex.ExerciseTemplateID
+	}
+	return strings.ToLower(ex.Title)
+}
+
+// candidate is a possible new record value found in a workout
+type candidate struct {
+	key     recordKey
+	value   float64
+	reps    int
+	formula string
+}
+
+// AddWorkout updates the book with a workout and returns the records it improved.
+// Workouts must be added in chronological order for history to be meaningful.
+func (b *RecordBook) AddWorkout(w api.Workout) []Improvement {
+	var improvements []Improvement
+	date := w.StartTime.Format("2006-01-02")
+
+	for _, ex := range w.Exercises {
+		exKey := exerciseKey(ex)
+		b.titles[exKey] = ex.Title
+
+		for _, c := range b.candidates(exKey, ex) {
+			entry := RecordEntry{
+				Value:     round(c.value, 2),
+				Reps:      c.reps,
+				Date:      date,
+				WorkoutID: w.ID,
+				Formula:   c.formula,
+			}
+
+			rec, exists := b.records[c.key]
+			if !exists {
+				rec = &Record{
+					Exercise:           ex.Title,
+					ExerciseTemplateID: ex.ExerciseTemplateID,
+					RecordType:         c.key.recordType,
+					Unit:               c.key.recordType.Unit(),
+				}
+				b.records[c.key] = rec
+			} else if !improves(c.key.recordType, entry.Value, rec.Value) {
+				continue
+			}
+
+			var previous *RecordEntry
+			if exists {
+				prev := rec.History[len(rec.History)-1]
+				previous = &prev
+			}
+
+			rec.Value = entry.Value
+			rec.Reps = entry.Reps
+			rec.Date = entry.Date
+			rec.WorkoutID = entry.WorkoutID
+			rec.Formula = entry.Formula
+			rec.History = append(rec.History, entry)
+
+			current := *rec
+			current.History = nil
+			improvements = append(improvements, Improvement{Record: current, Previous: previous})
+		}
+	}
+
+	return improvements
+}
+
+// candidates computes the best value of every record type for one exercise in a workout
+func (b *RecordBook) candidates(exKey string, ex api.Exercise) []candidate {
+	var maxWeight, sessionVolume, maxSetVolume, maxDuration, maxDistance, bestPace float64
+	var maxWeightReps, maxSetVolumeReps int
+	hasPace := false
+	repMax := make(map[int]float64)
+
+	for _, set := range ex.Sets {
+		weight, reps := 0.0, 0
+		if set.WeightKg != nil {
+			weight = *set.WeightKg
+		}
+		if set.Reps != nil {
+			reps = *set.Reps
+		}
+
+		if weight > maxWeight {
+			maxWeight = weight
+			maxWeightReps = reps
+		}
+
+		if weight > 0 && reps > 0 {
+			volume := weight * float64(reps)
+			sessionVolume += volume
+			if volume > maxSetVolume {
+				maxSetVolume = volume
+				maxSetVolumeReps = reps
+			}
+			if reps <= RepMaxLimit && weight > repMax[reps] {
+				repMax[reps] = weight
+			}
+		}
+
+		if set.DurationSeconds != nil && float64(*set.DurationSeconds) > maxDuration {
+			maxDuration = float64(*set.DurationSeconds)
+		}
+
+		if set.DistanceMeters != nil && *set.DistanceMeters > maxDistance {
+			maxDistance = *set.DistanceMeters
+		}
+
+		if set.DistanceMeters != nil && set.DurationSeconds != nil &&
+			*set.DistanceMeters > 0 && *set.DurationSeconds > 0 {
+			pace := float64(*set.DurationSeconds) / (*set.DistanceMeters / 1000)
+			if !hasPace || pace < bestPace {
+				bestPace = pace
+				hasPace = true
+			}
+		}
+	}
+
+	key := func(t RecordType, reps int) recordKey {
+		return recordKey{exercise: exKey, recordType: t, reps: reps}
+	}
+
+	var out []candidate
+	if maxWeight > 0 {
+		out = append(out, candidate{key: key(RecordWeight, 0), value: maxWeight, reps: maxWeightReps})
+	}
+	if b.estimator != nil {
+		if est, ok := BestEstimate(b.estimator, ex.Sets); ok {
+			out = append(out, candidate{key: key(RecordEstimated1RM, 0), value: est.Value, reps: est.Reps, formula: string(est.Formula)})
+		}
+	}
+	for reps := 1; reps <= RepMaxLimit; reps++ {
+		if weight, ok := repMax[reps]; ok {
+			out = append(out, candidate{key: key(RecordRepMax, reps), value: weight, reps: reps})
+		}
+	}
+	if sessionVolume > 0 {
+		out = append(out, candidate{key: key(RecordSessionVolume, 0), value: sessionVolume})
+	}
+	if maxSetVolume > 0 {
+		out = append(out, candidate{key: key(RecordSetVolume, 0), value: maxSetVolume, reps: maxSetVolumeReps})
+	}
+	if maxDuration > 0 {
+		out = append(out, candidate{key: key(RecordDuration, 0), value: maxDuration})
+	}
+	if maxDistance > 0 {
+		out = append(out, candidate{key: key(RecordDistance, 0), value: maxDistance})
+	}
+	if hasPace {
+		out = append(out, candidate{key: key(RecordPace, 0), value: bestPace})
+	}
+	return out
+}
+
+// improves reports whether value beats the current record value
+func improves(t RecordType, value, current float64) bool {
+	if t.lowerIsBetter() {
+		return value < current
+	}
+	return value > current
+}
+
+// Records returns the current records filtered by exercise name and record type,
+// grouped by exercise. Exercises are ordered by their heaviest weight record.
+// When withHistory is false the History field is omitted.
+func (b *RecordBook) Records(exerciseFilter string, types []RecordType, withHistory bool) []Record {
+	wanted := make(map[RecordType]bool)
+	for _, t := range types {
+		wanted[t] = true
+	}
+	typeOrder := make(map[RecordType]int)
+	for i, t := range RecordTypes() {
+		typeOrder[t] = i
+	}
+
+	exerciseRank := make(map[string]float64)
+	var out []Record
+	for key, rec := range b.records {
+		if exerciseFilter != "" &&
+			!strings.Contains(strings.ToLower(b.titles[key.exercise]), strings.ToLower(exerciseFilter)) {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[key.recordType] {
+			continue
+		}
+
+		r := *rec
+		r.Exercise = b.titles[key.exercise]
+		if withHistory {
+			r.History = append([]RecordEntry(nil), rec.History...)
+		} else {
+			r.History = nil
+		}
+		out = append(out, r)
+	}
+
+	for key, rec := range b.records {
+		if key.recordType == RecordWeight || key.recordType == RecordEstimated1RM {
+			if rec.Value > exerciseRank[b.titles[key.exercise]] {
+				exerciseRank[b.titles[key.exercise]] = rec.Value
+			}
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, c := out[i], out[j]
+		if a.Exercise != c.Exercise {
+			if exerciseRank[a.Exercise] != exerciseRank[c.Exercise] {
+				return exerciseRank[a.Exercise] > exerciseRank[c.Exercise]
+			}
+			return a.Exercise < c.Exercise
+		}
+		if a.RecordType != c.RecordType {
+			return typeOrder[a.RecordType] < typeOrder[c.RecordType]
+		}
+		return a.Reps < c.Reps
+	})
+
+	return out
+}
+
+// LimitExercises keeps the records of the first n distinct exercises
+func LimitExercises(records []Record, n int) []Record {
+	if n <= 0 {
+		return records
+	}
+	seen := make(map[string]bool)
+	var out []Record
+	for _, r := range records {
+		if !seen[r.Exercise] {
+			if len(seen) >= n {
+				break
+			}
+			seen[r.Exercise] = true
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
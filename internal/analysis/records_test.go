@@ -0,0 +1,164 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func weightSet(weight float64, reps int) api.Set {
+	return api.Set{SetType: api.SetTypeNormal, WeightKg: floatPtr(weight), Reps: intPtr(reps)}
+}
+
+func testWorkout(id string, day int, exercises ...api.Exercise) api.Workout {
+	start := time.Date(2024, 1, day, 9, 0, 0, 0, time.UTC)
+	return api.Workout{
+		ID:        id,
+		Title:     "Workout " + id,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Exercises: exercises,
+	}
+}
+
+func findRecord(records []Record, t RecordType, reps int) *Record {
+	for i := range records {
+		if records[i].RecordType == t && (t != RecordRepMax || records[i].Reps == reps) {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+func TestComputeRecords_Strength(t *testing.T) {
+	estimator, _ := NewEstimator("brzycki")
+	workouts := []api.Workout{
+		testWorkout("w2", 8, api.Exercise{
+			Title: "Bench Press", ExerciseTemplateID: "BP",
+			Sets: []api.Set{weightSet(100, 5), weightSet(105, 3)},
+		}),
+		testWorkout("w1", 1, api.Exercise{
+			Title: "Bench Press", ExerciseTemplateID: "BP",
+			Sets: []api.Set{weightSet(90, 5), weightSet(90, 5)},
+		}),
+	}
+
+	book := ComputeRecords(workouts, estimator)
+	records := book.Records("", nil, true)
+
+	weight := findRecord(records, RecordWeight, 0)
+	require.NotNil(t, weight)
+	assert.Equal(t, 105.0, weight.Value)
+	assert.Equal(t, "w2", weight.WorkoutID)
+	assert.Equal(t, "2024-01-08", weight.Date)
+	require.Len(t, weight.History, 2)
+	assert.Equal(t, 90.0, weight.History[0].Value)
+
+	fiveRM := findRecord(records, RecordRepMax, 5)
+	require.NotNil(t, fiveRM)
+	assert.Equal(t, 100.0, fiveRM.Value)
+
+	sessionVolume := findRecord(records, RecordSessionVolume, 0)
+	require.NotNil(t, sessionVolume)
+	assert.Equal(t, 900.0, sessionVolume.Value)
+	assert.Equal(t, "w1", sessionVolume.WorkoutID)
+
+	setVolume := findRecord(records, RecordSetVolume, 0)
+	require.NotNil(t, setVolume)
+	assert.Equal(t, 500.0, setVolume.Value)
+
+	e1rm := findRecord(records, RecordEstimated1RM, 0)
+	require.NotNil(t, e1rm)
+	assert.Equal(t, "brzycki", e1rm.Formula)
+}
+
+func TestComputeRecords_Cardio(t *testing.T) {
+	run := func(distance float64, seconds int) api.Set {
+		return api.Set{DistanceMeters: floatPtr(distance), DurationSeconds: intPtr(seconds)}
+	}
+	workouts := []api.Workout{
+		testWorkout("w1", 1, api.Exercise{Title: "Running", Sets: []api.Set{run(5000, 1500)}}),
+		testWorkout("w2", 2, api.Exercise{Title: "Running", Sets: []api.Set{run(10000, 3300)}}),
+		testWorkout("w3", 3, api.Exercise{Title: "Running", Sets: []api.Set{run(3000, 840)}}),
+	}
+
+	book := ComputeRecords(workouts, nil)
+	records := book.Records("run", nil, false)
+
+	distance := findRecord(records, RecordDistance, 0)
+	require.NotNil(t, distance)
+	assert.Equal(t, 10000.0, distance.Value)
+
+	duration := findRecord(records, RecordDuration, 0)
+	require.NotNil(t, duration)
+	assert.Equal(t, 3300.0, duration.Value)
+
+	pace := findRecord(records, RecordPace, 0)
+	require.NotNil(t, pace)
+	assert.Equal(t, 280.0, pace.Value)
+	assert.Equal(t, "w3", pace.WorkoutID)
+	assert.Nil(t, pace.History)
+}
+
+func TestRecordBook_AddWorkoutImprovements(t *testing.T) {
+	estimator, _ := NewEstimator("epley")
+	book := NewRecordBook(estimator)
+
+	first := book.AddWorkout(testWorkout("w1", 1, api.Exercise{
+		Title: "Squat", ExerciseTemplateID: "SQ", Sets: []api.Set{weightSet(100, 5)},
+	}))
+	for _, imp := range first {
+		assert.Nil(t, imp.Previous)
+	}
+
+	second := book.AddWorkout(testWorkout("w2", 2, api.Exercise{
+		Title: "Squat", ExerciseTemplateID: "SQ", Sets: []api.Set{weightSet(110, 5)},
+	}))
+	require.NotEmpty(t, second)
+	for _, imp := range second {
+		require.NotNil(t, imp.Previous)
+		assert.Equal(t, "w1", imp.Previous.WorkoutID)
+		assert.Equal(t, "w2", imp.Record.WorkoutID)
+	}
+
+	third := book.AddWorkout(testWorkout("w3", 3, api.Exercise{
+		Title: "Squat", ExerciseTemplateID: "SQ", Sets: []api.Set{weightSet(80, 5)},
+	}))
+	assert.Empty(t, third)
+}
+
+func TestParseRecordTypes(t *testing.T) {
+	all, err := ParseRecordTypes("")
+	require.NoError(t, err)
+	assert.Equal(t, RecordTypes(), all)
+
+	types, err := ParseRecordTypes("weight, Pace")
+	require.NoError(t, err)
+	assert.Equal(t, []RecordType{RecordWeight, RecordPace}, types)
+
+	_, err = ParseRecordTypes("speed")
+	assert.Error(t, err)
+}
+
+func TestLimitExercises(t *testing.T) {
+	records := []Record{
+		{Exercise: "A", RecordType: RecordWeight},
+		{Exercise: "A", RecordType: RecordEstimated1RM},
+		{Exercise: "B", RecordType: RecordWeight},
+		{Exercise: "C", RecordType: RecordWeight},
+	}
+
+	assert.Len(t, LimitExercises(records, 2), 3)
+	assert.Len(t, LimitExercises(records, 0), 4)
+}
+
+func TestFormatRecordValue(t *testing.T) {
+	assert.Equal(t, "100.0 kg × 5", FormatRecordValue(RecordWeight, 100, 5, false))
+	assert.Equal(t, "220.5 lbs × 5", FormatRecordValue(RecordWeight, 100, 5, true))
+	assert.Equal(t, "1102.3 lbs", FormatRecordValue(RecordSessionVolume, 500, 0, true))
+	assert.Equal(t, "1:30", FormatRecordValue(RecordDuration, 90, 0, true))
+}
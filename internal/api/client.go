@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -24,11 +25,22 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *resty.Client
+	logger     *slog.Logger
+	transport  http.RoundTripper
 }
 
 // ClientOption is a function that configures the client
 type ClientOption func(*Client)
 
+// defaultOptions are applied to every new client before its own options
+var defaultOptions []ClientOption
+
+// SetDefaultOptions sets options applied to every client created afterwards,
+// such as logging and tracing selected by global flags
+func SetDefaultOptions(opts ...ClientOption) {
+	defaultOptions = opts
+}
+
 // NewClient creates a new Hevy API client
 func NewClient(apiKey string, opts ...ClientOption) *Client {
 	c := &Client{
@@ -36,6 +48,9 @@ func NewClient(apiKey string, opts ...ClientOption) *Client {
 		apiKey:  apiKey,
 	}
 
+	for _, opt := range defaultOptions {
+		opt(c)
+	}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -48,6 +63,14 @@ func NewClient(apiKey string, opts ...ClientOption) *Client {
 		SetHeader("Accept", "application/json").
 		SetTimeout(DefaultTimeout)
 
+	if c.transport != nil {
+		c.httpClient.SetTransport(c.transport)
+	}
+	if c.logger != nil {
+		c.httpClient.OnAfterResponse(c.logResponse)
+		c.httpClient.OnError(c.logError)
+	}
+
 	return c
 }
 
@@ -67,6 +90,53 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithLogger logs every request to logger at debug level
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithTransport sends requests through a custom transport, such as a
+// Recorder or a Replayer
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// logResponse logs a completed request. Requests are never retried, so
+// there is no retry count to log.
+func (c *Client) logResponse(_ *resty.Client, resp *resty.Response) error {
+	req := resp.Request
+	c.logger.Debug("http request",
+		"method", req.Method,
+		"path", requestPath(req),
+		"query", req.QueryParam.Encode(),
+		"status", resp.StatusCode(),
+		"latency", resp.Time(),
+	)
+	return nil
+}
+
+// logError logs a request that failed without a response
+func (c *Client) logError(req *resty.Request, err error) {
+	c.logger.Debug("http request failed",
+		"method", req.Method,
+		"path", requestPath(req),
+		"query", req.QueryParam.Encode(),
+		"error", err.Error(),
+	)
+}
+
+// requestPath returns the URL path of a request
+func requestPath(req *resty.Request) string {
+	if req.RawRequest != nil {
+		return req.RawRequest.URL.Path
+	}
+	return req.URL
+}
+
 // ValidateAuth tests if the API key is valid by calling /workouts endpoint
 func (c *Client) ValidateAuth() error {
 	var result WorkoutsResponse
@@ -255,26 +325,54 @@ func (c *Client) GetRoutineFolders(page, pageSize int) (*RoutineFoldersResponse,
 
 // handleResponse checks for API errors based on HTTP status code
 func (c *Client) handleResponse(resp *resty.Response) error {
-	switch resp.StatusCode() {
-	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+	status := resp.StatusCode()
+	if status < 400 {
 		return nil
+	}
+
+	var apiErr APIError
+	switch status {
 	case http.StatusUnauthorized:
-		return ErrInvalidAPIKey
+		apiErr = *ErrInvalidAPIKey
 	case http.StatusForbidden:
-		return ErrForbidden
+		apiErr = *ErrForbidden
 	case http.StatusNotFound:
-		return ErrNotFound
+		apiErr = *ErrNotFound
 	case http.StatusTooManyRequests:
-		return ErrRateLimited
+		apiErr = *ErrRateLimited
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		message, fields := parseErrorBody(resp.Body())
+		if message == "" {
+			message = "The request was rejected"
+		}
+		apiErr = APIError{ErrorCode: "VALIDATION_ERROR", ErrorMessage: message, Fields: fields}
 	default:
-		if resp.StatusCode() >= 400 {
-			return &APIError{
-				ErrorCode:    "API_ERROR",
-				ErrorMessage: fmt.Sprintf("API returned status %d: %s", resp.StatusCode(), resp.String()),
-			}
+		code := "API_ERROR"
+		if status >= 500 {
+			code = "SERVER_ERROR"
+		}
+		apiErr = APIError{
+			ErrorCode:    code,
+			ErrorMessage: fmt.Sprintf("API returned status %d: %s", status, resp.String()),
+		}
+	}
+
+	apiErr.StatusCode = status
+	apiErr.RequestID = requestID(resp.Header())
+	return &apiErr
+}
+
+// requestIDHeaders are response headers that may identify a request
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Amz-Cf-Id", "Cf-Ray"}
+
+// requestID returns the request ID sent by the API, if any
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
 		}
-		return nil
 	}
+	return ""
 }
 
 // CreateWorkout creates a new workout
@@ -466,28 +564,74 @@ func (c *Client) GetExerciseTemplate(id string) (*ExerciseTemplate, error) {
 	return &result.ExerciseTemplate, nil
 }
 
-// GetAllWorkouts fetches all workouts using pagination
-func (c *Client) GetAllWorkouts() ([]Workout, error) {
-	var allWorkouts []Workout
-	page := 1
-	pageSize := 10 // API max is 10
+// Largest page sizes the API accepts for each list endpoint
+const (
+	maxWorkoutsPageSize          = 10
+	maxRoutinesPageSize          = 10
+	maxRoutineFoldersPageSize    = 10
+	maxExerciseTemplatesPageSize = 100
+)
 
-	for {
-		resp, err := c.GetWorkouts(page, pageSize)
+// getAll fetches every page of a list endpoint. fetch returns the items of
+// one page and the total page count.
+func getAll[T any](pageSize int, fetch func(page, pageSize int) ([]T, int, error)) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		items, pageCount, err := fetch(page, pageSize)
 		if err != nil {
 			return nil, err
 		}
-
-		allWorkouts = append(allWorkouts, resp.Workouts...)
+		all = append(all, items...)
 
 		// Check if there are more pages
-		if page >= resp.PageCount || len(resp.Workouts) == 0 {
-			break
+		if page >= pageCount || len(items) == 0 {
+			return all, nil
 		}
-		page++
 	}
+}
 
-	return allWorkouts, nil
+// GetAllWorkouts fetches all workouts using pagination
+func (c *Client) GetAllWorkouts() ([]Workout, error) {
+	return getAll(maxWorkoutsPageSize, func(page, pageSize int) ([]Workout, int, error) {
+		resp, err := c.GetWorkouts(page, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Workouts, resp.PageCount, nil
+	})
+}
+
+// GetAllExerciseTemplates fetches all exercise templates using pagination
+func (c *Client) GetAllExerciseTemplates() ([]ExerciseTemplate, error) {
+	return getAll(maxExerciseTemplatesPageSize, func(page, pageSize int) ([]ExerciseTemplate, int, error) {
+		resp, err := c.GetExerciseTemplates(page, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.ExerciseTemplates, resp.PageCount, nil
+	})
+}
+
+// GetAllRoutines fetches all routines using pagination
+func (c *Client) GetAllRoutines() ([]Routine, error) {
+	return getAll(maxRoutinesPageSize, func(page, pageSize int) ([]Routine, int, error) {
+		resp, err := c.GetRoutines(page, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Routines, resp.PageCount, nil
+	})
+}
+
+// GetAllRoutineFolders fetches all routine folders using pagination
+func (c *Client) GetAllRoutineFolders() ([]RoutineFolder, error) {
+	return getAll(maxRoutineFoldersPageSize, func(page, pageSize int) ([]RoutineFolder, int, error) {
+		resp, err := c.GetRoutineFolders(page, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.RoutineFolders, resp.PageCount, nil
+	})
 }
 
 // GetWorkoutEvents fetches workout events (updates/deletes) since a given time
@@ -152,6 +152,59 @@ func TestGetWorkout_NotFound(t *testing.T) {
 	assert.Equal(t, "NOT_FOUND", apiErr.ErrorCode)
 }
 
+func TestHandleResponse_ValidationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "Invalid request body", "errors": [{"field": "workout.title", "message": "is required"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL))
+	_, err := client.CreateWorkout(&CreateWorkoutRequest{})
+	require.Error(t, err)
+
+	apiErr, ok := err.(*APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_ERROR", apiErr.ErrorCode)
+	assert.Equal(t, "Invalid request body", apiErr.ErrorMessage)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "req-123", apiErr.RequestID)
+	assert.Equal(t, []FieldError{{Field: "workout.title", Message: "is required"}}, apiErr.Fields)
+	assert.Equal(t, ExitValidationError, apiErr.ExitCode())
+}
+
+func TestHandleResponse_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL))
+	_, err := client.GetWorkoutCount()
+	require.Error(t, err)
+
+	apiErr, ok := err.(*APIError)
+	require.True(t, ok)
+	assert.Equal(t, "SERVER_ERROR", apiErr.ErrorCode)
+	assert.True(t, apiErr.Retryable())
+}
+
+func TestHandleResponse_DoesNotModifyPredefinedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL))
+	_, err := client.GetWorkout("missing")
+	require.Error(t, err)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, http.StatusNotFound, err.(*APIError).StatusCode)
+	assert.Zero(t, ErrNotFound.StatusCode)
+}
+
 func TestGetWorkoutCount(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/workouts/count", r.URL.Path)
@@ -213,3 +266,26 @@ func TestGetExerciseTemplates(t *testing.T) {
 	assert.Len(t, resp.ExerciseTemplates, 1)
 	assert.Equal(t, "Bench Press", resp.ExerciseTemplates[0].Title)
 }
+
+func TestGetAllExerciseTemplates(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("pageSize"))
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ExerciseTemplatesResponse{
+			PageCount:         2,
+			ExerciseTemplates: []ExerciseTemplate{{ID: "ex-" + page}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL))
+	templates, err := client.GetAllExerciseTemplates()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "ex-2", templates[1].ID)
+}
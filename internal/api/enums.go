@@ -0,0 +1,239 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExerciseTypes lists every exercise type accepted by the API
+var ExerciseTypes = []ExerciseType{
+	ExerciseTypeWeightReps,
+	ExerciseTypeRepsOnly,
+	ExerciseTypeBodyweightReps,
+	ExerciseTypeBodyweightAssisted,
+	ExerciseTypeDuration,
+	ExerciseTypeWeightDuration,
+	ExerciseTypeDistanceDuration,
+	ExerciseTypeShortDistanceWeight,
+}
+
+// MuscleGroups lists every muscle group accepted by the API
+var MuscleGroups = []MuscleGroup{
+	MuscleGroupChest,
+	MuscleGroupShoulders,
+	MuscleGroupBiceps,
+	MuscleGroupTriceps,
+	MuscleGroupForearms,
+	MuscleGroupLats,
+	MuscleGroupUpperBack,
+	MuscleGroupTraps,
+	MuscleGroupLowerBack,
+	MuscleGroupAbdominals,
+	MuscleGroupQuadriceps,
+	MuscleGroupHamstrings,
+	MuscleGroupGlutes,
+	MuscleGroupCalves,
+	MuscleGroupAbductors,
+	MuscleGroupAdductors,
+	MuscleGroupCardio,
+	MuscleGroupNeck,
+	MuscleGroupFullBody,
+	MuscleGroupOther,
+}
+
+// EquipmentCategories lists every equipment category accepted by the API
+var EquipmentCategories = []EquipmentCategory{
+	EquipmentNone,
+	EquipmentBarbell,
+	EquipmentDumbbell,
+	EquipmentKettlebell,
+	EquipmentMachine,
+	EquipmentPlate,
+	EquipmentResistanceBand,
+	EquipmentSuspension,
+	EquipmentOther,
+}
+
+// RPEValues lists every RPE accepted by the API
+var RPEValues = []float64{6, 7, 7.5, 8, 8.5, 9, 9.5, 10}
+
+// CheckRPE returns an error unless rpe is one of RPEValues
+func CheckRPE(rpe float64) error {
+	for _, v := range RPEValues {
+		if v == rpe {
+			return nil
+		}
+	}
+	values := make([]string, len(RPEValues))
+	for i, v := range RPEValues {
+		values[i] = fmt.Sprintf("%g", v)
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(values, ", "))
+}
+
+// exerciseTypeNames are the names the Hevy app shows for exercise types
+var exerciseTypeNames = map[ExerciseType]string{
+	ExerciseTypeWeightReps:          "Weight & Reps",
+	ExerciseTypeRepsOnly:            "Reps Only",
+	ExerciseTypeBodyweightReps:      "Bodyweight Reps",
+	ExerciseTypeBodyweightAssisted:  "Assisted Bodyweight",
+	ExerciseTypeDuration:            "Duration",
+	ExerciseTypeWeightDuration:      "Weight & Duration",
+	ExerciseTypeDistanceDuration:    "Distance & Duration",
+	ExerciseTypeShortDistanceWeight: "Weight & Distance",
+}
+
+// Valid reports whether t is a known exercise type
+func (t ExerciseType) Valid() bool {
+	for _, v := range ExerciseTypes {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether m is a known muscle group
+func (m MuscleGroup) Valid() bool {
+	for _, v := range MuscleGroups {
+		if v == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether e is a known equipment category
+func (e EquipmentCategory) Valid() bool {
+	for _, v := range EquipmentCategories {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// DisplayName returns the name of the exercise type, e.g. "Weight & Reps"
+func (t ExerciseType) DisplayName() string {
+	if name, ok := exerciseTypeNames[t]; ok {
+		return name
+	}
+	return titleCase(string(t))
+}
+
+// DisplayName returns the name of the muscle group, e.g. "Upper Back"
+func (m MuscleGroup) DisplayName() string {
+	return titleCase(string(m))
+}
+
+// DisplayName returns the name of the equipment category, e.g.
+// "Resistance Band"
+func (e EquipmentCategory) DisplayName() string {
+	return titleCase(string(e))
+}
+
+// EnumValues returns the valid values for JSON Schema
+func (ExerciseType) EnumValues() []string { return enumStrings(ExerciseTypes) }
+
+// EnumValues returns the valid values for JSON Schema
+func (MuscleGroup) EnumValues() []string { return enumStrings(MuscleGroups) }
+
+// EnumValues returns the valid values for JSON Schema
+func (EquipmentCategory) EnumValues() []string { return enumStrings(EquipmentCategories) }
+
+// ParseExerciseType parses an exercise type by value or display name,
+// ignoring case, e.g. "weight_reps" or "Weight & Reps"
+func ParseExerciseType(s string) (ExerciseType, error) {
+	return parseEnum("exercise type", s, ExerciseTypes, ExerciseType.DisplayName)
+}
+
+// ParseMuscleGroup parses a muscle group by value or display name, ignoring
+// case, e.g. "upper_back" or "Upper Back"
+func ParseMuscleGroup(s string) (MuscleGroup, error) {
+	return parseEnum("muscle group", s, MuscleGroups, MuscleGroup.DisplayName)
+}
+
+// ParseEquipmentCategory parses an equipment category by value or display
+// name, ignoring case, e.g. "resistance_band" or "Resistance Band"
+func ParseEquipmentCategory(s string) (EquipmentCategory, error) {
+	return parseEnum("equipment", s, EquipmentCategories, EquipmentCategory.DisplayName)
+}
+
+// parseEnum finds the value of s among values. The error suggests a close
+// value when there is one and lists the valid values otherwise.
+func parseEnum[T ~string](kind, s string, values []T, display func(T) string) (T, error) {
+	key := enumKey(s)
+	for _, v := range values {
+		if key == string(v) || key == enumKey(display(v)) {
+			return v, nil
+		}
+	}
+
+	// Suggest a value that starts with the input, or one a typo or two away
+	var suggestion T
+	best := 3
+	if len(key) <= 4 {
+		best = 2
+	}
+	for _, v := range values {
+		d := editDistance(key, string(v))
+		if key != "" && strings.HasPrefix(string(v), key) {
+			d = 0
+		}
+		if d < best {
+			suggestion, best = v, d
+		}
+	}
+	if suggestion != "" {
+		return "", fmt.Errorf("unknown %s %q (did you mean %s?)", kind, s, suggestion)
+	}
+	return "", fmt.Errorf("unknown %s %q (valid: %s)", kind, s, strings.Join(enumStrings(values), ", "))
+}
+
+// enumKey normalizes s for comparison with enum values: lowercase with
+// spaces and hyphens as underscores, and "&" dropped
+func enumKey(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "&", " ")
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == '-' || r == '_' }), "_")
+}
+
+func enumStrings[T ~string](values []T) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return s
+}
+
+// titleCase turns "upper_back" into "Upper Back"
+func titleCase(s string) string {
+	words := strings.Split(s, "_")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
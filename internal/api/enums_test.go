@@ -0,0 +1,101 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnums(t *testing.T) {
+	tests := []struct {
+		in   string
+		want MuscleGroup
+	}{
+		{"chest", MuscleGroupChest},
+		{"Upper Back", MuscleGroupUpperBack},
+		{" full-body ", MuscleGroupFullBody},
+		{"LOWER_BACK", MuscleGroupLowerBack},
+	}
+	for _, tt := range tests {
+		got, err := ParseMuscleGroup(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got)
+	}
+
+	typ, err := ParseExerciseType("Weight & Reps")
+	require.NoError(t, err)
+	assert.Equal(t, ExerciseTypeWeightReps, typ)
+	typ, err = ParseExerciseType("assisted bodyweight")
+	require.NoError(t, err)
+	assert.Equal(t, ExerciseTypeBodyweightAssisted, typ)
+
+	equipment, err := ParseEquipmentCategory("Resistance Band")
+	require.NoError(t, err)
+	assert.Equal(t, EquipmentResistanceBand, equipment)
+}
+
+func TestParseEnums_Errors(t *testing.T) {
+	_, err := ParseMuscleGroup("tricep")
+	assert.EqualError(t, err, `unknown muscle group "tricep" (did you mean triceps?)`)
+	_, err = ParseMuscleGroup("quad")
+	assert.EqualError(t, err, `unknown muscle group "quad" (did you mean quadriceps?)`)
+	_, err = ParseEquipmentCategory("dumbell")
+	assert.EqualError(t, err, `unknown equipment "dumbell" (did you mean dumbbell?)`)
+
+	// Short inputs only get a suggestion one typo away
+	_, err = ParseMuscleGroup("pecs")
+	assert.EqualError(t, err, `unknown muscle group "pecs" (valid: `+
+		`chest, shoulders, biceps, triceps, forearms, lats, upper_back, traps, lower_back, abdominals, `+
+		`quadriceps, hamstrings, glutes, calves, abductors, adductors, cardio, neck, full_body, other)`)
+	_, err = ParseExerciseType("")
+	assert.Error(t, err)
+}
+
+func TestDisplayName(t *testing.T) {
+	assert.Equal(t, "Weight & Reps", ExerciseTypeWeightReps.DisplayName())
+	assert.Equal(t, "Upper Back", MuscleGroupUpperBack.DisplayName())
+	assert.Equal(t, "Resistance Band", EquipmentResistanceBand.DisplayName())
+	// Values the client does not know yet still read well
+	assert.Equal(t, "Sled Push", ExerciseType("sled_push").DisplayName())
+}
+
+func TestEnumsValid(t *testing.T) {
+	assert.Len(t, ExerciseTypes, 8)
+	assert.Len(t, MuscleGroups, 20)
+	assert.Len(t, EquipmentCategories, 9)
+	assert.True(t, MuscleGroupNeck.Valid())
+	assert.False(t, MuscleGroup("pecs").Valid())
+	assert.Equal(t, len(MuscleGroups), len(MuscleGroupNeck.EnumValues()))
+}
+
+func TestTemplateFilter(t *testing.T) {
+	bench := ExerciseTemplate{Title: "Bench Press", Type: ExerciseTypeWeightReps, PrimaryMuscleGroup: MuscleGroupChest,
+		SecondaryMuscleGroups: []MuscleGroup{MuscleGroupTriceps}, Equipment: EquipmentBarbell}
+
+	assert.True(t, TemplateFilter{}.Match(bench))
+	assert.True(t, TemplateFilter{Muscle: MuscleGroupTriceps}.Match(bench))
+	assert.False(t, TemplateFilter{Muscle: MuscleGroupBiceps}.Match(bench))
+	assert.True(t, TemplateFilter{Equipment: EquipmentBarbell, Type: ExerciseTypeWeightReps}.Match(bench))
+	assert.False(t, TemplateFilter{Equipment: EquipmentDumbbell}.Match(bench))
+	assert.False(t, TemplateFilter{Type: ExerciseTypeDuration}.Match(bench))
+
+	f, rest, err := ParseTemplateFilter("incline muscle:Chest press Equipment:dumbbell type:")
+	require.NoError(t, err)
+	assert.Equal(t, TemplateFilter{Muscle: MuscleGroupChest, Equipment: EquipmentDumbbell}, f)
+	assert.Equal(t, "incline press", rest)
+
+	_, _, err = ParseTemplateFilter("press muscle:pecs")
+	assert.Error(t, err)
+
+	assert.Equal(t, "Chest • Barbell", bench.Summary())
+	assert.Equal(t, "Triceps", bench.SecondaryMuscles())
+}
+
+func TestCheckRPE(t *testing.T) {
+	assert.NoError(t, CheckRPE(6))
+	assert.NoError(t, CheckRPE(9.5))
+	assert.EqualError(t, CheckRPE(6.5), "must be one of 6, 7, 7.5, 8, 8.5, 9, 9.5, 10")
+	assert.Error(t, CheckRPE(5))
+	assert.Error(t, CheckRPE(8.3))
+}
@@ -1,6 +1,13 @@
 package api
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
 
 // Exit codes for different error types
 const (
@@ -19,12 +26,33 @@ type APIError struct {
 	ErrorCode    string `json:"code"`
 	ErrorMessage string `json:"message"`
 	ErrorDetails string `json:"details,omitempty"`
+
+	// StatusCode is the HTTP status of the response, or 0 if none was received
+	StatusCode int `json:"status,omitempty"`
+	// RequestID identifies the request in the API's logs, when it sends one
+	RequestID string `json:"request_id,omitempty"`
+	// Fields lists field-level problems reported for validation errors
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError is a validation problem with a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // Error implements the error interface
 func (e *APIError) Error() string {
-	if e.ErrorDetails != "" {
-		return fmt.Sprintf("%s: %s (%s)", e.ErrorCode, e.ErrorMessage, e.ErrorDetails)
+	details := e.ErrorDetails
+	if details == "" && len(e.Fields) > 0 {
+		parts := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			parts[i] = f.Field + ": " + f.Message
+		}
+		details = strings.Join(parts, "; ")
+	}
+	if details != "" {
+		return fmt.Sprintf("%s: %s (%s)", e.ErrorCode, e.ErrorMessage, details)
 	}
 	return fmt.Sprintf("%s: %s", e.ErrorCode, e.ErrorMessage)
 }
@@ -34,10 +62,26 @@ func (e *APIError) Code() string {
 	return e.ErrorCode
 }
 
+// Is reports whether target is an APIError with the same code, so that
+// errors.Is(err, ErrNotFound) matches any not-found error
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.ErrorCode == e.ErrorCode
+}
+
+// Retryable reports whether the request may succeed if retried later
+func (e *APIError) Retryable() bool {
+	switch e.ErrorCode {
+	case "RATE_LIMITED", "NETWORK_ERROR", "SERVER_ERROR":
+		return true
+	}
+	return e.StatusCode == http.StatusRequestTimeout || e.StatusCode >= 500
+}
+
 // ExitCode returns the appropriate exit code for this error
 func (e *APIError) ExitCode() int {
 	switch e.ErrorCode {
-	case "INVALID_API_KEY", "UNAUTHORIZED":
+	case "INVALID_API_KEY", "UNAUTHORIZED", "FORBIDDEN":
 		return ExitAuthError
 	case "RATE_LIMITED":
 		return ExitRateLimited
@@ -69,6 +113,106 @@ func NewAPIErrorWithDetails(code, message, details string) *APIError {
 	}
 }
 
+// AsAPIError returns the APIError in err's chain, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// parseErrorBody extracts a message and field-level details from an API
+// error response. It accepts {"error": "..."}, {"message": "..."} and
+// field lists such as {"errors": [{"field": "title", "message": "..."}]}
+// or {"errors": {"title": ["..."]}}.
+func parseErrorBody(body []byte) (string, []FieldError) {
+	var parsed struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return strings.TrimSpace(string(body)), nil
+	}
+
+	message := parsed.Message
+	var text string
+	if json.Unmarshal(parsed.Error, &text) == nil && text != "" {
+		message = text
+	}
+
+	fields := parseFieldErrors(parsed.Errors)
+	if fields == nil {
+		fields = parseFieldErrors(parsed.Details)
+	}
+	if message == "" && len(fields) == 0 {
+		message = strings.TrimSpace(string(body))
+	}
+	return message, fields
+}
+
+// parseFieldErrors parses a list or map of field errors
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var list []struct {
+		Field   string          `json:"field"`
+		Path    json.RawMessage `json:"path"`
+		Message string          `json:"message"`
+	}
+	if json.Unmarshal(raw, &list) == nil {
+		var fields []FieldError
+		for _, item := range list {
+			field := item.Field
+			if field == "" {
+				field = joinPath(item.Path)
+			}
+			if item.Message != "" {
+				fields = append(fields, FieldError{Field: field, Message: item.Message})
+			}
+		}
+		return fields
+	}
+
+	var byField map[string]json.RawMessage
+	if json.Unmarshal(raw, &byField) == nil {
+		var fields []FieldError
+		for field, v := range byField {
+			var messages []string
+			var message string
+			if json.Unmarshal(v, &messages) == nil {
+				for _, m := range messages {
+					fields = append(fields, FieldError{Field: field, Message: m})
+				}
+			} else if json.Unmarshal(v, &message) == nil {
+				fields = append(fields, FieldError{Field: field, Message: message})
+			}
+		}
+		sort.SliceStable(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+		return fields
+	}
+	return nil
+}
+
+// joinPath formats a path given as a string or a list of keys and indexes
+func joinPath(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var parts []interface{}
+	if json.Unmarshal(raw, &parts) == nil {
+		strs := make([]string, len(parts))
+		for i, p := range parts {
+			strs[i] = fmt.Sprint(p)
+		}
+		return strings.Join(strs, ".")
+	}
+	return ""
+}
+
 // Common API errors
 var (
 	ErrInvalidAPIKey = NewAPIErrorWithDetails(
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,6 +55,7 @@ func TestAPIError_ExitCode(t *testing.T) {
 	}{
 		{"INVALID_API_KEY", ExitAuthError},
 		{"UNAUTHORIZED", ExitAuthError},
+		{"FORBIDDEN", ExitAuthError},
 		{"RATE_LIMITED", ExitRateLimited},
 		{"NETWORK_ERROR", ExitNetworkError},
 		{"NOT_FOUND", ExitNotFound},
@@ -89,3 +92,72 @@ func TestPredefinedErrors(t *testing.T) {
 	assert.Equal(t, "RATE_LIMITED", ErrRateLimited.ErrorCode)
 	assert.Equal(t, "NETWORK_ERROR", ErrNetworkError.ErrorCode)
 }
+
+func TestAPIError_Is(t *testing.T) {
+	err := fmt.Errorf("failed to fetch workout: %w", &APIError{ErrorCode: "NOT_FOUND", StatusCode: 404})
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrRateLimited))
+
+	apiErr, ok := AsAPIError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 404, apiErr.StatusCode)
+}
+
+func TestAPIError_Retryable(t *testing.T) {
+	assert.True(t, (&APIError{ErrorCode: "RATE_LIMITED"}).Retryable())
+	assert.True(t, (&APIError{ErrorCode: "NETWORK_ERROR"}).Retryable())
+	assert.True(t, (&APIError{ErrorCode: "API_ERROR", StatusCode: 503}).Retryable())
+	assert.False(t, (&APIError{ErrorCode: "NOT_FOUND", StatusCode: 404}).Retryable())
+	assert.False(t, (&APIError{ErrorCode: "VALIDATION_ERROR", StatusCode: 400}).Retryable())
+}
+
+func TestAPIError_ErrorWithFields(t *testing.T) {
+	err := &APIError{
+		ErrorCode:    "VALIDATION_ERROR",
+		ErrorMessage: "Invalid workout",
+		Fields:       []FieldError{{Field: "title", Message: "is required"}, {Field: "end_time", Message: "must be after start_time"}},
+	}
+	assert.Equal(t, "VALIDATION_ERROR: Invalid workout (title: is required; end_time: must be after start_time)", err.Error())
+}
+
+func TestParseErrorBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		message string
+		fields  []FieldError
+	}{
+		{
+			name:    "error string",
+			body:    `{"error": "Invalid workout id"}`,
+			message: "Invalid workout id",
+		},
+		{
+			name:    "field list",
+			body:    `{"message": "Validation failed", "errors": [{"field": "title", "message": "is required"}, {"path": ["exercises", 0, "sets"], "message": "must not be empty"}]}`,
+			message: "Validation failed",
+			fields: []FieldError{
+				{Field: "title", Message: "is required"},
+				{Field: "exercises.0.sets", Message: "must not be empty"},
+			},
+		},
+		{
+			name:   "field map",
+			body:   `{"errors": {"title": ["is required", "is too short"], "start_time": "is invalid"}}`,
+			fields: []FieldError{{Field: "start_time", Message: "is invalid"}, {Field: "title", Message: "is required"}, {Field: "title", Message: "is too short"}},
+		},
+		{
+			name:    "plain text",
+			body:    "Bad Request\n",
+			message: "Bad Request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, fields := parseErrorBody([]byte(tt.body))
+			assert.Equal(t, tt.message, message)
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
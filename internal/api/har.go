@@ -0,0 +1,178 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// har is the subset of the HAR 1.2 format used for traces
+type har struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// toHAR converts trace entries to a HAR document
+func toHAR(entries []TraceEntry) har {
+	h := har{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "hevycli", Version: strings.TrimPrefix(UserAgent, "hevycli/")},
+		Entries: make([]harEntry, 0, len(entries)),
+	}}
+
+	for _, e := range entries {
+		entry := harEntry{
+			StartedDateTime: e.StartedAt,
+			Time:            e.DurationMs,
+			Timings:         harTimings{Wait: e.DurationMs},
+			Comment:         e.Error,
+			Request: harRequest{
+				Method:      e.Request.Method,
+				URL:         e.Request.URL,
+				HTTPVersion: "HTTP/1.1",
+				Headers:     harHeaders(e.Request.Headers),
+				QueryString: []harNameValue{},
+				HeadersSize: -1,
+				BodySize:    len(e.Request.Body),
+			},
+			Response: harResponse{
+				Status:      e.Response.Status,
+				StatusText:  http.StatusText(e.Response.Status),
+				HTTPVersion: "HTTP/1.1",
+				Headers:     harHeaders(e.Response.Headers),
+				Content: harContent{
+					Size:     len(e.Response.Body),
+					MimeType: e.Response.Headers["Content-Type"],
+					Text:     e.Response.Body,
+				},
+				HeadersSize: -1,
+				BodySize:    len(e.Response.Body),
+			},
+		}
+		if u, err := url.Parse(e.Request.URL); err == nil {
+			for name, values := range u.Query() {
+				for _, v := range values {
+					entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: v})
+				}
+			}
+			sort.Slice(entry.Request.QueryString, func(i, j int) bool {
+				return entry.Request.QueryString[i].Name < entry.Request.QueryString[j].Name
+			})
+		}
+		if e.Request.Body != "" {
+			entry.Request.PostData = &harPostData{MimeType: e.Request.Headers["Content-Type"], Text: e.Request.Body}
+		}
+		h.Log.Entries = append(h.Log.Entries, entry)
+	}
+	return h
+}
+
+// fromHAR converts a HAR document to trace entries
+func fromHAR(h har) []TraceEntry {
+	entries := make([]TraceEntry, 0, len(h.Log.Entries))
+	for _, e := range h.Log.Entries {
+		entry := TraceEntry{
+			StartedAt:  e.StartedDateTime,
+			DurationMs: e.Time,
+			Error:      e.Comment,
+			Request: TraceRequest{
+				Method:  e.Request.Method,
+				URL:     e.Request.URL,
+				Headers: headerMap(e.Request.Headers),
+			},
+			Response: TraceResponse{
+				Status:  e.Response.Status,
+				Headers: headerMap(e.Response.Headers),
+				Body:    e.Response.Content.Text,
+			},
+		}
+		if e.Request.PostData != nil {
+			entry.Request.Body = e.Request.PostData.Text
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func harHeaders(headers map[string]string) []harNameValue {
+	list := make([]harNameValue, 0, len(headers))
+	for name, value := range headers {
+		list = append(list, harNameValue{Name: name, Value: value})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func headerMap(list []harNameValue) map[string]string {
+	headers := make(map[string]string, len(list))
+	for _, h := range list {
+		headers[h.Name] = h.Value
+	}
+	return headers
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"strings"
+)
+
+// Summary describes the template's primary muscle group and equipment,
+// e.g. "Chest • Barbell"
+func (t ExerciseTemplate) Summary() string {
+	return t.PrimaryMuscleGroup.DisplayName() + " • " + t.Equipment.DisplayName()
+}
+
+// TemplateFilter selects exercise templates by muscle group, equipment and
+// exercise type. Empty fields match every template.
+type TemplateFilter struct {
+	Muscle    MuscleGroup
+	Equipment EquipmentCategory
+	Type      ExerciseType
+}
+
+// Match reports whether the template passes the filter. The muscle group
+// matches the primary or a secondary muscle group.
+func (f TemplateFilter) Match(t ExerciseTemplate) bool {
+	if f.Muscle != "" && t.PrimaryMuscleGroup != f.Muscle {
+		found := false
+		for _, m := range t.SecondaryMuscleGroups {
+			if m == f.Muscle {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Equipment != "" && t.Equipment != f.Equipment {
+		return false
+	}
+	return f.Type == "" || t.Type == f.Type
+}
+
+// ParseTemplateFilter reads "muscle:", "equipment:" and "type:" terms from a
+// search query, e.g. "press muscle:chest equipment:dumbbell". It returns the
+// filter and the rest of the query. Values with spaces are written with
+// underscores, e.g. "muscle:upper_back"; a term without a value, as while
+// it is being typed, is ignored.
+func ParseTemplateFilter(query string) (TemplateFilter, string, error) {
+	var f TemplateFilter
+	var rest []string
+	for _, word := range strings.Fields(query) {
+		key, value, ok := strings.Cut(word, ":")
+		key = strings.ToLower(key)
+		if !ok || key != "muscle" && key != "equipment" && key != "type" {
+			rest = append(rest, word)
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		var err error
+		switch key {
+		case "muscle":
+			f.Muscle, err = ParseMuscleGroup(value)
+		case "equipment":
+			f.Equipment, err = ParseEquipmentCategory(value)
+		case "type":
+			f.Type, err = ParseExerciseType(value)
+		}
+		if err != nil {
+			return TemplateFilter{}, "", err
+		}
+	}
+	return f, strings.Join(rest, " "), nil
+}
+
+// SecondaryMuscles lists the display names of the template's secondary
+// muscle groups, e.g. "Triceps, Shoulders"
+func (t ExerciseTemplate) SecondaryMuscles() string {
+	names := make([]string, len(t.SecondaryMuscleGroups))
+	for i, m := range t.SecondaryMuscleGroups {
+		names[i] = m.DisplayName()
+	}
+	return strings.Join(names, ", ")
+}
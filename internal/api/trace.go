@@ -0,0 +1,289 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces the values of secret headers in logs and traces
+const Redacted = "REDACTED"
+
+// secretHeaders are never written to traces
+var secretHeaders = map[string]bool{
+	"api-key":       true,
+	"authorization": true,
+}
+
+// TraceEntry is a recorded request and response
+type TraceEntry struct {
+	StartedAt  time.Time     `json:"started_at"`
+	DurationMs float64       `json:"duration_ms"`
+	Request    TraceRequest  `json:"request"`
+	Response   TraceResponse `json:"response"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// TraceRequest is the recorded part of a request
+type TraceRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// TraceResponse is the recorded part of a response
+type TraceResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// Recorder is a transport that records every request and response to a
+// trace file. Files ending in .har are written as HAR 1.2 when the
+// recorder is closed; other files get one JSON entry per line as requests
+// complete.
+type Recorder struct {
+	next    http.RoundTripper
+	file    *os.File
+	har     bool
+	mu      sync.Mutex
+	entries []TraceEntry
+}
+
+// NewRecorder creates a recorder writing to path. next is the transport
+// that sends requests; nil means http.DefaultTransport.
+func NewRecorder(path string, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace file: %w", err)
+	}
+	return &Recorder{
+		next: next,
+		file: f,
+		har:  strings.EqualFold(filepath.Ext(path), ".har"),
+	}, nil
+}
+
+// RoundTrip sends a request and records it
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := TraceEntry{StartedAt: time.Now().UTC()}
+
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	entry.Request = TraceRequest{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: redactHeaders(req.Header),
+		Body:    string(reqBody),
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	entry.DurationMs = float64(time.Since(entry.StartedAt).Microseconds()) / 1000
+	if err != nil {
+		entry.Error = err.Error()
+		r.record(entry)
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	entry.Response = TraceResponse{
+		Status:  resp.StatusCode,
+		Headers: redactHeaders(resp.Header),
+		Body:    string(respBody),
+	}
+
+	r.record(entry)
+	return resp, nil
+}
+
+func (r *Recorder) record(entry TraceEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.har {
+		r.entries = append(r.entries, entry)
+		return
+	}
+	if b, err := json.Marshal(entry); err == nil {
+		r.file.Write(append(b, '\n'))
+	}
+}
+
+// Close writes any buffered entries and closes the trace file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.har {
+		b, err := json.MarshalIndent(toHAR(r.entries), "", "  ")
+		if err != nil {
+			r.file.Close()
+			return err
+		}
+		if _, err := r.file.Write(append(b, '\n')); err != nil {
+			r.file.Close()
+			return err
+		}
+	}
+	return r.file.Close()
+}
+
+// redactHeaders flattens headers, hiding secret values
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		value := strings.Join(values, ", ")
+		if secretHeaders[strings.ToLower(name)] {
+			value = Redacted
+		}
+		out[name] = value
+	}
+	return out
+}
+
+// Replayer is a transport that answers requests from a recorded trace
+// instead of the network. Requests are matched by method, path and query;
+// repeated requests get the recorded responses in order, and the last one
+// again once they run out.
+type Replayer struct {
+	mu      sync.Mutex
+	entries []TraceEntry
+	used    []bool
+}
+
+// NewReplayer loads a trace recorded as HAR or NDJSON
+func NewReplayer(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace file: %w", err)
+	}
+	entries, err := ParseTrace(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trace file: %w", err)
+	}
+	return &Replayer{entries: entries, used: make([]bool, len(entries))}, nil
+}
+
+// ParseTrace parses a trace in HAR or NDJSON format
+func ParseTrace(data []byte) ([]TraceEntry, error) {
+	var h har
+	if err := json.Unmarshal(data, &h); err == nil && h.Log.Version != "" {
+		return fromHAR(h), nil
+	}
+
+	var entries []TraceEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry TraceEntry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// RoundTrip returns the recorded response for a request
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	key := requestKey(req.Method, req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, entry := range r.entries {
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil || requestKey(entry.Request.Method, u) != key {
+			continue
+		}
+		last = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("no recorded response for %s", key)
+	}
+	r.used[last] = true
+
+	entry := r.entries[last]
+	if entry.Error != "" {
+		return nil, fmt.Errorf("%s", entry.Error)
+	}
+
+	header := make(http.Header, len(entry.Response.Headers))
+	for name, value := range entry.Response.Headers {
+		header.Set(name, value)
+	}
+	if header.Get("Content-Type") == "" && entry.Response.Body != "" {
+		// Hand-written traces often omit headers; API responses are JSON
+		header.Set("Content-Type", "application/json")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Response.Status, http.StatusText(entry.Response.Status)),
+		StatusCode:    entry.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(entry.Response.Body)),
+		ContentLength: int64(len(entry.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// requestKey identifies a request by method, path and sorted query
+func requestKey(method string, u *url.URL) string {
+	query := u.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(method) + " " + u.Path)
+	for i, name := range names {
+		sep := "&"
+		if i == 0 {
+			sep = "?"
+		}
+		b.WriteString(sep + name + "=" + strings.Join(query[name], ","))
+	}
+	return b.String()
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCountServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"workout_count": 42}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRecorder_NDJSON(t *testing.T) {
+	server := newCountServer(t)
+	path := filepath.Join(t.TempDir(), "trace.ndjson")
+
+	recorder, err := NewRecorder(path, nil)
+	require.NoError(t, err)
+
+	client := NewClient("secret-key", WithBaseURL(server.URL), WithTransport(recorder))
+	count, err := client.GetWorkoutCount()
+	require.NoError(t, err)
+	assert.Equal(t, 42, count)
+	require.NoError(t, recorder.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-key")
+	assert.Equal(t, 1, strings.Count(string(data), "\n"))
+
+	entries, err := ParseTrace(data)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "GET", entries[0].Request.Method)
+	assert.Equal(t, server.URL+"/workouts/count", entries[0].Request.URL)
+	assert.Equal(t, Redacted, entries[0].Request.Headers["Api-Key"])
+	assert.Equal(t, 200, entries[0].Response.Status)
+	assert.Equal(t, `{"workout_count": 42}`, entries[0].Response.Body)
+}
+
+func TestRecorder_HAR(t *testing.T) {
+	server := newCountServer(t)
+	path := filepath.Join(t.TempDir(), "trace.har")
+
+	recorder, err := NewRecorder(path, nil)
+	require.NoError(t, err)
+
+	client := NewClient("secret-key", WithBaseURL(server.URL), WithTransport(recorder))
+	_, err = client.GetWorkouts(2, 5)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-key")
+	assert.Contains(t, string(data), `"version": "1.2"`)
+	assert.Contains(t, string(data), `"name": "pageSize"`)
+
+	entries, err := ParseTrace(data)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, Redacted, entries[0].Request.Headers["Api-Key"])
+	assert.Equal(t, 200, entries[0].Response.Status)
+}
+
+func TestReplayer(t *testing.T) {
+	trace := `{"request":{"method":"GET","url":"https://example.com/v1/workouts?pageSize=5&page=1"},"response":{"status":200,"body":"{\"page\":1,\"page_count\":2,\"workouts\":[]}"}}
+{"request":{"method":"GET","url":"https://example.com/v1/workouts?page=2&pageSize=5"},"response":{"status":200,"body":"{\"page\":2,\"page_count\":2,\"workouts\":[]}"}}
+{"request":{"method":"GET","url":"https://example.com/v1/workouts/missing"},"response":{"status":404}}
+`
+	path := filepath.Join(t.TempDir(), "trace.ndjson")
+	require.NoError(t, os.WriteFile(path, []byte(trace), 0644))
+
+	replayer, err := NewReplayer(path)
+	require.NoError(t, err)
+	client := NewClient("key", WithBaseURL("https://elsewhere.test/v1"), WithTransport(replayer))
+
+	resp, err := client.GetWorkouts(2, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Page)
+
+	resp, err = client.GetWorkouts(1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Page)
+
+	_, err = client.GetWorkout("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.GetWorkoutCount()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no recorded response for GET /v1/workouts/count")
+}
+
+func TestWithLogger(t *testing.T) {
+	server := newCountServer(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient("secret-key", WithBaseURL(server.URL), WithLogger(logger))
+
+	_, err := client.GetWorkouts(1, 10)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "method=GET")
+	assert.Contains(t, out, "path=/workouts")
+	assert.Contains(t, out, "query=\"page=1&pageSize=10\"")
+	assert.Contains(t, out, "status=200")
+	assert.NotContains(t, out, "retries")
+	assert.NotContains(t, out, "secret-key")
+}
+
+func TestSetDefaultOptions(t *testing.T) {
+	server := newCountServer(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	SetDefaultOptions(WithLogger(logger))
+	t.Cleanup(func() { SetDefaultOptions() })
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	_, err := client.GetWorkoutCount()
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "path=/workouts/count")
+}
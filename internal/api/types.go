@@ -6,6 +6,7 @@ import "time"
 type Workout struct {
 	ID          string     `json:"id"`
 	Title       string     `json:"title"`
+	RoutineID   string     `json:"routine_id,omitempty"`
 	Description string     `json:"description,omitempty"`
 	StartTime   time.Time  `json:"start_time"`
 	EndTime     time.Time  `json:"end_time"`
@@ -40,18 +41,20 @@ type Exercise struct {
 	Notes              string `json:"notes,omitempty"`
 	ExerciseTemplateID string `json:"exercise_template_id"`
 	SupersetID         *int   `json:"superset_id,omitempty"`
+	RestSeconds        *int   `json:"rest_seconds,omitempty"`
 	Sets               []Set  `json:"sets"`
 }
 
 // Set represents a single set of an exercise
 type Set struct {
-	Index           int      `json:"index"`
-	SetType         SetType  `json:"type"`
-	WeightKg        *float64 `json:"weight_kg,omitempty"`
-	Reps            *int     `json:"reps,omitempty"`
-	DistanceMeters  *float64 `json:"distance_meters,omitempty"`
-	DurationSeconds *int     `json:"duration_seconds,omitempty"`
-	RPE             *float64 `json:"rpe,omitempty"`
+	Index           int       `json:"index"`
+	SetType         SetType   `json:"type"`
+	WeightKg        *float64  `json:"weight_kg,omitempty"`
+	Reps            *int      `json:"reps,omitempty"`
+	DistanceMeters  *float64  `json:"distance_meters,omitempty"`
+	DurationSeconds *int      `json:"duration_seconds,omitempty"`
+	RPE             *float64  `json:"rpe,omitempty"`
+	RepRange        *RepRange `json:"rep_range,omitempty"`
 }
 
 // SetType represents the type of set
@@ -85,13 +88,13 @@ type RoutineFolder struct {
 
 // ExerciseTemplate represents an exercise definition from the Hevy database
 type ExerciseTemplate struct {
-	ID                    string   `json:"id"`
-	Title                 string   `json:"title"`
-	Type                  string   `json:"type,omitempty"`
-	PrimaryMuscleGroup    string   `json:"primary_muscle_group"`
-	SecondaryMuscleGroups []string `json:"secondary_muscle_groups,omitempty"`
-	Equipment             string   `json:"equipment,omitempty"`
-	IsCustom              bool     `json:"is_custom"`
+	ID                    string            `json:"id"`
+	Title                 string            `json:"title"`
+	Type                  ExerciseType      `json:"type,omitempty"`
+	PrimaryMuscleGroup    MuscleGroup       `json:"primary_muscle_group"`
+	SecondaryMuscleGroups []MuscleGroup     `json:"secondary_muscle_groups,omitempty"`
+	Equipment             EquipmentCategory `json:"equipment,omitempty"`
+	IsCustom              bool              `json:"is_custom"`
 }
 
 // WorkoutEvent represents a change event for workout sync
@@ -249,6 +252,7 @@ type UpdateRoutineRequest struct {
 // UpdateRoutineData represents the routine data for update
 type UpdateRoutineData struct {
 	Title     string                  `json:"title"`
+	FolderID  *int                    `json:"folder_id,omitempty"`
 	Notes     *string                 `json:"notes,omitempty"`
 	Exercises []CreateRoutineExercise `json:"exercises"`
 }
@@ -271,6 +275,7 @@ type UpdateRoutineFolderRequest struct {
 // UpdateRoutineFolderData represents the folder data for update
 type UpdateRoutineFolderData struct {
 	Title string `json:"title"`
+	Index *int   `json:"index,omitempty"`
 }
 
 // WorkoutResponse represents the response from POST/PUT /workouts
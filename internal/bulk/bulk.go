@@ -0,0 +1,276 @@
+// Package bulk runs an operation over many selected objects: it previews
+// the selection, asks for a single confirmation, runs the operation with
+// bounded concurrency and reports the outcome for every object.
+package bulk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/output"
+)
+
+// DefaultConcurrency is the number of requests run at once by default
+const DefaultConcurrency = 4
+
+// Status is the outcome of the operation for one item
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Item is an object selected for a bulk operation
+type Item struct {
+	ID    string
+	Title string
+	// Detail is shown in the preview, e.g. a date or exercise count
+	Detail string
+}
+
+// Result is the outcome for one item
+type Result struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Code   string `json:"code,omitempty"`
+	// NewID is the ID of the object that replaced the item, if the action
+	// gave it a new ID
+	NewID string `json:"new_id,omitempty"`
+}
+
+// Report is the outcome of a bulk operation
+type Report struct {
+	Action    string   `json:"action"`
+	DryRun    bool     `json:"dry_run"`
+	Total     int      `json:"total"`
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Results   []Result `json:"results"`
+	// Operation is the undo journal entry of the changes, if any were made
+	Operation string `json:"operation,omitempty"`
+}
+
+// Err returns an error if any item failed
+func (r *Report) Err() error {
+	if r.Failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d items failed to %s", r.Failed, r.Total, r.Action)
+}
+
+// Run calls fn for every item with at most concurrency calls at once. fn
+// returns the ID of the object replacing the item, if any. Results are in
+// the order of items.
+func Run(action string, items []Item, concurrency int, fn func(Item) (string, error)) *Report {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	report := &Report{Action: action, Total: len(items), Results: make([]Result, len(items))}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, item Item) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := Result{ID: item.ID, Title: item.Title, Status: StatusOK}
+			newID, err := fn(item)
+			result.NewID = newID
+			if err != nil {
+				result.Status = StatusFailed
+				result.Error = err.Error()
+				if apiErr, ok := api.AsAPIError(err); ok {
+					result.Code = apiErr.ErrorCode
+				}
+			}
+			report.Results[i] = result
+		}(i, item)
+	}
+	wg.Wait()
+
+	for _, r := range report.Results {
+		if r.Status == StatusOK {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	return report
+}
+
+// Skipped returns the report of a dry run
+func Skipped(action string, items []Item) *Report {
+	report := &Report{Action: action, DryRun: true, Total: len(items), Results: make([]Result, len(items))}
+	for i, item := range items {
+		report.Results[i] = Result{ID: item.ID, Title: item.Title, Status: StatusSkipped}
+	}
+	return report
+}
+
+// MatchTitle reports whether title matches a glob pattern where * matches
+// any text and ? any single character, ignoring case
+func MatchTitle(pattern, title string) bool {
+	var b strings.Builder
+	b.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String()).MatchString(title)
+}
+
+// Options configures Execute
+type Options struct {
+	// Action is the verb shown to the user, e.g. "delete"
+	Action string
+	// Done is the past tense of Action, e.g. "deleted"
+	Done string
+	// Noun is the plural name of the items, e.g. "workouts"
+	Noun string
+	// DetailHeader is the preview column header for Item.Detail
+	DetailHeader string
+
+	Items       []Item
+	Concurrency int
+	DryRun      bool
+	Force       bool
+
+	OutputFormat string
+	Formatter    output.Formatter
+	NoColor      bool
+
+	// Operation is the undo journal entry that Do records changes in
+	Operation string
+
+	// Do performs the action on one item and returns the ID of the object
+	// replacing it, if the action gave it a new ID
+	Do func(Item) (string, error)
+}
+
+// Execute previews the items, asks for confirmation unless Force or DryRun
+// is set, runs the action and prints the report. Structured output formats
+// get the report; other formats get a summary. It returns an error if any
+// item failed.
+func Execute(o Options) error {
+	structured := output.IsStructured(o.OutputFormat)
+
+	if len(o.Items) == 0 {
+		if structured {
+			return printReport(o, &Report{Action: o.Action, DryRun: o.DryRun, Results: []Result{}})
+		}
+		fmt.Printf("No %s match.\n", o.Noun)
+		return nil
+	}
+
+	// The preview goes to stdout for people and stderr alongside the
+	// confirmation prompt when the report is structured
+	var preview io.Writer = os.Stdout
+	if structured {
+		preview = os.Stderr
+	}
+	if !structured || !(o.Force || o.DryRun) {
+		if err := printPreview(preview, o); err != nil {
+			return err
+		}
+	}
+
+	if o.DryRun {
+		if structured {
+			return printReport(o, Skipped(o.Action, o.Items))
+		}
+		fmt.Printf("Dry run: %d %s would be %s.\n", len(o.Items), o.Noun, o.Done)
+		return nil
+	}
+
+	if !o.Force {
+		if !cmdutil.IsInteractive() {
+			return cmdutil.UsageErrorf("refusing to %s %d %s without confirmation: use --force", o.Action, len(o.Items), o.Noun)
+		}
+		fmt.Fprintf(os.Stderr, "This will %s %d %s.\n", o.Action, len(o.Items), o.Noun)
+		fmt.Fprint(os.Stderr, "Type 'yes' to confirm: ")
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	report := Run(o.Action, o.Items, o.Concurrency, o.Do)
+	if report.Succeeded > 0 {
+		report.Operation = o.Operation
+	}
+	if structured {
+		if err := printReport(o, report); err != nil {
+			return err
+		}
+		return report.Err()
+	}
+
+	for _, r := range report.Results {
+		switch {
+		case r.Status == StatusFailed:
+			fmt.Printf("✗ %s (%s): %s\n", r.Title, r.ID, r.Error)
+		case r.NewID != "":
+			fmt.Printf("↻ %s (%s) is now %s\n", r.Title, r.ID, r.NewID)
+		}
+	}
+	fmt.Printf("%s %d of %d %s.\n", capitalize(o.Done), report.Succeeded, report.Total, o.Noun)
+	if report.Operation != "" {
+		fmt.Printf("Undo with 'hevycli undo %s'.\n", report.Operation)
+	}
+	return report.Err()
+}
+
+func printPreview(w io.Writer, o Options) error {
+	table := output.NewSimpleTable([]string{"ID", "Title", o.DetailHeader})
+	for _, item := range o.Items {
+		table.AddRow(item.ID, item.Title, item.Detail)
+	}
+	out, err := output.NewTableFormatter(output.Options{NoColor: o.NoColor}).Format(table)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, out)
+	fmt.Fprintf(w, "%d %s selected\n", len(o.Items), o.Noun)
+	return nil
+}
+
+func printReport(o Options, report *Report) error {
+	out, err := o.Formatter.Format(report)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,99 @@
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func items(n int) []Item {
+	out := make([]Item, n)
+	for i := range out {
+		out[i] = Item{ID: fmt.Sprintf("id%d", i), Title: fmt.Sprintf("Item %d", i)}
+	}
+	return out
+}
+
+func TestRun_OrderAndConcurrency(t *testing.T) {
+	var running, peak int32
+	report := Run("delete", items(20), 3, func(item Item) (string, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return "", nil
+	})
+
+	assert.LessOrEqual(t, int(peak), 3)
+	assert.Equal(t, 20, report.Total)
+	assert.Equal(t, 20, report.Succeeded)
+	require.Len(t, report.Results, 20)
+	for i, r := range report.Results {
+		assert.Equal(t, fmt.Sprintf("id%d", i), r.ID)
+		assert.Equal(t, StatusOK, r.Status)
+	}
+	assert.NoError(t, report.Err())
+}
+
+func TestRun_Failures(t *testing.T) {
+	report := Run("move", items(3), 2, func(item Item) (string, error) {
+		switch item.ID {
+		case "id0":
+			return "copy0", nil
+		case "id1":
+			return "", &api.APIError{StatusCode: 404, ErrorCode: "NOT_FOUND", ErrorMessage: "gone"}
+		}
+		return "", errors.New("boom")
+	})
+
+	assert.Equal(t, 1, report.Succeeded)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, "copy0", report.Results[0].NewID)
+	assert.Equal(t, StatusFailed, report.Results[1].Status)
+	assert.Equal(t, "NOT_FOUND", report.Results[1].Code)
+	assert.Equal(t, "boom", report.Results[2].Error)
+	assert.Empty(t, report.Results[2].Code)
+	assert.EqualError(t, report.Err(), "2 of 3 items failed to move")
+}
+
+func TestSkipped(t *testing.T) {
+	report := Skipped("delete", items(2))
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, report.Total)
+	assert.Zero(t, report.Succeeded)
+	for _, r := range report.Results {
+		assert.Equal(t, StatusSkipped, r.Status)
+	}
+	assert.NoError(t, report.Err())
+}
+
+func TestMatchTitle(t *testing.T) {
+	tests := []struct {
+		pattern, title string
+		want           bool
+	}{
+		{"PPL*", "PPL Push", true},
+		{"ppl*", "PPL Push", true},
+		{"PPL*", "My PPL", false},
+		{"*test*", "Morning Test Run", true},
+		{"Day ?", "Day 1", true},
+		{"Day ?", "Day 10", false},
+		{"Push (A)", "Push (A)", true},
+		{"Push", "Push Day", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchTitle(tt.pattern, tt.title), "%q ~ %q", tt.pattern, tt.title)
+	}
+}
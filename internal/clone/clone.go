@@ -0,0 +1,173 @@
+// Package clone copies routines, optionally changing weights, reps and
+// exercises on the way, e.g. to build the next training block from the
+// current one.
+package clone
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+	"github.com/obay/hevycli/internal/edit"
+	"github.com/obay/hevycli/internal/journal"
+)
+
+// Transform describes the changes made to a routine while copying it
+type Transform struct {
+	// WeightPercent changes every weight by this percentage, e.g. 5 or -10
+	WeightPercent float64
+	// WeightRound rounds changed weights to a multiple of this many kg
+	WeightRound float64
+	// RepDelta is added to reps and rep ranges; reps never drop below 1
+	RepDelta int
+	// Swaps replaces exercise template IDs
+	Swaps map[string]string
+}
+
+// Apply changes the exercises of a routine request in place
+func (t Transform) Apply(data *api.CreateRoutineData) {
+	for i := range data.Exercises {
+		ex := &data.Exercises[i]
+		if to, ok := t.Swaps[ex.ExerciseTemplateID]; ok {
+			ex.ExerciseTemplateID = to
+		}
+		for j := range ex.Sets {
+			set := &ex.Sets[j]
+			if set.WeightKg != nil && t.WeightPercent != 0 {
+				w := t.weight(*set.WeightKg)
+				set.WeightKg = &w
+			}
+			set.Reps = t.reps(set.Reps)
+			if set.RepRange != nil {
+				set.RepRange = &api.RepRange{
+					Start: t.reps(set.RepRange.Start),
+					End:   t.reps(set.RepRange.End),
+				}
+			}
+		}
+	}
+}
+
+func (t Transform) weight(kg float64) float64 {
+	kg *= 1 + t.WeightPercent/100
+	if t.WeightRound > 0 {
+		return math.Round(kg/t.WeightRound) * t.WeightRound
+	}
+	// Drop floating point noise such as 105.00000000000001
+	return math.Round(kg*100) / 100
+}
+
+func (t Transform) reps(reps *int) *int {
+	if reps == nil || t.RepDelta == 0 {
+		return reps
+	}
+	n := *reps + t.RepDelta
+	if n < 1 {
+		n = 1
+	}
+	return &n
+}
+
+// Request builds the create request for a copy of r with the given title.
+// The copy goes into folderID, or stays in the routine's folder if it is nil.
+func Request(r *api.Routine, title string, folderID *int, t Transform) (*api.CreateRoutineRequest, error) {
+	if folderID == nil && r.FolderID != nil {
+		id, err := strconv.Atoi(*r.FolderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid folder ID %q", *r.FolderID)
+		}
+		folderID = &id
+	}
+
+	update := edit.FromRoutine(r).Request()
+	req := &api.CreateRoutineRequest{Routine: api.CreateRoutineData{
+		Title:     title,
+		FolderID:  folderID,
+		Notes:     update.Routine.Notes,
+		Exercises: update.Routine.Exercises,
+	}}
+	t.Apply(&req.Routine)
+	return req, nil
+}
+
+// Routine creates a copy of r and records it in the undo journal
+func Routine(client *api.Client, rec *journal.Recorder, r *api.Routine, title string, folderID *int, t Transform) (*api.Routine, error) {
+	req, err := Request(r, title, folderID, t)
+	if err != nil {
+		return nil, err
+	}
+	created, err := client.CreateRoutine(req)
+	if err != nil {
+		return nil, err
+	}
+	change, err := journal.Snapshot(journal.ActionCreate, created)
+	if err != nil {
+		return created, err
+	}
+	if err := rec.Record(change); err != nil {
+		return created, fmt.Errorf("failed to record undo journal: %w", err)
+	}
+	return created, nil
+}
+
+// ParseSwaps parses OLD=NEW exercise template ID pairs
+func ParseSwaps(specs []string) (map[string]string, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	swaps := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		from, to, ok := strings.Cut(spec, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid swap %q (expected OLD=NEW exercise template IDs)", spec)
+		}
+		if _, dup := swaps[from]; dup {
+			return nil, fmt.Errorf("exercise %s is swapped more than once", from)
+		}
+		swaps[from] = to
+	}
+	return swaps, nil
+}
+
+// Flags are the command-line flags that build a Transform
+type Flags struct {
+	WeightPercent float64
+	WeightRound   float64
+	RepDelta      int
+	Swaps         []string
+}
+
+// Register adds the transform flags to a flag set
+func (f *Flags) Register(fs *pflag.FlagSet) {
+	fs.Float64Var(&f.WeightPercent, "weight-percent", 0, "Change weights by a percentage, e.g. 5 or -10")
+	fs.Float64Var(&f.WeightRound, "round", 0, "Round changed weights to a multiple of this many kg, e.g. 2.5")
+	fs.IntVar(&f.RepDelta, "reps", 0, "Add to (or, if negative, subtract from) every rep target")
+	fs.StringArrayVar(&f.Swaps, "swap", nil, "Replace an exercise: OLD=NEW template IDs (repeatable)")
+}
+
+// Transform validates the flags and returns the transform they describe.
+// Invalid values are usage errors.
+func (f *Flags) Transform() (Transform, error) {
+	if f.WeightPercent <= -100 {
+		return Transform{}, cmdutil.UsageErrorf("--weight-percent must be greater than -100")
+	}
+	if f.WeightRound < 0 {
+		return Transform{}, cmdutil.UsageErrorf("--round cannot be negative")
+	}
+	swaps, err := ParseSwaps(f.Swaps)
+	if err != nil {
+		return Transform{}, cmdutil.UsageErrorf("%v", err)
+	}
+	return Transform{
+		WeightPercent: f.WeightPercent,
+		WeightRound:   f.WeightRound,
+		RepDelta:      f.RepDelta,
+		Swaps:         swaps,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package clone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+)
+
+func intPtr(n int) *int           { return &n }
+func floatPtr(f float64) *float64 { return &f }
+func strPtr(s string) *string     { return &s }
+
+func testRoutine() *api.Routine {
+	return &api.Routine{
+		ID:       "r1",
+		Title:    "Push A",
+		FolderID: strPtr("7"),
+		Exercises: []api.Exercise{
+			{
+				ExerciseTemplateID: "BENCH",
+				SupersetID:         intPtr(0),
+				RestSeconds:        intPtr(90),
+				Notes:              "Pause on chest",
+				Sets: []api.Set{
+					{SetType: api.SetTypeWarmup, WeightKg: floatPtr(60), Reps: intPtr(10)},
+					{SetType: api.SetTypeNormal, WeightKg: floatPtr(100), RepRange: &api.RepRange{Start: intPtr(6), End: intPtr(8)}},
+				},
+			},
+			{
+				ExerciseTemplateID: "PLANK",
+				SupersetID:         intPtr(0),
+				Sets:               []api.Set{{SetType: api.SetTypeNormal, DurationSeconds: intPtr(60)}},
+			},
+		},
+	}
+}
+
+func TestRequest_CopiesRoutine(t *testing.T) {
+	req, err := Request(testRoutine(), "Push B", nil, Transform{})
+	require.NoError(t, err)
+
+	data := req.Routine
+	assert.Equal(t, "Push B", data.Title)
+	assert.Equal(t, 7, *data.FolderID)
+	require.Len(t, data.Exercises, 2)
+	bench := data.Exercises[0]
+	assert.Equal(t, "BENCH", bench.ExerciseTemplateID)
+	assert.Equal(t, 0, *bench.SupersetID)
+	assert.Equal(t, 90, *bench.RestSeconds)
+	assert.Equal(t, "Pause on chest", *bench.Notes)
+	assert.Equal(t, api.SetTypeWarmup, bench.Sets[0].Type)
+	assert.Equal(t, 100.0, *bench.Sets[1].WeightKg)
+	assert.Equal(t, 8, *bench.Sets[1].RepRange.End)
+	assert.Equal(t, 60, *data.Exercises[1].Sets[0].DurationSeconds)
+
+	req, err = Request(testRoutine(), "Push B", intPtr(9), Transform{})
+	require.NoError(t, err)
+	assert.Equal(t, 9, *req.Routine.FolderID)
+}
+
+func TestRequest_Transforms(t *testing.T) {
+	r := testRoutine()
+	req, err := Request(r, "Push B", nil, Transform{
+		WeightPercent: 5,
+		WeightRound:   2.5,
+		RepDelta:      -7,
+		Swaps:         map[string]string{"BENCH": "INCLINE"},
+	})
+	require.NoError(t, err)
+
+	bench := req.Routine.Exercises[0]
+	assert.Equal(t, "INCLINE", bench.ExerciseTemplateID)
+	assert.Equal(t, 62.5, *bench.Sets[0].WeightKg)
+	assert.Equal(t, 3, *bench.Sets[0].Reps)
+	assert.Equal(t, 105.0, *bench.Sets[1].WeightKg)
+	assert.Equal(t, 1, *bench.Sets[1].RepRange.Start)
+	assert.Equal(t, 1, *bench.Sets[1].RepRange.End)
+	assert.Nil(t, bench.Sets[1].Reps)
+	assert.Equal(t, "PLANK", req.Routine.Exercises[1].ExerciseTemplateID)
+
+	// The source routine is left alone
+	assert.Equal(t, 60.0, *r.Exercises[0].Sets[0].WeightKg)
+	assert.Equal(t, 10, *r.Exercises[0].Sets[0].Reps)
+	assert.Equal(t, 6, *r.Exercises[0].Sets[1].RepRange.Start)
+}
+
+func TestTransform_WeightWithoutRounding(t *testing.T) {
+	assert.Equal(t, 65.63, Transform{WeightPercent: 5}.weight(62.5))
+	assert.Equal(t, 90.0, Transform{WeightPercent: -10}.weight(100))
+}
+
+func TestParseSwaps(t *testing.T) {
+	swaps, err := ParseSwaps([]string{"A=B", " C = D "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "B", "C": "D"}, swaps)
+
+	_, err = ParseSwaps([]string{"A"})
+	assert.Error(t, err)
+	_, err = ParseSwaps([]string{"A=B", "A=C"})
+	assert.EqualError(t, err, "exercise A is swapped more than once")
+}
+
+func TestFlags_Transform(t *testing.T) {
+	f := Flags{WeightPercent: 2.5, RepDelta: 1, Swaps: []string{"A=B"}}
+	transform, err := f.Transform()
+	require.NoError(t, err)
+	assert.Equal(t, Transform{WeightPercent: 2.5, RepDelta: 1, Swaps: map[string]string{"A": "B"}}, transform)
+
+	for _, f := range []Flags{{WeightPercent: -100}, {WeightRound: -1}, {Swaps: []string{"=B"}}} {
+		_, err := f.Transform()
+		var usage *cmdutil.UsageError
+		assert.ErrorAs(t, err, &usage)
+	}
+}
@@ -0,0 +1,32 @@
+package cmdutil
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// CompleteExerciseTypes completes a flag with the exercise types
+func CompleteExerciseTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return enumCompletions(api.ExerciseTypes, api.ExerciseType.DisplayName), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteMuscleGroups completes a flag with the muscle groups
+func CompleteMuscleGroups(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return enumCompletions(api.MuscleGroups, api.MuscleGroup.DisplayName), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteEquipment completes a flag with the equipment categories
+func CompleteEquipment(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return enumCompletions(api.EquipmentCategories, api.EquipmentCategory.DisplayName), cobra.ShellCompDirectiveNoFileComp
+}
+
+// enumCompletions returns each value with its display name as the
+// description shown by shells that support it
+func enumCompletions[T ~string](values []T, display func(T) string) []string {
+	completions := make([]string, len(values))
+	for i, v := range values {
+		completions[i] = string(v) + "\t" + display(v)
+	}
+	return completions
+}
@@ -0,0 +1,306 @@
+package cmdutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RangeSeparator separates the two ends of an explicit date range
+const RangeSeparator = ".."
+
+// DateRange is a span of time. End is exclusive.
+// A zero Start or End means the range is open on that side.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether t falls within the range
+func (r DateRange) Contains(t time.Time) bool {
+	if !r.Start.IsZero() && t.Before(r.Start) {
+		return false
+	}
+	if !r.End.IsZero() && !t.Before(r.End) {
+		return false
+	}
+	return true
+}
+
+// DateParser parses natural-language and relative dates used by date flags
+type DateParser struct {
+	// Now is the reference time for relative dates
+	Now time.Time
+	// Location is the timezone dates are interpreted in
+	Location *time.Location
+	// WeekStart is the first day of the week for "this week" and "last week"
+	WeekStart time.Weekday
+}
+
+// NewDateParser returns a parser for the given timezone name and week start.
+// An empty timezone uses the local timezone; an empty week start means Monday.
+func NewDateParser(timezone, weekStart string) (*DateParser, error) {
+	loc := time.Local
+	if timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone: %s", timezone)
+		}
+	}
+
+	start := time.Monday
+	if weekStart != "" {
+		var err error
+		start, err = ParseWeekday(weekStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid week start: %w", err)
+		}
+	}
+
+	return &DateParser{
+		Now:       time.Now().In(loc),
+		Location:  loc,
+		WeekStart: start,
+	}, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+// ParseWeekday parses a weekday name such as "monday" or "sun"
+func ParseWeekday(s string) (time.Weekday, error) {
+	if d, ok := weekdays[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return d, nil
+	}
+	return 0, fmt.Errorf("unknown weekday: %q", s)
+}
+
+var (
+	relativePattern = regexp.MustCompile(`^-?(\d+)\s*(d|days?|w|weeks?|m|months?|y|years?)(\s+ago)?$`)
+	isoWeekPattern  = regexp.MustCompile(`^(\d{4})-?w(\d{1,2})$`)
+	monthPattern    = regexp.MustCompile(`^([a-z]+)(?:\s+(\d{4}))?$`)
+	periodPattern   = regexp.MustCompile(`^(this|last|next)\s+(week|month|year)$`)
+)
+
+// Parse parses a date expression into the range of time it covers:
+//
+//	today, yesterday, tomorrow      a single day
+//	monday .. sunday                the most recent such day, today included
+//	this/last/next week|month|year  a calendar period
+//	3d, 3w, 3m, 1y, -90d, 2 weeks ago
+//	                                a single day that long ago
+//	2026-03-14, 2026-03, 2026       a day, month or year
+//	2026-W14                        an ISO week
+//	march, mar 2026                 a month, the most recent one without a year
+//	2026-01..2026-03                an explicit range; either side may be omitted
+//
+// Full RFC 3339 timestamps are also accepted and give an empty range at that instant.
+func (p *DateParser) Parse(s string) (DateRange, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return DateRange{}, fmt.Errorf("empty date")
+	}
+
+	if from, to, ok := strings.Cut(s, RangeSeparator); ok {
+		var r DateRange
+		if strings.TrimSpace(from) != "" {
+			start, err := p.parseSingle(strings.TrimSpace(from))
+			if err != nil {
+				return DateRange{}, err
+			}
+			r.Start = start.Start
+		}
+		if strings.TrimSpace(to) != "" {
+			end, err := p.parseSingle(strings.TrimSpace(to))
+			if err != nil {
+				return DateRange{}, err
+			}
+			r.End = end.End
+		}
+		if !r.Start.IsZero() && !r.End.IsZero() && !r.End.After(r.Start) {
+			return DateRange{}, fmt.Errorf("invalid date range: %q ends before it starts", s)
+		}
+		return r, nil
+	}
+
+	return p.parseSingle(s)
+}
+
+func (p *DateParser) parseSingle(s string) (DateRange, error) {
+	today := p.day(p.Now)
+
+	switch s {
+	case "today", "now":
+		return p.days(today, 1), nil
+	case "yesterday":
+		return p.days(today.AddDate(0, 0, -1), 1), nil
+	case "tomorrow":
+		return p.days(today.AddDate(0, 0, 1), 1), nil
+	}
+
+	if d, ok := weekdays[s]; ok {
+		back := (int(today.Weekday()) - int(d) + 7) % 7
+		return p.days(today.AddDate(0, 0, -back), 1), nil
+	}
+
+	if m := periodPattern.FindStringSubmatch(s); m != nil {
+		offset := map[string]int{"this": 0, "last": -1, "next": 1}[m[1]]
+		switch m[2] {
+		case "week":
+			return p.days(p.weekStart(today).AddDate(0, 0, 7*offset), 7), nil
+		case "month":
+			start := time.Date(today.Year(), today.Month()+time.Month(offset), 1, 0, 0, 0, 0, p.Location)
+			return DateRange{Start: start, End: start.AddDate(0, 1, 0)}, nil
+		default:
+			start := time.Date(today.Year()+offset, time.January, 1, 0, 0, 0, 0, p.Location)
+			return DateRange{Start: start, End: start.AddDate(1, 0, 0)}, nil
+		}
+	}
+
+	if m := relativePattern.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return DateRange{}, fmt.Errorf("invalid date: %q", s)
+		}
+		var day time.Time
+		switch m[2][0] {
+		case 'd':
+			day = today.AddDate(0, 0, -n)
+		case 'w':
+			day = today.AddDate(0, 0, -7*n)
+		case 'm':
+			day = today.AddDate(0, -n, 0)
+		default:
+			day = today.AddDate(-n, 0, 0)
+		}
+		return p.days(day, 1), nil
+	}
+
+	if m := isoWeekPattern.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		if week < 1 || week > 53 {
+			return DateRange{}, fmt.Errorf("invalid ISO week: %q", s)
+		}
+		// ISO week 1 contains January 4th; weeks start on Monday
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, p.Location)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+7*(week-1))
+		if y, _ := monday.ISOWeek(); week == 53 && y != year {
+			return DateRange{}, fmt.Errorf("invalid ISO week: %q (%d has 52 weeks)", s, year)
+		}
+		return p.days(monday, 7), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, p.Location); err == nil {
+		return p.days(t, 1), nil
+	}
+	if t, err := time.ParseInLocation("2006-01", s, p.Location); err == nil {
+		return DateRange{Start: t, End: t.AddDate(0, 1, 0)}, nil
+	}
+	if t, err := time.ParseInLocation("2006", s, p.Location); err == nil {
+		return DateRange{Start: t, End: t.AddDate(1, 0, 0)}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return DateRange{Start: t, End: t}, nil
+	}
+
+	if m := monthPattern.FindStringSubmatch(s); m != nil {
+		if month, ok := months[m[1]]; ok {
+			year := today.Year()
+			if m[2] != "" {
+				year, _ = strconv.Atoi(m[2])
+			} else if month > today.Month() {
+				year--
+			}
+			start := time.Date(year, month, 1, 0, 0, 0, 0, p.Location)
+			return DateRange{Start: start, End: start.AddDate(0, 1, 0)}, nil
+		}
+	}
+
+	return DateRange{}, fmt.Errorf("invalid date: %q (try YYYY-MM-DD, today, monday, last week, 3w, 2026-W14, march or 2026-01..2026-03)", s)
+}
+
+// SinceUntil parses --since and --until flag values into a range.
+// --since uses the start of its expression and --until the end, so
+// "--until 2026-03" includes all of March. An explicit range such as
+// "2026-01..2026-03" given to --since sets both ends.
+func (p *DateParser) SinceUntil(since, until string) (DateRange, error) {
+	var r DateRange
+	if since != "" {
+		parsed, err := p.Parse(since)
+		if err != nil {
+			return DateRange{}, UsageErrorf("invalid --since: %w", err)
+		}
+		r.Start = parsed.Start
+		if strings.Contains(since, RangeSeparator) {
+			r.End = parsed.End
+		}
+	}
+	if until != "" {
+		parsed, err := p.Parse(until)
+		if err != nil {
+			return DateRange{}, UsageErrorf("invalid --until: %w", err)
+		}
+		r.End = parsed.End
+	}
+	if !r.Start.IsZero() && !r.End.IsZero() && !r.End.After(r.Start) {
+		return DateRange{}, UsageErrorf("--until must be after --since")
+	}
+	return r, nil
+}
+
+// Period parses a --period value. A relative expression such as "90d" or
+// "3w" covers the time since that day, with no end; anything else covers
+// the range Parse gives it.
+func (p *DateParser) Period(s string) (DateRange, error) {
+	r, err := p.Parse(s)
+	if err != nil {
+		return DateRange{}, err
+	}
+	if relativePattern.MatchString(strings.ToLower(strings.TrimSpace(s))) {
+		r.End = time.Time{}
+	}
+	return r, nil
+}
+
+// day truncates t to midnight in the parser's timezone
+func (p *DateParser) day(t time.Time) time.Time {
+	t = t.In(p.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, p.Location)
+}
+
+// days returns the range of n days starting at day
+func (p *DateParser) days(day time.Time, n int) DateRange {
+	return DateRange{Start: day, End: day.AddDate(0, 0, n)}
+}
+
+// weekStart returns the first day of the week containing day
+func (p *DateParser) weekStart(day time.Time) time.Time {
+	back := (int(day.Weekday()) - int(p.WeekStart) + 7) % 7
+	return day.AddDate(0, 0, -back)
+}
@@ -0,0 +1,164 @@
+package cmdutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testParser returns a parser fixed at Wednesday 2026-04-15 10:30 in Berlin
+func testParser(t *testing.T, weekStart time.Weekday) *DateParser {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	return &DateParser{
+		Now:       time.Date(2026, time.April, 15, 10, 30, 0, 0, loc),
+		Location:  loc,
+		WeekStart: weekStart,
+	}
+}
+
+func TestDateParser_Parse(t *testing.T) {
+	p := testParser(t, time.Monday)
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, p.Location)
+	}
+
+	tests := []struct {
+		input string
+		start time.Time
+		end   time.Time
+	}{
+		{"today", day(2026, 4, 15), day(2026, 4, 16)},
+		{"Yesterday", day(2026, 4, 14), day(2026, 4, 15)},
+		{"monday", day(2026, 4, 13), day(2026, 4, 14)},
+		{"wed", day(2026, 4, 15), day(2026, 4, 16)},
+		{"thursday", day(2026, 4, 9), day(2026, 4, 10)},
+		{"this week", day(2026, 4, 13), day(2026, 4, 20)},
+		{"last week", day(2026, 4, 6), day(2026, 4, 13)},
+		{"last month", day(2026, 3, 1), day(2026, 4, 1)},
+		{"this year", day(2026, 1, 1), day(2027, 1, 1)},
+		{"3w", day(2026, 3, 25), day(2026, 3, 26)},
+		{"-90d", day(2026, 1, 15), day(2026, 1, 16)},
+		{"2 months ago", day(2026, 2, 15), day(2026, 2, 16)},
+		{"1y", day(2025, 4, 15), day(2025, 4, 16)},
+		{"2026-W14", day(2026, 3, 30), day(2026, 4, 6)},
+		{"2021-w01", day(2021, 1, 4), day(2021, 1, 11)},
+		{"2026-03-14", day(2026, 3, 14), day(2026, 3, 15)},
+		{"2026-03", day(2026, 3, 1), day(2026, 4, 1)},
+		{"2025", day(2025, 1, 1), day(2026, 1, 1)},
+		{"march", day(2026, 3, 1), day(2026, 4, 1)},
+		{"december", day(2025, 12, 1), day(2026, 1, 1)},
+		{"sep 2024", day(2024, 9, 1), day(2024, 10, 1)},
+		{"2026-01..2026-03", day(2026, 1, 1), day(2026, 4, 1)},
+		{"last week..today", day(2026, 4, 6), day(2026, 4, 16)},
+		{"2026-02..", day(2026, 2, 1), time.Time{}},
+		{"..2025", time.Time{}, day(2026, 1, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := p.Parse(tt.input)
+			require.NoError(t, err)
+			assert.True(t, tt.start.Equal(r.Start), "start: want %v, got %v", tt.start, r.Start)
+			assert.True(t, tt.end.Equal(r.End), "end: want %v, got %v", tt.end, r.End)
+		})
+	}
+}
+
+func TestDateParser_ParseErrors(t *testing.T) {
+	p := testParser(t, time.Monday)
+	for _, input := range []string{"", "soon", "2026-13", "2026-W60", "2025-W53", "2026-03..2026-01", "3 fortnights"} {
+		_, err := p.Parse(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestDateParser_WeekStart(t *testing.T) {
+	p := testParser(t, time.Sunday)
+	r, err := p.Parse("this week")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-04-12", r.Start.Format("2006-01-02"))
+	assert.Equal(t, "2026-04-19", r.End.Format("2006-01-02"))
+
+	// ISO weeks always start on Monday
+	r, err = p.Parse("2026-W14")
+	require.NoError(t, err)
+	assert.Equal(t, time.Monday, r.Start.Weekday())
+}
+
+func TestDateParser_Timezone(t *testing.T) {
+	p := testParser(t, time.Monday)
+	r, err := p.Parse("today")
+	require.NoError(t, err)
+
+	// 23:30 UTC on the 14th is already the 15th in Berlin
+	assert.True(t, r.Contains(time.Date(2026, 4, 14, 23, 30, 0, 0, time.UTC)))
+	assert.False(t, r.Contains(time.Date(2026, 4, 14, 21, 30, 0, 0, time.UTC)))
+}
+
+func TestDateParser_SinceUntil(t *testing.T) {
+	p := testParser(t, time.Monday)
+
+	r, err := p.SinceUntil("2026-03", "2026-03")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01", r.Start.Format("2006-01-02"))
+	assert.Equal(t, "2026-04-01", r.End.Format("2006-01-02"))
+
+	r, err = p.SinceUntil("last week", "")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-04-06", r.Start.Format("2006-01-02"))
+	assert.True(t, r.End.IsZero())
+
+	r, err = p.SinceUntil("2026-01..2026-02", "")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01", r.End.Format("2006-01-02"))
+
+	_, err = p.SinceUntil("today", "last week")
+	assert.Error(t, err)
+}
+
+func TestDateParser_Period(t *testing.T) {
+	p := testParser(t, time.Monday)
+
+	r, err := p.Period("90d")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01-15", r.Start.Format("2006-01-02"))
+	assert.True(t, r.End.IsZero())
+
+	r, err = p.Period("3 weeks ago")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-25", r.Start.Format("2006-01-02"))
+	assert.True(t, r.End.IsZero())
+
+	r, err = p.Period("last month")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01", r.Start.Format("2006-01-02"))
+	assert.Equal(t, "2026-04-01", r.End.Format("2006-01-02"))
+
+	_, err = p.Period("soon")
+	assert.Error(t, err)
+}
+
+func TestParseWeekday(t *testing.T) {
+	d, err := ParseWeekday("Sunday")
+	require.NoError(t, err)
+	assert.Equal(t, time.Sunday, d)
+
+	_, err = ParseWeekday("someday")
+	assert.Error(t, err)
+}
+
+func TestNewDateParser(t *testing.T) {
+	p, err := NewDateParser("America/New_York", "sun")
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", p.Location.String())
+	assert.Equal(t, time.Sunday, p.WeekStart)
+
+	_, err = NewDateParser("Mars/Olympus", "")
+	assert.Error(t, err)
+	_, err = NewDateParser("", "someday")
+	assert.Error(t, err)
+}
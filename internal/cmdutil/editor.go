@@ -0,0 +1,61 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Editor returns the user's preferred editor from $VISUAL or $EDITOR, defaulting to vi
+func Editor() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if e := strings.TrimSpace(os.Getenv(env)); e != "" {
+			return e
+		}
+	}
+	return "vi"
+}
+
+// EditText opens initial in the user's editor and returns the saved text.
+// pattern is the temporary file name pattern, e.g. "workout-*.json".
+func EditText(initial, pattern string) (string, error) {
+	return EditTextWith(Editor(), initial, pattern)
+}
+
+// EditTextWith is like EditText but uses the given editor command
+func EditTextWith(editor, initial, pattern string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	// The editor may include arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	if len(parts) == 0 {
+		parts = strings.Fields(Editor())
+	}
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", parts[0], err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return string(data), nil
+}
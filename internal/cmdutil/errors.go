@@ -0,0 +1,71 @@
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// UsageError reports invalid arguments or flag values
+type UsageError struct {
+	Err error
+}
+
+// UsageErrorf creates a UsageError with a formatted message
+func UsageErrorf(format string, args ...any) error {
+	return &UsageError{Err: fmt.Errorf(format, args...)}
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the error code used in JSON output
+func (e *UsageError) Code() string {
+	return "INVALID_ARGUMENTS"
+}
+
+// ExitCode returns the exit code for invalid arguments
+func (e *UsageError) ExitCode() int {
+	return api.ExitInvalidArgs
+}
+
+// ErrNoAPIKey is returned by commands that need an API key when none is
+// configured
+var ErrNoAPIKey error = &NoAPIKeyError{}
+
+// NoAPIKeyError reports that no API key is configured for the active profile
+type NoAPIKeyError struct{}
+
+func (e *NoAPIKeyError) Error() string {
+	return "API key not configured. Run 'hevycli config init' to set up"
+}
+
+// Code returns the error code used in JSON output
+func (e *NoAPIKeyError) Code() string {
+	return "NO_API_KEY"
+}
+
+// ExitCode returns the exit code for authentication failures
+func (e *NoAPIKeyError) ExitCode() int {
+	return api.ExitAuthError
+}
+
+// ExitCode returns the process exit code for an error. Errors anywhere in
+// the chain that have an ExitCode method, such as api.APIError and
+// UsageError, decide the code; other errors exit with 1.
+func ExitCode(err error) int {
+	if err == nil {
+		return api.ExitSuccess
+	}
+	var coded interface{ ExitCode() int }
+	if errors.As(err, &coded) {
+		return coded.ExitCode()
+	}
+	return api.ExitGeneralError
+}
@@ -0,0 +1,45 @@
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, api.ExitSuccess},
+		{"plain error", errors.New("boom"), api.ExitGeneralError},
+		{"usage error", UsageErrorf("invalid --weeks: %d", 0), api.ExitInvalidArgs},
+		{"wrapped api error", fmt.Errorf("failed to fetch workout: %w", &api.APIError{ErrorCode: "NOT_FOUND"}), api.ExitNotFound},
+		{"auth error", fmt.Errorf("failed: %w", api.ErrInvalidAPIKey), api.ExitAuthError},
+		{"rate limited", api.ErrRateLimited, api.ExitRateLimited},
+		{"no api key", ErrNoAPIKey, api.ExitAuthError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestUsageError(t *testing.T) {
+	inner := errors.New("bad value")
+	err := fmt.Errorf("invalid flag: %w", &UsageError{Err: inner})
+
+	assert.Equal(t, "invalid flag: bad value", err.Error())
+	assert.ErrorIs(t, err, inner)
+
+	var usage *UsageError
+	assert.ErrorAs(t, err, &usage)
+	assert.Equal(t, "INVALID_ARGUMENTS", usage.Code())
+}
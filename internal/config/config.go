@@ -14,12 +14,24 @@ import (
 type Config struct {
 	API     APIConfig     `mapstructure:"api" yaml:"api"`
 	Display DisplayConfig `mapstructure:"display" yaml:"display"`
+	Stats   StatsConfig   `mapstructure:"stats" yaml:"stats"`
+
+	CurrentProfile string                   `mapstructure:"current_profile" yaml:"current_profile,omitempty"`
+	Profiles       map[string]ProfileConfig `mapstructure:"profiles" yaml:"profiles,omitempty"`
+
+	// Aliases maps alias names to command expansions
+	Aliases map[string]string `mapstructure:"aliases" yaml:"aliases,omitempty"`
+
+	// ActiveProfile is the profile selected for this run; empty means the default profile
+	ActiveProfile string `mapstructure:"-" yaml:"-"`
 }
 
 // APIConfig holds API-related configuration
 type APIConfig struct {
-	Key     string `mapstructure:"key" yaml:"key,omitempty"`
-	BaseURL string `mapstructure:"base_url" yaml:"base_url"`
+	Key        string `mapstructure:"key" yaml:"key,omitempty"`
+	KeyBackend string `mapstructure:"key_backend" yaml:"key_backend,omitempty"`
+	KeyCommand string `mapstructure:"key_command" yaml:"key_command,omitempty"`
+	BaseURL    string `mapstructure:"base_url" yaml:"base_url"`
 }
 
 // DisplayConfig holds display-related configuration
@@ -29,6 +41,14 @@ type DisplayConfig struct {
 	Units        string `mapstructure:"units" yaml:"units"`
 	DateFormat   string `mapstructure:"date_format" yaml:"date_format"`
 	TimeFormat   string `mapstructure:"time_format" yaml:"time_format"`
+	Timezone     string `mapstructure:"timezone" yaml:"timezone,omitempty"`
+	WeekStart    string `mapstructure:"week_start" yaml:"week_start,omitempty"`
+}
+
+// StatsConfig holds analytics-related configuration
+type StatsConfig struct {
+	OneRMFormula string `mapstructure:"one_rm_formula" yaml:"one_rm_formula"`
+	WeeklyTarget int    `mapstructure:"weekly_target" yaml:"weekly_target,omitempty"`
 }
 
 // DefaultConfig returns configuration with sensible defaults
@@ -44,6 +64,9 @@ func DefaultConfig() *Config {
 			DateFormat:   "2006-01-02",
 			TimeFormat:   "15:04",
 		},
+		Stats: StatsConfig{
+			OneRMFormula: "brzycki",
+		},
 	}
 }
 
@@ -68,7 +91,24 @@ func EnsureConfigDir() error {
 }
 
 // Load reads configuration from file, environment, and returns a Config struct
+// with the active profile selected
 func Load(cfgFile string) (*Config, error) {
+	cfg, err := LoadUnresolved(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.resolveProfile(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// LoadUnresolved reads configuration without selecting a profile.
+// It is used by commands that manage profiles, which must work even when
+// the requested profile does not exist.
+func LoadUnresolved(cfgFile string) (*Config, error) {
 	v := viper.New()
 
 	// Set config file location
@@ -87,6 +127,7 @@ func Load(cfgFile string) (*Config, error) {
 	v.SetDefault("display.units", "metric")
 	v.SetDefault("display.date_format", "2006-01-02")
 	v.SetDefault("display.time_format", "15:04")
+	v.SetDefault("stats.one_rm_formula", "brzycki")
 
 	// Environment variable support
 	v.SetEnvPrefix("HEVYCLI")
@@ -98,6 +139,7 @@ func Load(cfgFile string) (*Config, error) {
 	_ = v.BindEnv("display.output_format", "HEVYCLI_OUTPUT_FORMAT")
 	_ = v.BindEnv("display.units", "HEVYCLI_UNITS")
 	_ = v.BindEnv("display.color", "HEVYCLI_COLOR")
+	_ = v.BindEnv("stats.one_rm_formula", "HEVYCLI_ONE_RM_FORMULA")
 
 	// Read config file (ignore if not found)
 	if err := v.ReadInConfig(); err != nil {
@@ -150,23 +192,25 @@ func SaveTo(cfg *Config, path string) error {
 	return nil
 }
 
-// GetAPIKey returns the API key with precedence: env > config
+// GetAPIKey returns the API key with precedence: env > key command > secrets backend > config.
+// Lookup errors are reported on stderr and result in an empty key.
 func (c *Config) GetAPIKey() string {
-	// Check environment variable first
-	if key := os.Getenv("HEVYCLI_API_KEY"); key != "" {
-		return key
+	key, err := c.ResolveAPIKey()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return ""
 	}
-	return c.API.Key
+	return key
 }
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	// Validate output format
 	switch c.Display.OutputFormat {
-	case "json", "table", "plain":
+	case "json", "table", "plain", "yaml", "ndjson", "csv":
 		// Valid
 	default:
-		return fmt.Errorf("invalid output format: %s (must be json, table, or plain)", c.Display.OutputFormat)
+		return fmt.Errorf("invalid output format: %s (must be json, table, plain, yaml, ndjson, or csv)", c.Display.OutputFormat)
 	}
 
 	// Validate units
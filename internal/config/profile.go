@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile is the name of the implicit profile backed by the top-level api.key
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is returned when the selected profile does not exist
+var ErrProfileNotFound = errors.New("profile not found")
+
+// ProfileConfig holds the settings of one named account
+type ProfileConfig struct {
+	APIKey        string `mapstructure:"api_key" yaml:"api_key,omitempty"`
+	APIKeyBackend string `mapstructure:"api_key_backend" yaml:"api_key_backend,omitempty"`
+	APIKeyCommand string `mapstructure:"api_key_command" yaml:"api_key_command,omitempty"`
+	Description   string `mapstructure:"description" yaml:"description,omitempty"`
+}
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// profileOverride is set from the --profile flag and wins over HEVYCLI_PROFILE
+var profileOverride string
+
+// SetProfileOverride selects the profile used by subsequent Load calls
+func SetProfileOverride(name string) {
+	profileOverride = strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeProfileName lowercases a profile name and checks that it is valid.
+// Names are lowercased because config map keys are case-insensitive.
+func NormalizeProfileName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !profileNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid profile name: %q (use letters, digits, '-' and '_')", name)
+	}
+	return name, nil
+}
+
+// RequestedProfile returns the profile requested by --profile or HEVYCLI_PROFILE, if any
+func RequestedProfile() string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	return strings.ToLower(strings.TrimSpace(os.Getenv("HEVYCLI_PROFILE")))
+}
+
+// resolveProfile picks the active profile: --profile > HEVYCLI_PROFILE > current_profile
+func (c *Config) resolveProfile() error {
+	name := RequestedProfile()
+	if name == "" {
+		name = c.CurrentProfile
+	}
+	if name == "" || name == DefaultProfile {
+		c.ActiveProfile = ""
+		return nil
+	}
+	if _, ok := c.Profiles[name]; !ok {
+		return fmt.Errorf("%w: %s (see 'hevycli config profile list')", ErrProfileNotFound, name)
+	}
+	c.ActiveProfile = name
+	return nil
+}
+
+// ProfileName returns the active profile name, or "default" when none is selected
+func (c *Config) ProfileName() string {
+	if c.ActiveProfile == "" {
+		return DefaultProfile
+	}
+	return c.ActiveProfile
+}
+
+// ProfileNames returns the configured profile names in sorted order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProfileDir returns the directory holding per-profile data such as the undo journal
+func ProfileDir(name string) string {
+	if name == "" || name == DefaultProfile {
+		return ConfigDir()
+	}
+	return filepath.Join(ConfigDir(), "profiles", name)
+}
+
+// JournalDir returns the undo journal directory of the active profile
+func (c *Config) JournalDir() string {
+	return filepath.Join(ProfileDir(c.ActiveProfile), "journal")
+}
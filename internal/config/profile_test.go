@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveProfilesConfig(t *testing.T) string {
+	t.Helper()
+	cfgFile := filepath.Join(t.TempDir(), "config.yaml")
+
+	cfg := DefaultConfig()
+	cfg.API.Key = "top-level-key"
+	cfg.CurrentProfile = "client-a"
+	cfg.Profiles = map[string]ProfileConfig{
+		"client-a": {APIKey: "key-a"},
+		"client-b": {APIKey: "key-b", Description: "Bob"},
+	}
+	require.NoError(t, SaveTo(cfg, cfgFile))
+	return cfgFile
+}
+
+func TestLoadProfiles(t *testing.T) {
+	t.Setenv("HEVYCLI_API_KEY", "")
+	t.Setenv("HEVYCLI_PROFILE", "")
+	cfgFile := saveProfilesConfig(t)
+
+	cfg, err := LoadFrom(cfgFile)
+	require.NoError(t, err)
+	assert.Equal(t, "client-a", cfg.ActiveProfile)
+	assert.Equal(t, "key-a", cfg.GetAPIKey())
+	assert.Equal(t, []string{"client-a", "client-b"}, cfg.ProfileNames())
+	assert.Equal(t, "Bob", cfg.Profiles["client-b"].Description)
+}
+
+func TestLoadProfiles_Precedence(t *testing.T) {
+	t.Setenv("HEVYCLI_API_KEY", "")
+	cfgFile := saveProfilesConfig(t)
+
+	t.Setenv("HEVYCLI_PROFILE", "client-b")
+	cfg, err := LoadFrom(cfgFile)
+	require.NoError(t, err)
+	assert.Equal(t, "key-b", cfg.GetAPIKey())
+
+	SetProfileOverride("default")
+	defer SetProfileOverride("")
+	cfg, err = LoadFrom(cfgFile)
+	require.NoError(t, err)
+	assert.Equal(t, "", cfg.ActiveProfile)
+	assert.Equal(t, "default", cfg.ProfileName())
+	assert.Equal(t, "top-level-key", cfg.GetAPIKey())
+}
+
+func TestLoadProfiles_NotFound(t *testing.T) {
+	cfgFile := saveProfilesConfig(t)
+	t.Setenv("HEVYCLI_PROFILE", "missing")
+
+	_, err := LoadFrom(cfgFile)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrProfileNotFound))
+
+	cfg, err := LoadUnresolved(cfgFile)
+	require.NoError(t, err)
+	assert.Len(t, cfg.Profiles, 2)
+}
+
+func TestNormalizeProfileName(t *testing.T) {
+	name, err := NormalizeProfileName(" Client_A ")
+	require.NoError(t, err)
+	assert.Equal(t, "client_a", name)
+
+	_, err = NormalizeProfileName("bad name")
+	assert.Error(t, err)
+
+	_, err = NormalizeProfileName("")
+	assert.Error(t, err)
+}
+
+func TestProfileDirs(t *testing.T) {
+	assert.Equal(t, ConfigDir(), ProfileDir(DefaultProfile))
+	assert.Equal(t, filepath.Join(ConfigDir(), "profiles", "coach"), ProfileDir("coach"))
+
+	cfg := DefaultConfig()
+	cfg.ActiveProfile = "coach"
+	assert.Equal(t, filepath.Join(ConfigDir(), "profiles", "coach", "journal"), cfg.JournalDir())
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/obay/hevycli/internal/secrets"
+)
+
+// SecretsFile returns the path of the encrypted secrets file
+func SecretsFile() string {
+	return filepath.Join(ConfigDir(), "secrets.age")
+}
+
+// secretsOptions configures the secrets backends
+func secretsOptions() secrets.Options {
+	return secrets.Options{
+		FilePath:   SecretsFile(),
+		Passphrase: secrets.TerminalPassphrase,
+	}
+}
+
+// keySource returns where the API key of a profile is kept
+func (c *Config) keySource(profile string) (backend, command, plaintext string) {
+	if profile == "" || profile == DefaultProfile {
+		return c.API.KeyBackend, c.API.KeyCommand, c.API.Key
+	}
+	p := c.Profiles[profile]
+	return p.APIKeyBackend, p.APIKeyCommand, p.APIKey
+}
+
+// setKeySource records where the API key of a profile is kept
+func (c *Config) setKeySource(profile, backend, plaintext string) {
+	if backend == secrets.BackendPlain {
+		backend = ""
+	}
+	if profile == "" || profile == DefaultProfile {
+		c.API.KeyBackend, c.API.Key = backend, plaintext
+		return
+	}
+	p := c.Profiles[profile]
+	p.APIKeyBackend, p.APIKey = backend, plaintext
+	c.Profiles[profile] = p
+}
+
+// ResolveAPIKey returns the API key with precedence:
+// env > key command > secrets backend > plaintext config
+func (c *Config) ResolveAPIKey() (string, error) {
+	if key := os.Getenv("HEVYCLI_API_KEY"); key != "" {
+		return key, nil
+	}
+
+	profile := c.ProfileName()
+	backend, command, plaintext := c.keySource(profile)
+
+	if command != "" {
+		return secrets.RunCommand(command)
+	}
+
+	store, err := secrets.Open(backend, secretsOptions())
+	if err != nil {
+		return "", err
+	}
+	if store == nil {
+		return plaintext, nil
+	}
+
+	key, err := store.Get(profile)
+	if errors.Is(err, secrets.ErrNotFound) {
+		return plaintext, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key from %s: %w", store.Name(), err)
+	}
+	return key, nil
+}
+
+// StoreAPIKey stores the API key of the active profile. See StoreProfileAPIKey.
+func (c *Config) StoreAPIKey(key, backend string) (string, error) {
+	return c.StoreProfileAPIKey(c.ProfileName(), key, backend)
+}
+
+// StoreProfileAPIKey stores a profile's API key in a secrets backend and
+// returns the backend used. An empty backend keeps the profile's current
+// backend, or picks one automatically for keys that are not stored yet.
+// The auto backend prefers the system keyring, then the encrypted file, and
+// falls back to plaintext when neither is usable. The config must be saved
+// afterwards to record the backend.
+func (c *Config) StoreProfileAPIKey(profile, key, backend string) (string, error) {
+	current, _, _ := c.keySource(profile)
+	if backend == "" {
+		backend = current
+	}
+	if backend == "" {
+		backend = secrets.BackendAuto
+	}
+
+	backend, err := secrets.ParseBackend(backend)
+	if err != nil {
+		return "", err
+	}
+	if backend == secrets.BackendAuto {
+		switch {
+		case secrets.KeyringAvailable():
+			backend = secrets.BackendKeyring
+		case secrets.PassphraseAvailable():
+			backend = secrets.BackendFile
+		default:
+			backend = secrets.BackendPlain
+		}
+	}
+
+	if backend == secrets.BackendPlain {
+		c.setKeySource(profile, backend, key)
+		return backend, nil
+	}
+
+	store, err := secrets.Open(backend, secretsOptions())
+	if err != nil {
+		return "", err
+	}
+	if err := store.Set(profile, key); err != nil {
+		return "", fmt.Errorf("failed to store API key in %s: %w", store.Name(), err)
+	}
+
+	// Remove the key from the previous backend when switching
+	if current != "" && current != backend {
+		if old, err := secrets.Open(current, secretsOptions()); err == nil && old != nil {
+			_ = old.Delete(profile)
+		}
+	}
+
+	c.setKeySource(profile, backend, "")
+	return backend, nil
+}
+
+// DeleteProfileSecret removes a profile's API key from its secrets backend
+func (c *Config) DeleteProfileSecret(profile string) error {
+	backend, _, _ := c.keySource(profile)
+	store, err := secrets.Open(backend, secretsOptions())
+	if err != nil || store == nil {
+		return err
+	}
+	return store.Delete(profile)
+}
+
+// PlaintextKeyProfiles returns the profiles whose API key is stored in the config file
+func (c *Config) PlaintextKeyProfiles() []string {
+	var names []string
+	if c.API.Key != "" {
+		names = append(names, DefaultProfile)
+	}
+	for _, name := range c.ProfileNames() {
+		if c.Profiles[name].APIKey != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// KeyBackendName describes where a profile's API key is kept, for display
+func (c *Config) KeyBackendName(profile string) string {
+	backend, command, _ := c.keySource(profile)
+	switch {
+	case command != "":
+		return "command"
+	case backend == "":
+		return secrets.BackendPlain
+	default:
+		return backend
+	}
+}
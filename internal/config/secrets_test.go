@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/secrets"
+)
+
+func TestStoreAPIKey_Plain(t *testing.T) {
+	t.Setenv("HEVYCLI_API_KEY", "")
+	cfg := DefaultConfig()
+	cfg.Profiles = map[string]ProfileConfig{"coach": {}}
+
+	backend, err := cfg.StoreAPIKey("top", secrets.BackendPlain)
+	require.NoError(t, err)
+	assert.Equal(t, secrets.BackendPlain, backend)
+	assert.Equal(t, "top", cfg.API.Key)
+
+	cfg.ActiveProfile = "coach"
+	_, err = cfg.StoreAPIKey("coach-key", secrets.BackendPlain)
+	require.NoError(t, err)
+	assert.Equal(t, "coach-key", cfg.Profiles["coach"].APIKey)
+	assert.Equal(t, "top", cfg.API.Key)
+	assert.Equal(t, "coach-key", cfg.GetAPIKey())
+}
+
+func TestStoreAPIKey_EncryptedFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HEVYCLI_API_KEY", "")
+	t.Setenv(secrets.PassphraseEnv, "correct horse battery staple")
+
+	cfg := DefaultConfig()
+	cfg.API.Key = "plaintext-key"
+	cfg.Profiles = map[string]ProfileConfig{"coach": {APIKey: "coach-plain"}}
+	assert.Equal(t, []string{DefaultProfile, "coach"}, cfg.PlaintextKeyProfiles())
+
+	backend, err := cfg.StoreProfileAPIKey(DefaultProfile, "plaintext-key", secrets.BackendFile)
+	require.NoError(t, err)
+	assert.Equal(t, secrets.BackendFile, backend)
+	assert.Empty(t, cfg.API.Key)
+	assert.Equal(t, secrets.BackendFile, cfg.API.KeyBackend)
+	assert.Equal(t, []string{"coach"}, cfg.PlaintextKeyProfiles())
+
+	data, err := os.ReadFile(SecretsFile())
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "plaintext-key")
+
+	key, err := cfg.ResolveAPIKey()
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext-key", key)
+	assert.Equal(t, secrets.BackendFile, cfg.KeyBackendName(DefaultProfile))
+
+	require.NoError(t, cfg.DeleteProfileSecret(DefaultProfile))
+	key, err = cfg.ResolveAPIKey()
+	require.NoError(t, err)
+	assert.Empty(t, key)
+}
+
+func TestResolveAPIKey_Command(t *testing.T) {
+	t.Setenv("HEVYCLI_API_KEY", "")
+	cfg := DefaultConfig()
+	cfg.API.Key = "ignored"
+	cfg.API.KeyCommand = "echo from-command"
+
+	key, err := cfg.ResolveAPIKey()
+	require.NoError(t, err)
+	assert.Equal(t, "from-command", key)
+	assert.Equal(t, "command", cfg.KeyBackendName(DefaultProfile))
+
+	cfg.API.KeyCommand = "exit 3"
+	_, err = cfg.ResolveAPIKey()
+	assert.Error(t, err)
+}
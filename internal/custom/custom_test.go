@@ -0,0 +1,256 @@
+package custom
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/journal"
+)
+
+func TestParse_YAML(t *testing.T) {
+	src := `
+- title: Cable Fly
+  type: weight_reps
+  muscle: chest
+  equipment: machine
+  other_muscles: [shoulders]
+- title: Plank Hold
+  type: duration
+  muscle: Abdominals
+`
+	entries, err := Parse("exercises.yaml", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, Entry{Line: 2, Title: "Cable Fly", Type: "weight_reps", Muscle: "chest", Equipment: "machine", OtherMuscles: []string{"shoulders"}}, entries[0])
+	assert.Equal(t, 7, entries[1].Line)
+
+	entries, err = Parse("exercises.yml", []byte("exercises:\n  - title: Dip\n    muscle: triceps\n"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Dip", entries[0].Title)
+
+	_, err = Parse("exercises.yaml", []byte("title: Dip\n"))
+	assert.Error(t, err)
+}
+
+func TestParse_CSV(t *testing.T) {
+	src := "Title,Type,Muscle,Equipment,Other Muscles\n" +
+		"Cable Fly,weight_reps,chest,machine,shoulders;triceps\n" +
+		"\"Plank, Weighted\",weight_duration,abdominals,plate,\n"
+	_, err := Parse("exercises.csv", []byte(src))
+	assert.EqualError(t, err, `unknown CSV column "Other Muscles"`)
+
+	src = "title,exercise_type,muscle_group,equipment_category,other_muscles\n" +
+		"Cable Fly,weight_reps,chest,machine,shoulders;triceps\n" +
+		"\"Plank, Weighted\",weight_duration,abdominals,plate,\n"
+	entries, err := Parse("exercises.CSV", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, Entry{Line: 2, Title: "Cable Fly", Type: "weight_reps", Muscle: "chest", Equipment: "machine", OtherMuscles: []string{"shoulders", "triceps"}}, entries[0])
+	assert.Equal(t, "Plank, Weighted", entries[1].Title)
+	assert.Equal(t, 3, entries[1].Line)
+	assert.Empty(t, entries[1].OtherMuscles)
+}
+
+func TestEntryRequest(t *testing.T) {
+	req, problems := Entry{Line: 1, Title: " Cable Fly ", Muscle: "Upper Back", OtherMuscles: []string{"full-body"}}.Request()
+	assert.Empty(t, problems)
+	assert.Equal(t, api.CreateCustomExerciseData{
+		Title:             "Cable Fly",
+		ExerciseType:      api.ExerciseTypeWeightReps,
+		EquipmentCategory: api.EquipmentNone,
+		MuscleGroup:       api.MuscleGroupUpperBack,
+		OtherMuscles:      []api.MuscleGroup{api.MuscleGroupFullBody},
+	}, req)
+
+	_, problems = Entry{Line: 4, Type: "weights", Muscle: "pecs", Equipment: "cable", OtherMuscles: []string{"tricep"}}.Request()
+	require.Len(t, problems, 5)
+	assert.Equal(t, "line 4: title is required", problems[0])
+	assert.Contains(t, problems[1], `line 4: unknown exercise type "weights" (valid: weight_reps, reps_only`)
+	assert.Contains(t, problems[2], `unknown muscle group "pecs"`)
+	assert.Contains(t, problems[3], `unknown equipment "cable"`)
+	assert.Equal(t, `line 4: other muscles: unknown muscle group "tricep" (did you mean triceps?)`, problems[4])
+
+	_, problems = Entry{Line: 2, Title: "Dip"}.Request()
+	assert.Equal(t, []string{"line 2: muscle is required"}, problems)
+}
+
+func TestPlan(t *testing.T) {
+	templates := []api.ExerciseTemplate{
+		{ID: "c1", Title: "Cable Fly", IsCustom: true},
+		{ID: "b1", Title: "Plank"},
+	}
+	planned, err := Plan([]Entry{
+		{Line: 1, Title: "cable  fly", Muscle: "chest"},
+		{Line: 2, Title: "Plank", Muscle: "abdominals"},
+	}, templates)
+	require.NoError(t, err)
+	require.Len(t, planned, 2)
+	require.NotNil(t, planned[0].Existing)
+	assert.Equal(t, "c1", planned[0].Existing.ID)
+	// Only custom exercises count as existing
+	assert.Nil(t, planned[1].Existing)
+
+	_, err = Plan([]Entry{
+		{Line: 1, Title: "Dip", Muscle: "triceps"},
+		{Line: 5, Title: "DIP", Muscle: "nope"},
+	}, nil)
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []string{
+		`line 5: unknown muscle group "nope" (valid: chest, shoulders, biceps, triceps, forearms, lats, upper_back, traps, lower_back, abdominals, quadriceps, hamstrings, glutes, calves, abductors, adductors, cardio, neck, full_body, other)`,
+		`line 5: "DIP" is already on line 1`,
+	}, verr.Problems)
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("Cable Flys", "cable fly"))
+	assert.Equal(t, 1.0, Similarity("DB Bench Press", "Bench Press (Dumbbell)"))
+	assert.Equal(t, 1.0, Similarity("RDL (Barbell)", "Romanian Deadlift (Barbell)"))
+	assert.InDelta(t, 0.8, Similarity("Bench Press", "Incline Bench Press"), 0.001)
+	assert.Equal(t, 0.0, Similarity("Squat", "Bench Press"))
+	assert.Equal(t, 0.0, Similarity("", "Bench Press"))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	templates := []api.ExerciseTemplate{
+		{ID: "b1", Title: "Bench Press (Barbell)", Type: api.ExerciseTypeWeightReps, PrimaryMuscleGroup: "chest", Equipment: "barbell"},
+		{ID: "b2", Title: "Bench Press (Dumbbell)", Type: api.ExerciseTypeWeightReps, PrimaryMuscleGroup: "chest", Equipment: "dumbbell"},
+		{ID: "b3", Title: "Squat (Barbell)", Type: api.ExerciseTypeWeightReps, PrimaryMuscleGroup: "quadriceps", Equipment: "barbell"},
+		{ID: "b4", Title: "Sled Push", Type: api.ExerciseTypeShortDistanceWeight, PrimaryMuscleGroup: "quadriceps"},
+		{ID: "c1", Title: "DB bench press", Type: api.ExerciseTypeWeightReps, PrimaryMuscleGroup: "chest", Equipment: "dumbbell", IsCustom: true},
+		// Same title, other muscle group: not a duplicate
+		{ID: "c2", Title: "Squats (Barbell)", Type: api.ExerciseTypeWeightReps, PrimaryMuscleGroup: "glutes", IsCustom: true},
+		{ID: "c3", Title: "Banded Face Pull", Type: api.ExerciseTypeWeightReps, PrimaryMuscleGroup: "shoulders", IsCustom: true},
+		// Same title, other type: not a duplicate
+		{ID: "c4", Title: "Sled Push", Type: api.ExerciseTypeWeightDuration, PrimaryMuscleGroup: "quadriceps", IsCustom: true},
+	}
+	dups := FindDuplicates(templates, DefaultMinScore)
+	require.Len(t, dups, 1)
+	assert.Equal(t, "c1", dups[0].Custom.ID)
+	assert.Equal(t, "b2", dups[0].Builtin.ID)
+	assert.Equal(t, 1.0, dups[0].Score)
+
+	// With a low threshold the barbell press qualifies too, but the better
+	// match wins
+	dups = FindDuplicates(templates, 0.5)
+	require.Len(t, dups, 1)
+	assert.Equal(t, "b2", dups[0].Builtin.ID)
+}
+
+func TestFindDuplicates_TiePrefersEquipment(t *testing.T) {
+	templates := []api.ExerciseTemplate{
+		{ID: "b1", Title: "Alpha Beta Gamma Delta", PrimaryMuscleGroup: "chest", Equipment: "barbell"},
+		{ID: "b2", Title: "Alpha Beta Gamma Epsilon", PrimaryMuscleGroup: "chest", Equipment: "dumbbell"},
+		{ID: "c1", Title: "Alpha Beta Gamma", PrimaryMuscleGroup: "chest", Equipment: "dumbbell", IsCustom: true},
+	}
+	dups := FindDuplicates(templates, 0.5)
+	require.Len(t, dups, 1)
+	assert.Equal(t, "b2", dups[0].Builtin.ID)
+	assert.Equal(t, 0.86, dups[0].Score)
+}
+
+func TestFindUsage(t *testing.T) {
+	workouts := []api.Workout{
+		{ID: "w1", Exercises: []api.Exercise{{ExerciseTemplateID: "c1"}}},
+		{ID: "w2", Exercises: []api.Exercise{{ExerciseTemplateID: "b1"}}},
+	}
+	routines := []api.Routine{{ID: "r1", Exercises: []api.Exercise{{ExerciseTemplateID: "b1"}, {ExerciseTemplateID: "c1"}}}}
+	u := FindUsage("c1", workouts, routines)
+	assert.Equal(t, Usage{Workouts: []string{"w1"}, Routines: []string{"r1"}}, u)
+	assert.False(t, u.Empty())
+	assert.True(t, FindUsage("zz", workouts, routines).Empty())
+}
+
+func TestRemap(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/workouts/w1":
+			var req api.UpdateWorkoutRequest
+			require.NoError(t, json.Unmarshal(body, &req))
+			require.Len(t, req.Workout.Exercises, 2)
+			assert.Equal(t, "b1", req.Workout.Exercises[0].ExerciseTemplateID)
+			assert.Equal(t, "x", req.Workout.Exercises[1].ExerciseTemplateID)
+			json.NewEncoder(w).Encode(map[string]any{"workout": api.Workout{ID: "w1"}})
+		case "/routines/r1":
+			var req api.UpdateRoutineRequest
+			require.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, "b1", req.Routine.Exercises[0].ExerciseTemplateID)
+			json.NewEncoder(w).Encode(map[string]any{"routine": api.Routine{ID: "r1"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient("key", api.WithBaseURL(server.URL))
+	j := journal.Open(t.TempDir())
+	rec := j.Begin("dedupe")
+
+	workouts := []api.Workout{
+		{ID: "w1", Title: "Push", Exercises: []api.Exercise{{ExerciseTemplateID: "c1"}, {ExerciseTemplateID: "x"}}},
+		{ID: "w2", Title: "Legs", Exercises: []api.Exercise{{ExerciseTemplateID: "x"}}},
+	}
+	routines := []api.Routine{{ID: "r1", Title: "Push", Exercises: []api.Exercise{{ExerciseTemplateID: "c1"}}}}
+	from := api.ExerciseTemplate{ID: "c1", Type: api.ExerciseTypeWeightReps}
+	to := api.ExerciseTemplate{ID: "b1", Type: api.ExerciseTypeWeightReps}
+	done, err := Remap(client, rec, from, to, workouts, routines)
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Workouts: []string{"w1"}, Routines: []string{"r1"}}, done)
+	assert.Equal(t, []string{"PUT /workouts/w1", "PUT /routines/r1"}, requests)
+	assert.Equal(t, "b1", workouts[0].Exercises[0].ExerciseTemplateID)
+	assert.True(t, FindUsage("c1", workouts, routines).Empty())
+
+	op, err := j.Get(rec.ID())
+	require.NoError(t, err)
+	require.Len(t, op.Changes, 2)
+	assert.Equal(t, journal.ActionUpdate, op.Changes[0].Action)
+	assert.Equal(t, journal.KindWorkout, op.Changes[0].Kind)
+	assert.Equal(t, journal.KindRoutine, op.Changes[1].Kind)
+}
+
+func TestRemap_Mismatch(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	client := api.NewClient("key", api.WithBaseURL(server.URL))
+	rec := journal.Open(t.TempDir()).Begin("remap")
+
+	weight, seconds, meters := 40.0, 30, 20.0
+	start := time.Date(2026, 10, 1, 17, 0, 0, 0, time.UTC)
+	workouts := []api.Workout{
+		{ID: "w1", Title: "Legs", StartTime: start, Exercises: []api.Exercise{{ExerciseTemplateID: "c1", Sets: []api.Set{
+			{WeightKg: &weight, DurationSeconds: &seconds},
+		}}}},
+		{ID: "w2", Title: "Sprints", StartTime: start, Exercises: []api.Exercise{{ExerciseTemplateID: "x", Sets: []api.Set{
+			{DistanceMeters: &meters},
+		}}}},
+	}
+	from := api.ExerciseTemplate{ID: "c1", Type: api.ExerciseTypeWeightDuration}
+	to := api.ExerciseTemplate{ID: "b1", Title: "Sled Push", Type: api.ExerciseTypeShortDistanceWeight}
+
+	done, err := Remap(client, rec, from, to, workouts, nil)
+	var merr *MismatchError
+	require.ErrorAs(t, err, &merr)
+	assert.Equal(t, []string{`workout "Legs" (2026-10-01): set 1 has a duration`}, merr.Problems)
+	assert.True(t, done.Empty())
+	assert.Empty(t, requests)
+
+	// The same sets fit a weight and duration exercise
+	to.Type = api.ExerciseTypeWeightDuration
+	assert.NoError(t, CheckRemap("c1", to, workouts, nil))
+}
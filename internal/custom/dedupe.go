@@ -0,0 +1,115 @@
+package custom
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// DefaultMinScore is the title similarity from which a custom exercise is
+// considered a duplicate
+const DefaultMinScore = 0.75
+
+// Duplicate is a custom exercise that duplicates a built-in template
+type Duplicate struct {
+	Custom  api.ExerciseTemplate `json:"custom"`
+	Builtin api.ExerciseTemplate `json:"builtin"`
+	// Score is the similarity of the titles from 0 to 1
+	Score float64 `json:"score"`
+}
+
+// abbreviations expands common shorthand in exercise titles
+var abbreviations = map[string]string{
+	"db":  "dumbbell",
+	"bb":  "barbell",
+	"kb":  "kettlebell",
+	"ohp": "overhead press",
+	"rdl": "romanian deadlift",
+}
+
+// titleWords returns the set of words of a title, lowercased, with
+// punctuation removed, shorthand expanded and plurals made singular
+func titleWords(title string) map[string]bool {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127 {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(b.String()) {
+		if long, ok := abbreviations[w]; ok {
+			for _, lw := range strings.Fields(long) {
+				words[lw] = true
+			}
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		words[w] = true
+	}
+	return words
+}
+
+// Similarity scores how alike two exercise titles are from 0 to 1: the
+// share of words the titles have in common (Dice coefficient), ignoring
+// word order, punctuation, plurals and shorthand like "DB"
+func Similarity(a, b string) float64 {
+	wa, wb := titleWords(a), titleWords(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(wa)+len(wb))
+}
+
+// FindDuplicates pairs every custom template with the most similar built-in
+// template of the same exercise type and primary muscle group whose title
+// scores at least minScore. Ties prefer the same equipment. Duplicates are
+// sorted by the custom title.
+func FindDuplicates(templates []api.ExerciseTemplate, minScore float64) []Duplicate {
+	var duplicates []Duplicate
+	for _, c := range templates {
+		if !c.IsCustom {
+			continue
+		}
+		var best *Duplicate
+		var bestScore float64
+		for _, b := range templates {
+			if b.IsCustom || b.Type != c.Type || b.PrimaryMuscleGroup != c.PrimaryMuscleGroup {
+				continue
+			}
+			score := Similarity(c.Title, b.Title)
+			if score < minScore {
+				continue
+			}
+			// Compare unrounded scores so ties are exact
+			if best == nil || score > bestScore ||
+				score == bestScore && b.Equipment == c.Equipment && best.Builtin.Equipment != c.Equipment {
+				best = &Duplicate{Custom: c, Builtin: b}
+				bestScore = score
+			}
+		}
+		if best != nil {
+			best.Score = round2(bestScore)
+			duplicates = append(duplicates, *best)
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return strings.ToLower(duplicates[i].Custom.Title) < strings.ToLower(duplicates[j].Custom.Title)
+	})
+	return duplicates
+}
+
+func round2(v float64) float64 {
+	return float64(int(v*100+0.5)) / 100
+}
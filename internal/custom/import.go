@@ -0,0 +1,249 @@
+// Package custom manages custom exercise templates: importing them in bulk,
+// finding the ones that duplicate built-in templates and moving history from
+// one template to another.
+//
+// The API can create custom exercises but cannot edit or delete them. A
+// custom exercise is "replaced" by creating the corrected one, remapping the
+// workouts and routines that use the old one and deleting it in the app.
+package custom
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// Entry is one exercise of an import file
+type Entry struct {
+	// Line is the line of the entry in the file
+	Line         int      `yaml:"-"`
+	Title        string   `yaml:"title"`
+	Type         string   `yaml:"type"`
+	Muscle       string   `yaml:"muscle"`
+	Equipment    string   `yaml:"equipment"`
+	OtherMuscles []string `yaml:"other_muscles"`
+}
+
+// ValidationError lists the problems of an import file
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%d problems in import file:\n  %s", len(e.Problems), strings.Join(e.Problems, "\n  "))
+}
+
+// Parse reads the entries of an import file. Files ending in .csv are CSV
+// with a header row; anything else is YAML (or JSON) holding a list of
+// entries, either at the top level or under "exercises".
+func Parse(name string, data []byte) ([]Entry, error) {
+	if strings.EqualFold(filepath.Ext(name), ".csv") {
+		return parseCSV(data)
+	}
+	return parseYAML(data)
+}
+
+func parseYAML(data []byte) ([]Entry, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	list := doc.Content[0]
+	if list.Kind == yaml.MappingNode {
+		list = nil
+		for i := 0; i+1 < len(doc.Content[0].Content); i += 2 {
+			if doc.Content[0].Content[i].Value == "exercises" {
+				list = doc.Content[0].Content[i+1]
+			}
+		}
+		if list == nil {
+			return nil, errors.New(`expected a list of exercises or an "exercises" key`)
+		}
+	}
+	if list.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("line %d: expected a list of exercises", list.Line)
+	}
+
+	entries := make([]Entry, len(list.Content))
+	for i, n := range list.Content {
+		if err := n.Decode(&entries[i]); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n.Line, err)
+		}
+		entries[i].Line = n.Line
+	}
+	return entries, nil
+}
+
+// csvColumns maps header names to entry fields; the API's request field
+// names are accepted too
+var csvColumns = map[string]string{
+	"title":              "title",
+	"name":               "title",
+	"type":               "type",
+	"exercise_type":      "type",
+	"muscle":             "muscle",
+	"muscle_group":       "muscle",
+	"equipment":          "equipment",
+	"equipment_category": "equipment",
+	"other_muscles":      "other_muscles",
+}
+
+func parseCSV(data []byte) ([]Entry, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	columns := make([]string, len(header))
+	for i, h := range header {
+		field, ok := csvColumns[strings.ToLower(strings.TrimSpace(h))]
+		if !ok {
+			return nil, fmt.Errorf("unknown CSV column %q", h)
+		}
+		columns[i] = field
+	}
+
+	var entries []Entry
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		e := Entry{Line: line}
+		for i, value := range record {
+			if i >= len(columns) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			switch columns[i] {
+			case "title":
+				e.Title = value
+			case "type":
+				e.Type = value
+			case "muscle":
+				e.Muscle = value
+			case "equipment":
+				e.Equipment = value
+			case "other_muscles":
+				// Several muscles are separated by semicolons or pipes
+				for _, m := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '|' }) {
+					if m = strings.TrimSpace(m); m != "" {
+						e.OtherMuscles = append(e.OtherMuscles, m)
+					}
+				}
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// Request validates the entry and converts it into a create request. The
+// type defaults to weight_reps and the equipment to none, like in
+// 'hevycli exercise create'. Values are parsed like flags, so "Upper Back"
+// is read as upper_back.
+func (e Entry) Request() (api.CreateCustomExerciseData, []string) {
+	var problems []string
+	add := func(err error) {
+		problems = append(problems, fmt.Sprintf("line %d: %v", e.Line, err))
+	}
+
+	data := api.CreateCustomExerciseData{
+		Title:             strings.TrimSpace(e.Title),
+		ExerciseType:      api.ExerciseTypeWeightReps,
+		EquipmentCategory: api.EquipmentNone,
+	}
+	if data.Title == "" {
+		add(errors.New("title is required"))
+	}
+	var err error
+	if e.Type != "" {
+		if data.ExerciseType, err = api.ParseExerciseType(e.Type); err != nil {
+			add(err)
+		}
+	}
+	if e.Muscle == "" {
+		add(errors.New("muscle is required"))
+	} else if data.MuscleGroup, err = api.ParseMuscleGroup(e.Muscle); err != nil {
+		add(err)
+	}
+	if e.Equipment != "" {
+		if data.EquipmentCategory, err = api.ParseEquipmentCategory(e.Equipment); err != nil {
+			add(err)
+		}
+	}
+	for _, m := range e.OtherMuscles {
+		muscle, err := api.ParseMuscleGroup(m)
+		if err != nil {
+			add(fmt.Errorf("other muscles: %w", err))
+			continue
+		}
+		data.OtherMuscles = append(data.OtherMuscles, muscle)
+	}
+	return data, problems
+}
+
+// Planned is an entry ready to be imported
+type Planned struct {
+	Entry   Entry
+	Request api.CreateCustomExerciseData
+	// Existing is the custom exercise with the same title, if there is one;
+	// the entry is then skipped
+	Existing *api.ExerciseTemplate
+}
+
+// Plan validates every entry and matches them against the existing
+// templates. Nothing is planned unless every entry is valid: the problems
+// of all entries are returned as a *ValidationError.
+func Plan(entries []Entry, templates []api.ExerciseTemplate) ([]Planned, error) {
+	existing := make(map[string]*api.ExerciseTemplate)
+	for i := range templates {
+		if templates[i].IsCustom {
+			existing[titleKey(templates[i].Title)] = &templates[i]
+		}
+	}
+
+	var problems []string
+	seen := make(map[string]int)
+	planned := make([]Planned, 0, len(entries))
+	for _, e := range entries {
+		req, errs := e.Request()
+		problems = append(problems, errs...)
+		key := titleKey(req.Title)
+		if line, ok := seen[key]; ok && key != "" {
+			problems = append(problems, fmt.Sprintf("line %d: %q is already on line %d", e.Line, req.Title, line))
+		}
+		seen[key] = e.Line
+		planned = append(planned, Planned{Entry: e, Request: req, Existing: existing[key]})
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return planned, nil
+}
+
+// titleKey is the title compared when looking for existing exercises
+func titleKey(title string) string {
+	return strings.Join(strings.Fields(strings.ToLower(title)), " ")
+}
@@ -0,0 +1,186 @@
+package custom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/edit"
+	"github.com/obay/hevycli/internal/journal"
+)
+
+// Usage is where an exercise template is used
+type Usage struct {
+	Workouts []string `json:"workouts"`
+	Routines []string `json:"routines"`
+}
+
+// Empty reports whether the template is not used anywhere
+func (u Usage) Empty() bool {
+	return len(u.Workouts) == 0 && len(u.Routines) == 0
+}
+
+// FindUsage returns the IDs of the workouts and routines that include the
+// exercise template
+func FindUsage(templateID string, workouts []api.Workout, routines []api.Routine) Usage {
+	u := Usage{Workouts: []string{}, Routines: []string{}}
+	for _, w := range workouts {
+		if hasTemplate(w.Exercises, templateID) {
+			u.Workouts = append(u.Workouts, w.ID)
+		}
+	}
+	for _, r := range routines {
+		if hasTemplate(r.Exercises, templateID) {
+			u.Routines = append(u.Routines, r.ID)
+		}
+	}
+	return u
+}
+
+func hasTemplate(exercises []api.Exercise, templateID string) bool {
+	for _, ex := range exercises {
+		if ex.ExerciseTemplateID == templateID {
+			return true
+		}
+	}
+	return false
+}
+
+// MismatchError lists the sets that a remap would move onto an exercise
+// type that cannot record them
+type MismatchError struct {
+	To       api.ExerciseTemplate
+	Problems []string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("'%s' (%s) cannot hold %d set(s):\n  %s",
+		e.To.Title, e.To.Type.DisplayName(), len(e.Problems), strings.Join(e.Problems, "\n  "))
+}
+
+// CheckRemap reports, as a *MismatchError, every set of the workouts and
+// routines using template from that the exercise type of to cannot record,
+// such as a distance moved onto a weight and reps exercise.
+func CheckRemap(from string, to api.ExerciseTemplate, workouts []api.Workout, routines []api.Routine) error {
+	var problems []string
+	check := func(what string, exercises []api.Exercise) {
+		for _, ex := range exercises {
+			if ex.ExerciseTemplateID != from {
+				continue
+			}
+			for i, set := range ex.Sets {
+				if value := unfitValue(to.Type, set); value != "" {
+					problems = append(problems, fmt.Sprintf("%s: set %d has a %s", what, i+1, value))
+				}
+			}
+		}
+	}
+	for _, w := range workouts {
+		check(fmt.Sprintf("workout %q (%s)", w.Title, w.StartTime.Format("2006-01-02")), w.Exercises)
+	}
+	for _, r := range routines {
+		check(fmt.Sprintf("routine %q", r.Title), r.Exercises)
+	}
+	if len(problems) > 0 {
+		return &MismatchError{To: to, Problems: problems}
+	}
+	return nil
+}
+
+// unfitValue returns the first value of the set that exercise type t does
+// not record, or "" if the set fits
+func unfitValue(t api.ExerciseType, set api.Set) string {
+	var weight, reps, distance, duration bool
+	switch t {
+	case api.ExerciseTypeWeightReps, api.ExerciseTypeBodyweightReps, api.ExerciseTypeBodyweightAssisted:
+		weight, reps = true, true
+	case api.ExerciseTypeRepsOnly:
+		reps = true
+	case api.ExerciseTypeDuration:
+		duration = true
+	case api.ExerciseTypeWeightDuration:
+		weight, duration = true, true
+	case api.ExerciseTypeDistanceDuration:
+		distance, duration = true, true
+	case api.ExerciseTypeShortDistanceWeight:
+		weight, distance = true, true
+	default:
+		return ""
+	}
+	switch {
+	case set.WeightKg != nil && *set.WeightKg != 0 && !weight:
+		return "weight"
+	case set.Reps != nil && *set.Reps != 0 && !reps:
+		return "rep count"
+	case set.DistanceMeters != nil && *set.DistanceMeters != 0 && !distance:
+		return "distance"
+	case set.DurationSeconds != nil && *set.DurationSeconds != 0 && !duration:
+		return "duration"
+	}
+	return ""
+}
+
+// Remap replaces the exercise template from with to in every workout and
+// routine that uses it, recording each update in the undo journal. Nothing
+// is changed unless every set fits the exercise type of to (see CheckRemap).
+// The updated workouts and routines are changed in place, so later remaps
+// start from their new state. It returns where the template was replaced;
+// if an update fails the ones made so far are kept and returned, and can be
+// reverted with the journal.
+func Remap(client *api.Client, rec *journal.Recorder, from, to api.ExerciseTemplate, workouts []api.Workout, routines []api.Routine) (Usage, error) {
+	done := Usage{Workouts: []string{}, Routines: []string{}}
+	if err := CheckRemap(from.ID, to, workouts, routines); err != nil {
+		return done, err
+	}
+	for i := range workouts {
+		w := &workouts[i]
+		if !hasTemplate(w.Exercises, from.ID) {
+			continue
+		}
+		req := edit.FromWorkout(w).Request()
+		for j := range req.Workout.Exercises {
+			if req.Workout.Exercises[j].ExerciseTemplateID == from.ID {
+				req.Workout.Exercises[j].ExerciseTemplateID = to.ID
+			}
+		}
+		err := rec.Track(journal.ActionUpdate, w, func() error {
+			_, err := client.UpdateWorkout(w.ID, req)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to update workout %q: %w", w.Title, err)
+		}
+		replaceTemplate(w.Exercises, from.ID, to.ID)
+		done.Workouts = append(done.Workouts, w.ID)
+	}
+	for i := range routines {
+		r := &routines[i]
+		if !hasTemplate(r.Exercises, from.ID) {
+			continue
+		}
+		req := edit.FromRoutine(r).Request()
+		for j := range req.Routine.Exercises {
+			if req.Routine.Exercises[j].ExerciseTemplateID == from.ID {
+				req.Routine.Exercises[j].ExerciseTemplateID = to.ID
+			}
+		}
+		err := rec.Track(journal.ActionUpdate, r, func() error {
+			_, err := client.UpdateRoutine(r.ID, req)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to update routine %q: %w", r.Title, err)
+		}
+		replaceTemplate(r.Exercises, from.ID, to.ID)
+		done.Routines = append(done.Routines, r.ID)
+	}
+	return done, nil
+}
+
+func replaceTemplate(exercises []api.Exercise, from, to string) {
+	for i := range exercises {
+		if exercises[i].ExerciseTemplateID == from {
+			exercises[i].ExerciseTemplateID = to
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package edit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the document format shown in the editor
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// ErrEmpty is returned when the edited document is empty, which cancels the edit
+var ErrEmpty = errors.New("empty document")
+
+// errorMarker starts the lines added by Annotate
+const errorMarker = "ERROR:"
+
+// ParseFormat parses a --format value
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatYAML, "yml":
+		return FormatYAML, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("invalid format %q (use yaml or json)", s)
+	}
+}
+
+// Ext returns the file extension for the format
+func (f Format) Ext() string {
+	return "." + string(f)
+}
+
+// comment returns the line comment prefix of the format. JSON documents use
+// // comments, which are removed before parsing.
+func (f Format) comment() string {
+	if f == FormatJSON {
+		return "//"
+	}
+	return "#"
+}
+
+// Marshal encodes a document with header comment lines at the top and each
+// exercise template ID annotated with its title from titles
+func Marshal(doc any, f Format, titles map[string]string, header []string) ([]byte, error) {
+	var b bytes.Buffer
+	for _, line := range header {
+		fmt.Fprintf(&b, "%s %s\n", f.comment(), line)
+	}
+
+	if f == FormatJSON {
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode document: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if m := jsonTemplateLine.FindStringSubmatch(line); m != nil && titles[m[2]] != "" {
+				fmt.Fprintf(&b, "%s// %s\n", m[1], titles[m[2]])
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		return b.Bytes(), nil
+	}
+
+	var node yaml.Node
+	if err := node.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	annotateTemplates(&node, titles)
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+var jsonTemplateLine = regexp.MustCompile(`^(\s*)"exercise_template_id": "([^"]*)"`)
+
+// annotateTemplates adds the exercise title as a line comment to every
+// exercise_template_id value
+func annotateTemplates(n *yaml.Node, titles map[string]string) {
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if key.Value == "exercise_template_id" && titles[value.Value] != "" {
+				value.LineComment = titles[value.Value]
+			}
+		}
+	}
+	for _, child := range n.Content {
+		annotateTemplates(child, titles)
+	}
+}
+
+// Unmarshal decodes an edited document, rejecting unknown fields.
+// A document with only comments and whitespace returns ErrEmpty.
+func Unmarshal(data []byte, f Format, v any) error {
+	text := stripComments(string(data), f)
+	if strings.TrimSpace(text) == "" {
+		return ErrEmpty
+	}
+
+	if f == FormatJSON {
+		dec := json.NewDecoder(strings.NewReader(text))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(v); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		return nil
+	}
+
+	dec := yaml.NewDecoder(strings.NewReader(text))
+	dec.KnownFields(true)
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrEmpty
+		}
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	return nil
+}
+
+// stripComments removes whole-line comments from JSON documents. Lines are
+// blanked rather than removed so parser line numbers still match the editor.
+// YAML comments are handled by the YAML parser.
+func stripComments(text string, f Format) string {
+	if f != FormatJSON {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Annotate returns text with problems listed in comments at the top,
+// replacing the problems added by an earlier call
+func Annotate(text string, f Format, problems []string) string {
+	prefix := f.comment() + " " + errorMarker
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(line, prefix) {
+			kept = append(kept, line)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s The document was not saved:\n", prefix)
+	for _, p := range problems {
+		fmt.Fprintf(&b, "%s   %s\n", prefix, p)
+	}
+	fmt.Fprintf(&b, "%s Fix the problems and save again, or save an empty file to cancel.\n", prefix)
+	b.WriteString(strings.Join(kept, "\n"))
+	return b.String()
+}
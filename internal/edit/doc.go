@@ -0,0 +1,277 @@
+// Package edit converts workouts and routines into documents that can be
+// edited in a text editor and converts the edited documents back into
+// update requests.
+package edit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// WorkoutDoc is the editable form of a workout
+type WorkoutDoc struct {
+	Title       string        `json:"title" yaml:"title"`
+	Description string        `json:"description" yaml:"description"`
+	StartTime   string        `json:"start_time" yaml:"start_time"`
+	EndTime     string        `json:"end_time" yaml:"end_time"`
+	Exercises   []ExerciseDoc `json:"exercises" yaml:"exercises"`
+}
+
+// RoutineDoc is the editable form of a routine
+type RoutineDoc struct {
+	Title     string        `json:"title" yaml:"title"`
+	Exercises []ExerciseDoc `json:"exercises" yaml:"exercises"`
+}
+
+// ExerciseDoc is an editable exercise. RestSeconds only applies to routines.
+type ExerciseDoc struct {
+	ExerciseTemplateID string   `json:"exercise_template_id" yaml:"exercise_template_id"`
+	SupersetID         *int     `json:"superset_id,omitempty" yaml:"superset_id,omitempty"`
+	RestSeconds        *int     `json:"rest_seconds,omitempty" yaml:"rest_seconds,omitempty"`
+	Notes              string   `json:"notes,omitempty" yaml:"notes,omitempty"`
+	Sets               []SetDoc `json:"sets" yaml:"sets"`
+}
+
+// SetDoc is an editable set. RPE only applies to workouts and RepRange to routines.
+type SetDoc struct {
+	Type            api.SetType   `json:"type" yaml:"type"`
+	WeightKg        *float64      `json:"weight_kg,omitempty" yaml:"weight_kg,omitempty"`
+	Reps            *int          `json:"reps,omitempty" yaml:"reps,omitempty"`
+	DistanceMeters  *int          `json:"distance_meters,omitempty" yaml:"distance_meters,omitempty"`
+	DurationSeconds *int          `json:"duration_seconds,omitempty" yaml:"duration_seconds,omitempty"`
+	RPE             *float64      `json:"rpe,omitempty" yaml:"rpe,omitempty"`
+	RepRange        *api.RepRange `json:"rep_range,omitempty" yaml:"rep_range,omitempty"`
+}
+
+// FromWorkout converts a workout into an editable document
+func FromWorkout(w *api.Workout) *WorkoutDoc {
+	return &WorkoutDoc{
+		Title:       w.Title,
+		Description: w.Description,
+		StartTime:   w.StartTime.Format(time.RFC3339),
+		EndTime:     w.EndTime.Format(time.RFC3339),
+		Exercises:   fromExercises(w.Exercises, false),
+	}
+}
+
+// FromRoutine converts a routine into an editable document
+func FromRoutine(r *api.Routine) *RoutineDoc {
+	return &RoutineDoc{
+		Title:     r.Title,
+		Exercises: fromExercises(r.Exercises, true),
+	}
+}
+
+func fromExercises(exercises []api.Exercise, routine bool) []ExerciseDoc {
+	docs := make([]ExerciseDoc, len(exercises))
+	for i, ex := range exercises {
+		doc := ExerciseDoc{
+			ExerciseTemplateID: ex.ExerciseTemplateID,
+			SupersetID:         ex.SupersetID,
+			Notes:              ex.Notes,
+			Sets:               make([]SetDoc, len(ex.Sets)),
+		}
+		if routine {
+			doc.RestSeconds = ex.RestSeconds
+		}
+		for j, s := range ex.Sets {
+			set := SetDoc{
+				Type:            s.SetType,
+				WeightKg:        s.WeightKg,
+				Reps:            s.Reps,
+				DurationSeconds: s.DurationSeconds,
+			}
+			if s.DistanceMeters != nil {
+				meters := int(*s.DistanceMeters + 0.5)
+				set.DistanceMeters = &meters
+			}
+			if routine {
+				set.RepRange = s.RepRange
+			} else {
+				set.RPE = s.RPE
+			}
+			doc.Sets[j] = set
+		}
+		docs[i] = doc
+	}
+	return docs
+}
+
+// TemplateIDs returns the exercise template IDs used by the workout
+func (d *WorkoutDoc) TemplateIDs() []string {
+	return templateIDs(d.Exercises)
+}
+
+// TemplateIDs returns the exercise template IDs used by the routine
+func (d *RoutineDoc) TemplateIDs() []string {
+	return templateIDs(d.Exercises)
+}
+
+func templateIDs(exercises []ExerciseDoc) []string {
+	ids := make([]string, len(exercises))
+	for i, ex := range exercises {
+		ids[i] = ex.ExerciseTemplateID
+	}
+	return ids
+}
+
+// Validate checks the document and returns every problem found
+func (d *WorkoutDoc) Validate() []string {
+	var problems []string
+	if strings.TrimSpace(d.Title) == "" {
+		problems = append(problems, "title: must not be empty")
+	}
+	start, err := time.Parse(time.RFC3339, d.StartTime)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("start_time: %q is not an RFC 3339 time", d.StartTime))
+	}
+	end, err2 := time.Parse(time.RFC3339, d.EndTime)
+	if err2 != nil {
+		problems = append(problems, fmt.Sprintf("end_time: %q is not an RFC 3339 time", d.EndTime))
+	}
+	if err == nil && err2 == nil && !end.After(start) {
+		problems = append(problems, "end_time: must be after start_time")
+	}
+	return append(problems, validateExercises(d.Exercises, false)...)
+}
+
+// Validate checks the document and returns every problem found
+func (d *RoutineDoc) Validate() []string {
+	var problems []string
+	if strings.TrimSpace(d.Title) == "" {
+		problems = append(problems, "title: must not be empty")
+	}
+	return append(problems, validateExercises(d.Exercises, true)...)
+}
+
+var setTypes = map[api.SetType]bool{
+	api.SetTypeNormal:  true,
+	api.SetTypeWarmup:  true,
+	api.SetTypeDropset: true,
+	api.SetTypeFailure: true,
+}
+
+func validateExercises(exercises []ExerciseDoc, routine bool) []string {
+	var problems []string
+	add := func(path, format string, args ...any) {
+		problems = append(problems, path+": "+fmt.Sprintf(format, args...))
+	}
+	if len(exercises) == 0 {
+		add("exercises", "at least one exercise is required")
+	}
+	for i, ex := range exercises {
+		path := fmt.Sprintf("exercises[%d]", i)
+		if strings.TrimSpace(ex.ExerciseTemplateID) == "" {
+			add(path+".exercise_template_id", "must not be empty")
+		}
+		if ex.RestSeconds != nil && !routine {
+			add(path+".rest_seconds", "only applies to routines")
+		}
+		if ex.RestSeconds != nil && *ex.RestSeconds < 0 {
+			add(path+".rest_seconds", "must not be negative")
+		}
+		if len(ex.Sets) == 0 {
+			add(path+".sets", "at least one set is required")
+		}
+		for j, s := range ex.Sets {
+			setPath := fmt.Sprintf("%s.sets[%d]", path, j)
+			if !setTypes[s.Type] {
+				add(setPath+".type", "%q is not one of normal, warmup, dropset, failure", s.Type)
+			}
+			if s.WeightKg != nil && *s.WeightKg < 0 {
+				add(setPath+".weight_kg", "must not be negative")
+			}
+			if s.Reps != nil && *s.Reps < 0 {
+				add(setPath+".reps", "must not be negative")
+			}
+			if s.DistanceMeters != nil && *s.DistanceMeters < 0 {
+				add(setPath+".distance_meters", "must not be negative")
+			}
+			if s.DurationSeconds != nil && *s.DurationSeconds < 0 {
+				add(setPath+".duration_seconds", "must not be negative")
+			}
+			if s.RPE != nil {
+				if routine {
+					add(setPath+".rpe", "only applies to workouts")
+				} else if err := api.CheckRPE(*s.RPE); err != nil {
+					add(setPath+".rpe", "%v", err)
+				}
+			}
+			if s.RepRange != nil && !routine {
+				add(setPath+".rep_range", "only applies to routines")
+			}
+		}
+	}
+	return problems
+}
+
+// Request converts the document into an update request
+func (d *WorkoutDoc) Request() *api.UpdateWorkoutRequest {
+	description := d.Description
+	exercises := make([]api.CreateWorkoutExercise, len(d.Exercises))
+	for i, ex := range d.Exercises {
+		out := api.CreateWorkoutExercise{
+			ExerciseTemplateID: ex.ExerciseTemplateID,
+			SupersetID:         ex.SupersetID,
+			Notes:              optionalString(ex.Notes),
+			Sets:               make([]api.CreateWorkoutSet, len(ex.Sets)),
+		}
+		for j, s := range ex.Sets {
+			out.Sets[j] = api.CreateWorkoutSet{
+				Type:            s.Type,
+				WeightKg:        s.WeightKg,
+				Reps:            s.Reps,
+				DistanceMeters:  s.DistanceMeters,
+				DurationSeconds: s.DurationSeconds,
+				RPE:             s.RPE,
+			}
+		}
+		exercises[i] = out
+	}
+	return &api.UpdateWorkoutRequest{Workout: api.UpdateWorkoutData{
+		Title:       d.Title,
+		Description: &description,
+		StartTime:   d.StartTime,
+		EndTime:     d.EndTime,
+		Exercises:   exercises,
+	}}
+}
+
+// Request converts the document into an update request
+func (d *RoutineDoc) Request() *api.UpdateRoutineRequest {
+	exercises := make([]api.CreateRoutineExercise, len(d.Exercises))
+	for i, ex := range d.Exercises {
+		out := api.CreateRoutineExercise{
+			ExerciseTemplateID: ex.ExerciseTemplateID,
+			SupersetID:         ex.SupersetID,
+			RestSeconds:        ex.RestSeconds,
+			Notes:              optionalString(ex.Notes),
+			Sets:               make([]api.CreateRoutineSet, len(ex.Sets)),
+		}
+		for j, s := range ex.Sets {
+			out.Sets[j] = api.CreateRoutineSet{
+				Type:            s.Type,
+				WeightKg:        s.WeightKg,
+				Reps:            s.Reps,
+				DistanceMeters:  s.DistanceMeters,
+				DurationSeconds: s.DurationSeconds,
+				RepRange:        s.RepRange,
+			}
+		}
+		exercises[i] = out
+	}
+	return &api.UpdateRoutineRequest{Routine: api.UpdateRoutineData{
+		Title:     d.Title,
+		Exercises: exercises,
+	}}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
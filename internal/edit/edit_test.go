@@ -0,0 +1,232 @@
+package edit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+func intPtr(v int) *int           { return &v }
+
+func testWorkout() *api.Workout {
+	start := time.Date(2026, 10, 18, 17, 0, 0, 0, time.UTC)
+	return &api.Workout{
+		ID: "w1", Title: "Push", Description: "good day",
+		StartTime: start, EndTime: start.Add(time.Hour),
+		Exercises: []api.Exercise{
+			{Title: "Bench Press (Barbell)", ExerciseTemplateID: "BB", Notes: "paused", Sets: []api.Set{
+				{SetType: api.SetTypeWarmup, WeightKg: floatPtr(60), Reps: intPtr(10)},
+				{SetType: api.SetTypeNormal, WeightKg: floatPtr(100), Reps: intPtr(8), RPE: floatPtr(8)},
+			}},
+		},
+	}
+}
+
+func TestMarshal_YAMLComments(t *testing.T) {
+	doc := FromWorkout(testWorkout())
+	data, err := Marshal(doc, FormatYAML, map[string]string{"BB": "Bench Press (Barbell)"}, []string{"Editing workout w1"})
+	require.NoError(t, err)
+	text := string(data)
+	assert.True(t, strings.HasPrefix(text, "# Editing workout w1\n"))
+	assert.Contains(t, text, "exercise_template_id: BB # Bench Press (Barbell)")
+	assert.Contains(t, text, "start_time: \"2026-10-18T17:00:00Z\"")
+
+	var back WorkoutDoc
+	require.NoError(t, Unmarshal(data, FormatYAML, &back))
+	assert.Equal(t, doc, &back)
+}
+
+func TestMarshal_JSONComments(t *testing.T) {
+	doc := FromWorkout(testWorkout())
+	data, err := Marshal(doc, FormatJSON, map[string]string{"BB": "Bench Press (Barbell)"}, []string{"header"})
+	require.NoError(t, err)
+	text := string(data)
+	assert.True(t, strings.HasPrefix(text, "// header\n"))
+	assert.Contains(t, text, "      // Bench Press (Barbell)\n      \"exercise_template_id\": \"BB\"")
+
+	var back WorkoutDoc
+	require.NoError(t, Unmarshal(data, FormatJSON, &back))
+	assert.Equal(t, doc, &back)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	var doc WorkoutDoc
+	assert.ErrorIs(t, Unmarshal([]byte("# only comments\n\n"), FormatYAML, &doc), ErrEmpty)
+	assert.ErrorIs(t, Unmarshal([]byte("// only comments\n"), FormatJSON, &doc), ErrEmpty)
+
+	err := Unmarshal([]byte("title: x\ncolour: red\n"), FormatYAML, &doc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field colour not found")
+
+	err = Unmarshal([]byte(`{"title": "x", "colour": "red"}`), FormatJSON, &doc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "colour"`)
+}
+
+func TestValidate(t *testing.T) {
+	doc := FromWorkout(testWorkout())
+	assert.Empty(t, doc.Validate())
+
+	doc.Title = " "
+	doc.EndTime = doc.StartTime
+	doc.Exercises[0].RestSeconds = intPtr(90)
+	doc.Exercises[0].Sets[0].Type = "heavy"
+	doc.Exercises[0].Sets[1].RPE = floatPtr(8.3)
+	assert.Equal(t, []string{
+		"title: must not be empty",
+		"end_time: must be after start_time",
+		"exercises[0].rest_seconds: only applies to routines",
+		`exercises[0].sets[0].type: "heavy" is not one of normal, warmup, dropset, failure`,
+		"exercises[0].sets[1].rpe: must be one of 6, 7, 7.5, 8, 8.5, 9, 9.5, 10",
+	}, doc.Validate())
+
+	routine := &RoutineDoc{Title: "R", Exercises: []ExerciseDoc{{ExerciseTemplateID: "BB", Sets: []SetDoc{{Type: api.SetTypeNormal, RPE: floatPtr(8)}}}}}
+	assert.Equal(t, []string{"exercises[0].sets[0].rpe: only applies to workouts"}, routine.Validate())
+}
+
+func TestRoutineRoundTrip(t *testing.T) {
+	routine := &api.Routine{ID: "r1", Title: "Legs", Exercises: []api.Exercise{
+		{Title: "Squat", ExerciseTemplateID: "SQ", RestSeconds: intPtr(120), Sets: []api.Set{
+			{SetType: api.SetTypeNormal, WeightKg: floatPtr(100), RepRange: &api.RepRange{Start: intPtr(5), End: intPtr(8)}},
+		}},
+	}}
+	req := FromRoutine(routine).Request()
+	require.Len(t, req.Routine.Exercises, 1)
+	ex := req.Routine.Exercises[0]
+	assert.Equal(t, 120, *ex.RestSeconds)
+	assert.Nil(t, ex.Notes)
+	assert.Equal(t, 5, *ex.Sets[0].RepRange.Start)
+}
+
+func TestAnnotate(t *testing.T) {
+	text := "title: x\n"
+	once := Annotate(text, FormatYAML, []string{"problem one"})
+	assert.Equal(t, "# ERROR: The document was not saved:\n# ERROR:   problem one\n# ERROR: Fix the problems and save again, or save an empty file to cancel.\ntitle: x\n", once)
+
+	twice := Annotate(once, FormatYAML, []string{"problem two"})
+	assert.NotContains(t, twice, "problem one")
+	assert.Contains(t, twice, "problem two")
+	assert.True(t, strings.HasSuffix(twice, "title: x\n"))
+}
+
+func TestDiff(t *testing.T) {
+	assert.Empty(t, Diff("a\nb\n", "a\nb\n"))
+
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n"
+	b := "1\n2\n3\n4\nfive\n6\n7\n8\n"
+	assert.Equal(t, "@@ line 3 @@\n  3\n  4\n- 5\n+ five\n  6\n  7\n", Diff(a, b))
+
+	assert.Equal(t, "@@ line 1 @@\n  a\n+ b\n", Diff("a\n", "a\nb\n"))
+}
+
+// fakeEditor returns a Session.Edit function that applies each edit in turn
+func fakeEditor(t *testing.T, edits ...func(string) string) (func(string) (string, error), *[]string) {
+	var seen []string
+	return func(text string) (string, error) {
+		seen = append(seen, text)
+		require.Less(t, len(seen)-1, len(edits), "editor opened too many times")
+		return edits[len(seen)-1](text), nil
+	}, &seen
+}
+
+func newSession(editFn func(string) (string, error), saved **WorkoutDoc) *Session[*WorkoutDoc] {
+	return &Session[*WorkoutDoc]{
+		Format: FormatYAML,
+		Titles: map[string]string{"BB": "Bench Press (Barbell)"},
+		Edit:   editFn,
+		New:    func() *WorkoutDoc { return &WorkoutDoc{} },
+		Lookup: func(id string) (string, error) {
+			if id == "DB" {
+				return "Bench Press (Dumbbell)", nil
+			}
+			return "", api.ErrNotFound
+		},
+		Confirm: func(diff string) (bool, error) { return true, nil },
+		Save: func(doc *WorkoutDoc) error {
+			*saved = doc
+			return nil
+		},
+	}
+}
+
+func TestSession_SaveAfterFixingErrors(t *testing.T) {
+	editFn, seen := fakeEditor(t,
+		func(s string) string {
+			return strings.Replace(s, "exercise_template_id: BB", "exercise_template_id: XX", 1)
+		},
+		func(s string) string {
+			return strings.Replace(s, "exercise_template_id: XX", "exercise_template_id: DB", 1)
+		},
+	)
+	var saved *WorkoutDoc
+	session := newSession(editFn, &saved)
+
+	var diff string
+	session.Confirm = func(d string) (bool, error) {
+		diff = d
+		return true, nil
+	}
+
+	result, err := session.Run(FromWorkout(testWorkout()))
+	require.NoError(t, err)
+	assert.Equal(t, Saved, result)
+	require.Len(t, *seen, 2)
+	assert.Contains(t, (*seen)[1], `# ERROR:   exercises[0].exercise_template_id: unknown exercise template "XX"`)
+	require.NotNil(t, saved)
+	assert.Equal(t, "DB", saved.Exercises[0].ExerciseTemplateID)
+	assert.Contains(t, diff, "-   - exercise_template_id: BB # Bench Press (Barbell)")
+	assert.Contains(t, diff, "+   - exercise_template_id: DB # Bench Press (Dumbbell)")
+}
+
+func TestSession_Outcomes(t *testing.T) {
+	var saved *WorkoutDoc
+
+	editFn, _ := fakeEditor(t, func(s string) string { return s })
+	result, err := newSession(editFn, &saved).Run(FromWorkout(testWorkout()))
+	require.NoError(t, err)
+	assert.Equal(t, Unchanged, result)
+
+	editFn, _ = fakeEditor(t, func(string) string { return "" })
+	result, err = newSession(editFn, &saved).Run(FromWorkout(testWorkout()))
+	require.NoError(t, err)
+	assert.Equal(t, Cancelled, result)
+
+	// Closing an annotated document without changes gives up
+	editFn, _ = fakeEditor(t,
+		func(s string) string { return strings.Replace(s, "title: Push", "title: \"\"", 1) },
+		func(s string) string { return s },
+	)
+	_, err = newSession(editFn, &saved).Run(FromWorkout(testWorkout()))
+	assert.EqualError(t, err, "edit aborted: the document still has errors")
+	assert.Nil(t, saved)
+}
+
+func TestSession_APIValidationReopens(t *testing.T) {
+	editFn, seen := fakeEditor(t,
+		func(s string) string { return strings.Replace(s, "title: Push", "title: Push Day", 1) },
+		func(s string) string { return strings.Replace(s, "title: Push Day", "title: Push 2", 1) },
+	)
+	var saved *WorkoutDoc
+	session := newSession(editFn, &saved)
+	calls := 0
+	session.Save = func(doc *WorkoutDoc) error {
+		calls++
+		if calls == 1 {
+			return &api.APIError{ErrorCode: "VALIDATION_ERROR", ErrorMessage: "invalid", Fields: []api.FieldError{{Field: "workout.title", Message: "too long"}}}
+		}
+		saved = doc
+		return nil
+	}
+
+	result, err := session.Run(FromWorkout(testWorkout()))
+	require.NoError(t, err)
+	assert.Equal(t, Saved, result)
+	assert.Contains(t, (*seen)[1], "# ERROR:   workout.title: too long")
+	assert.Equal(t, "Push 2", saved.Title)
+}
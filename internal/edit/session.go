@@ -0,0 +1,188 @@
+package edit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// Document is an editable workout or routine
+type Document interface {
+	Validate() []string
+	TemplateIDs() []string
+}
+
+// Result is the outcome of an edit session
+type Result int
+
+const (
+	// Saved means the edited document was submitted
+	Saved Result = iota
+	// Unchanged means the edited document matches the original
+	Unchanged
+	// Cancelled means the user emptied the document or declined to save
+	Cancelled
+)
+
+// Session edits a document until it is saved, left unchanged or cancelled.
+// Invalid documents, including ones the API rejects as invalid, are opened
+// again with the problems listed at the top.
+type Session[T Document] struct {
+	Format Format
+	// Header lines are shown as comments at the top of the document
+	Header []string
+	// Titles maps exercise template IDs to titles shown as comments
+	Titles map[string]string
+
+	// Edit opens text in an editor and returns the saved text
+	Edit func(text string) (string, error)
+	// New returns an empty document to decode the edited text into
+	New func() T
+	// Lookup returns the title of a template ID missing from Titles, or an
+	// error if the template does not exist
+	Lookup func(id string) (string, error)
+	// Confirm shows the diff against the original and reports whether to save
+	Confirm func(diff string) (bool, error)
+	// Save submits the edited document
+	Save func(doc T) error
+}
+
+// Run edits the original document
+func (s *Session[T]) Run(original T) (Result, error) {
+	if s.Titles == nil {
+		s.Titles = make(map[string]string)
+	}
+	before, err := Marshal(original, s.Format, s.Titles, nil)
+	if err != nil {
+		return Cancelled, err
+	}
+	text, err := Marshal(original, s.Format, s.Titles, s.Header)
+	if err != nil {
+		return Cancelled, err
+	}
+
+	annotated := false
+	for {
+		edited, err := s.Edit(string(text))
+		if err != nil {
+			return Cancelled, err
+		}
+		if annotated && edited == string(text) {
+			return Cancelled, fmt.Errorf("edit aborted: the document still has errors")
+		}
+
+		doc := s.New()
+		problems, err := s.check([]byte(edited), doc)
+		if errors.Is(err, ErrEmpty) {
+			return Cancelled, nil
+		}
+		if err != nil {
+			return Cancelled, err
+		}
+		if len(problems) > 0 {
+			text = []byte(Annotate(edited, s.Format, problems))
+			annotated = true
+			continue
+		}
+
+		after, err := Marshal(doc, s.Format, s.Titles, nil)
+		if err != nil {
+			return Cancelled, err
+		}
+		diff := Diff(string(before), string(after))
+		if diff == "" {
+			return Unchanged, nil
+		}
+		if ok, err := s.Confirm(diff); err != nil || !ok {
+			return Cancelled, err
+		}
+
+		err = s.Save(doc)
+		if apiErr, ok := api.AsAPIError(err); ok && apiErr.ErrorCode == "VALIDATION_ERROR" {
+			text = []byte(Annotate(edited, s.Format, apiProblems(apiErr)))
+			annotated = true
+			continue
+		}
+		if err != nil {
+			return Cancelled, err
+		}
+		return Saved, nil
+	}
+}
+
+// check decodes and validates edited text. Decoding problems are returned as
+// problems so the document can be fixed; only ErrEmpty and lookup failures
+// unrelated to the document are returned as errors.
+func (s *Session[T]) check(data []byte, doc T) ([]string, error) {
+	if err := Unmarshal(data, s.Format, doc); err != nil {
+		if errors.Is(err, ErrEmpty) {
+			return nil, err
+		}
+		return []string{err.Error()}, nil
+	}
+
+	problems := doc.Validate()
+	for i, id := range doc.TemplateIDs() {
+		if id == "" || s.Titles[id] != "" {
+			continue
+		}
+		title, err := s.Lookup(id)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				problems = append(problems, fmt.Sprintf("exercises[%d].exercise_template_id: unknown exercise template %q", i, id))
+				continue
+			}
+			return nil, err
+		}
+		s.Titles[id] = title
+	}
+	return problems, nil
+}
+
+// apiProblems lists the problems reported in an API validation error
+func apiProblems(err *api.APIError) []string {
+	if len(err.Fields) == 0 {
+		return []string{"rejected by the API: " + strings.TrimSpace(err.ErrorMessage)}
+	}
+	problems := make([]string, len(err.Fields))
+	for i, f := range err.Fields {
+		problems[i] = f.Field + ": " + f.Message
+	}
+	return problems
+}
+
+// Titles maps the template IDs of exercises to their titles
+func Titles(exercises []api.Exercise) map[string]string {
+	titles := make(map[string]string, len(exercises))
+	for _, ex := range exercises {
+		titles[ex.ExerciseTemplateID] = ex.Title
+	}
+	return titles
+}
+
+// TemplateLookup returns a Session.Lookup function that fetches exercise
+// templates from the API
+func TemplateLookup(client *api.Client) func(id string) (string, error) {
+	return func(id string) (string, error) {
+		t, err := client.GetExerciseTemplate(id)
+		if err != nil {
+			return "", err
+		}
+		return t.Title, nil
+	}
+}
+
+// ConfirmDiff prints the diff to stderr and asks on stdin whether to save
+func ConfirmDiff(diff string) (bool, error) {
+	fmt.Fprintln(os.Stderr, "Changes:")
+	fmt.Fprint(os.Stderr, diff)
+	fmt.Fprint(os.Stderr, "Save these changes? [y/N]: ")
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "y" || answer == "yes", nil
+}
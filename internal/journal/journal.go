@@ -0,0 +1,375 @@
+// Package journal keeps a local record of destructive and mutating commands.
+// The full object is saved before it is deleted or updated so the change can
+// be reverted later with 'hevycli undo'.
+package journal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// MaxOperations is the number of operations kept; older ones are removed
+const MaxOperations = 500
+
+// ErrNotFound is returned when no operation matches an ID
+var ErrNotFound = errors.New("operation not found")
+
+// Kind is the type of object changed
+type Kind string
+
+const (
+	KindWorkout Kind = "workout"
+	KindRoutine Kind = "routine"
+	KindFolder  Kind = "folder"
+)
+
+// Action is what was done to an object
+type Action string
+
+const (
+	ActionDelete Action = "delete"
+	ActionUpdate Action = "update"
+	// ActionCreate records an object created in place of another, such as a
+	// routine copied into a folder; undoing it deletes the object
+	ActionCreate Action = "create"
+)
+
+// Change is one object created, deleted or updated by an operation
+type Change struct {
+	Kind   Kind   `json:"kind"`
+	Action Action `json:"action"`
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	// Before is the object as it was before the change, or as it was
+	// created for ActionCreate
+	Before json.RawMessage `json:"before"`
+
+	// UndoneAt is set once the change has been reverted
+	UndoneAt *time.Time `json:"undone_at,omitempty"`
+	// RestoredID is the ID of the object recreated by reverting a delete
+	RestoredID string `json:"restored_id,omitempty"`
+}
+
+// Operation is a command that changed one or more objects
+type Operation struct {
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	Changes []Change  `json:"changes"`
+	// Reverts is the ID of the operation undone by this one, if any
+	Reverts string `json:"reverts,omitempty"`
+}
+
+// Undone reports whether every change of the operation has been reverted
+func (o *Operation) Undone() bool {
+	for _, c := range o.Changes {
+		if c.UndoneAt == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Snapshot returns a change recording v, a workout, routine or folder, before
+// the action is applied to it
+func Snapshot(action Action, v any) (Change, error) {
+	var c Change
+	switch o := v.(type) {
+	case *api.Workout:
+		c = Change{Kind: KindWorkout, ID: o.ID, Title: o.Title}
+	case *api.Routine:
+		c = Change{Kind: KindRoutine, ID: o.ID, Title: o.Title}
+	case *api.RoutineFolder:
+		c = Change{Kind: KindFolder, ID: o.ID, Title: o.Title}
+	default:
+		return Change{}, fmt.Errorf("cannot journal %T", v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to snapshot %s %s: %w", c.Kind, c.ID, err)
+	}
+	c.Action = action
+	c.Before = data
+	return c, nil
+}
+
+// Journal stores operations as JSON files in a directory
+type Journal struct {
+	dir string
+}
+
+// Open returns the journal stored in dir. The directory is created when the
+// first operation is saved.
+func Open(dir string) *Journal {
+	return &Journal{dir: dir}
+}
+
+// Begin starts recording an operation for a command
+func (j *Journal) Begin(command string) *Recorder {
+	return &Recorder{j: j, op: Operation{ID: newID(time.Now()), Time: time.Now(), Command: command}}
+}
+
+// List returns the recorded operations, newest first
+func (j *Journal) List() ([]*Operation, error) {
+	entries, err := os.ReadDir(j.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var ops []*Operation
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		op, err := j.load(filepath.Join(j.dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(a, b int) bool { return ops[a].Time.After(ops[b].Time) })
+	return ops, nil
+}
+
+// Get returns the operation with the given ID or unique ID prefix
+func (j *Journal) Get(id string) (*Operation, error) {
+	ops, err := j.List()
+	if err != nil {
+		return nil, err
+	}
+	var found *Operation
+	for _, op := range ops {
+		if op.ID == id {
+			return op, nil
+		}
+		if strings.HasPrefix(op.ID, id) {
+			if found != nil {
+				return nil, fmt.Errorf("operation ID %q is ambiguous", id)
+			}
+			found = op
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return found, nil
+}
+
+// Latest returns the newest operation that has not been undone, skipping
+// operations recorded by undo itself so repeated undos go further back
+func (j *Journal) Latest() (*Operation, error) {
+	ops, err := j.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if !op.Undone() && op.Reverts == "" {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: nothing to undo", ErrNotFound)
+}
+
+// Save writes an operation, replacing any earlier version of it
+func (j *Journal) Save(op *Operation) error {
+	if err := os.MkdirAll(j.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	data, err := json.MarshalIndent(op, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode operation: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial entry
+	path := j.path(op.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// remove deletes an operation
+func (j *Journal) remove(id string) error {
+	err := os.Remove(j.path(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to update journal: %w", err)
+	}
+	return nil
+}
+
+// prune removes the oldest operations beyond MaxOperations
+func (j *Journal) prune() error {
+	ops, err := j.List()
+	if err != nil {
+		return err
+	}
+	for i := MaxOperations; i < len(ops); i++ {
+		if err := j.remove(ops[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *Journal) path(id string) string {
+	return filepath.Join(j.dir, id+".json")
+}
+
+func (j *Journal) load(path string) (*Operation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	var op Operation
+	if err := json.Unmarshal(data, &op); err != nil {
+		return nil, fmt.Errorf("failed to parse journal entry %s: %w", filepath.Base(path), err)
+	}
+	return &op, nil
+}
+
+// newID returns a sortable operation ID such as "20261018-170102-3f9a"
+func newID(t time.Time) string {
+	b := make([]byte, 2)
+	rand.Read(b)
+	return t.Format("20060102-150405") + "-" + hex.EncodeToString(b)
+}
+
+// Recorder adds the changes made by one command to its operation. It is safe
+// for concurrent use.
+type Recorder struct {
+	j     *Journal
+	mu    sync.Mutex
+	op    Operation
+	saved bool
+}
+
+// ID returns the ID of the operation being recorded
+func (r *Recorder) ID() string {
+	return r.op.ID
+}
+
+// Record saves a change before it is made
+func (r *Recorder) Record(c Change) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.op.Changes = append(r.op.Changes, c)
+	if err := r.j.Save(&r.op); err != nil {
+		r.op.Changes = r.op.Changes[:len(r.op.Changes)-1]
+		return err
+	}
+	if !r.saved {
+		r.saved = true
+		return r.j.prune()
+	}
+	return nil
+}
+
+// Forget removes a recorded change that was not made after all. When the
+// same object was recorded several times the latest matching change goes,
+// so earlier changes to it can still be undone.
+func (r *Recorder) Forget(c Change) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.op.Changes) - 1; i >= 0; i-- {
+		existing := r.op.Changes[i]
+		if existing.Kind == c.Kind && existing.Action == c.Action && existing.ID == c.ID {
+			r.op.Changes = append(r.op.Changes[:i], r.op.Changes[i+1:]...)
+			break
+		}
+	}
+	if len(r.op.Changes) == 0 {
+		r.saved = false
+		return r.j.remove(r.op.ID)
+	}
+	return r.j.Save(&r.op)
+}
+
+// Track records the object v, runs mutate and forgets the change again if
+// mutate fails. The mutation is not attempted when the journal cannot be
+// written.
+func (r *Recorder) Track(action Action, v any, mutate func() error) error {
+	c, err := Snapshot(action, v)
+	if err != nil {
+		return err
+	}
+	if err := r.Record(c); err != nil {
+		return fmt.Errorf("failed to record undo journal: %w", err)
+	}
+	if err := mutate(); err != nil {
+		r.Forget(c)
+		return err
+	}
+	return nil
+}
+
+// Summary describes the changes of the operation, e.g. "delete 3 workouts"
+// or `update routine "Push"`
+func (o *Operation) Summary() string {
+	if len(o.Changes) == 1 {
+		c := o.Changes[0]
+		return fmt.Sprintf("%s %s %q", c.Action, c.Kind, c.Title)
+	}
+
+	// Count changes per action and kind, keeping the order they were made
+	type key struct {
+		action Action
+		kind   Kind
+	}
+	counts := make(map[key]int)
+	var order []key
+	for _, c := range o.Changes {
+		k := key{c.Action, c.Kind}
+		if counts[k] == 0 {
+			order = append(order, k)
+		}
+		counts[k]++
+	}
+	parts := make([]string, len(order))
+	for i, k := range order {
+		noun := string(k.kind)
+		if counts[k] != 1 {
+			noun += "s"
+		}
+		parts[i] = fmt.Sprintf("%s %d %s", k.action, counts[k], noun)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Status is "undone" when every change has been reverted, "partly undone"
+// when some have, and "" otherwise
+func (o *Operation) Status() string {
+	undone := 0
+	for _, c := range o.Changes {
+		if c.UndoneAt != nil {
+			undone++
+		}
+	}
+	switch {
+	case undone == 0:
+		return ""
+	case undone == len(o.Changes):
+		return "undone"
+	}
+	return "partly undone"
+}
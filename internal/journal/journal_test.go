@@ -0,0 +1,144 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func TestSnapshot(t *testing.T) {
+	w := &api.Workout{ID: "w1", Title: "Push"}
+	c, err := Snapshot(ActionDelete, w)
+	require.NoError(t, err)
+	assert.Equal(t, KindWorkout, c.Kind)
+	assert.Equal(t, ActionDelete, c.Action)
+	assert.Equal(t, "w1", c.ID)
+	assert.Equal(t, "Push", c.Title)
+
+	var back api.Workout
+	require.NoError(t, json.Unmarshal(c.Before, &back))
+	assert.Equal(t, *w, back)
+
+	_, err = Snapshot(ActionDelete, "not an object")
+	assert.Error(t, err)
+}
+
+func TestRecorder_TrackAndForget(t *testing.T) {
+	j := Open(t.TempDir())
+	rec := j.Begin("hevycli workout delete")
+
+	require.NoError(t, rec.Track(ActionDelete, &api.Workout{ID: "w1", Title: "A"}, func() error { return nil }))
+	err := rec.Track(ActionDelete, &api.Workout{ID: "w2", Title: "B"}, func() error { return errors.New("boom") })
+	assert.EqualError(t, err, "boom")
+
+	ops, err := j.List()
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, rec.ID(), ops[0].ID)
+	require.Len(t, ops[0].Changes, 1)
+	assert.Equal(t, "w1", ops[0].Changes[0].ID)
+
+	// An operation whose only change failed leaves nothing behind
+	other := j.Begin("hevycli routine delete")
+	assert.Error(t, other.Track(ActionDelete, &api.Routine{ID: "r1"}, func() error { return errors.New("boom") }))
+	ops, err = j.List()
+	require.NoError(t, err)
+	assert.Len(t, ops, 1)
+}
+
+func TestRecorder_ForgetLatest(t *testing.T) {
+	j := Open(t.TempDir())
+	rec := j.Begin("hevycli routine move")
+
+	r := &api.Routine{ID: "r1", Title: "A"}
+	require.NoError(t, rec.Track(ActionUpdate, r, func() error { return nil }))
+	r.Title = "B"
+	assert.Error(t, rec.Track(ActionUpdate, r, func() error { return errors.New("boom") }))
+	assert.Error(t, rec.Track(ActionDelete, r, func() error { return errors.New("boom") }))
+
+	op, err := j.Get(rec.ID())
+	require.NoError(t, err)
+	require.Len(t, op.Changes, 1)
+	assert.Equal(t, ActionUpdate, op.Changes[0].Action)
+	assert.Equal(t, "A", op.Changes[0].Title)
+}
+
+func TestJournal_GetAndLatest(t *testing.T) {
+	j := Open(t.TempDir())
+	_, err := j.Latest()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	now := time.Now()
+	older := &Operation{ID: "20261017-100000-aaaa", Time: now.Add(-time.Hour), Command: "a",
+		Changes: []Change{{Kind: KindFolder, Action: ActionUpdate, ID: "1"}}}
+	newer := &Operation{ID: "20261018-100000-bbbb", Time: now, Command: "b",
+		Changes: []Change{{Kind: KindFolder, Action: ActionUpdate, ID: "2", UndoneAt: &now}}}
+	require.NoError(t, j.Save(older))
+	require.NoError(t, j.Save(newer))
+
+	latest, err := j.Latest()
+	require.NoError(t, err)
+	assert.Equal(t, older.ID, latest.ID)
+
+	// Operations recorded by undo are skipped
+	redo := &Operation{ID: "20261018-110000-cccc", Time: now.Add(time.Hour), Reverts: newer.ID,
+		Changes: []Change{{Kind: KindFolder, Action: ActionUpdate, ID: "2"}}}
+	require.NoError(t, j.Save(redo))
+	latest, err = j.Latest()
+	require.NoError(t, err)
+	assert.Equal(t, older.ID, latest.ID)
+
+	op, err := j.Get("20261018-10")
+	require.NoError(t, err)
+	assert.Equal(t, newer.ID, op.ID)
+
+	_, err = j.Get("2026101")
+	assert.EqualError(t, err, `operation ID "2026101" is ambiguous`)
+
+	_, err = j.Get("nope")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestJournal_Prune(t *testing.T) {
+	dir := t.TempDir()
+	j := Open(dir)
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < MaxOperations; i++ {
+		op := &Operation{ID: newID(start.Add(time.Duration(i) * time.Second)), Time: start.Add(time.Duration(i) * time.Second)}
+		require.NoError(t, j.Save(op))
+	}
+	oldest, err := j.List()
+	require.NoError(t, err)
+
+	rec := j.Begin("hevycli folder update")
+	require.NoError(t, rec.Record(Change{Kind: KindFolder, Action: ActionUpdate, ID: "1"}))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, MaxOperations)
+	_, err = j.Get(oldest[len(oldest)-1].ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestOperation_SummaryAndStatus(t *testing.T) {
+	now := time.Now()
+	op := &Operation{Changes: []Change{{Kind: KindRoutine, Action: ActionUpdate, Title: "Push"}}}
+	assert.Equal(t, `update routine "Push"`, op.Summary())
+	assert.Equal(t, "", op.Status())
+
+	op = &Operation{Changes: []Change{
+		{Kind: KindWorkout, Action: ActionDelete, UndoneAt: &now},
+		{Kind: KindWorkout, Action: ActionDelete},
+		{Kind: KindFolder, Action: ActionDelete},
+	}}
+	assert.Equal(t, "delete 2 workouts, delete 1 folder", op.Summary())
+	assert.Equal(t, "partly undone", op.Status())
+	assert.False(t, op.Undone())
+}
@@ -0,0 +1,174 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/edit"
+)
+
+// Outcome is the result of reverting one change
+type Outcome struct {
+	Kind   Kind   `json:"kind"`
+	Action Action `json:"action"`
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	OK     bool   `json:"ok"`
+	// RestoredID is the ID of the object recreated by reverting a delete
+	RestoredID string `json:"restored_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// Note explains what could not be restored
+	Note string `json:"note,omitempty"`
+}
+
+// Undo reverts the changes of op that have not been undone yet, newest first,
+// and saves their new state in the journal. Deleted objects are recreated
+// with new IDs; updated objects get their previous state back. The state an
+// update replaces is recorded in a new operation for command so the undo can
+// itself be undone.
+func (j *Journal) Undo(op *Operation, client *api.Client, command string) ([]Outcome, error) {
+	rec := j.Begin(command)
+	rec.op.Reverts = op.ID
+
+	var outcomes []Outcome
+	for i := len(op.Changes) - 1; i >= 0; i-- {
+		c := &op.Changes[i]
+		if c.UndoneAt != nil {
+			continue
+		}
+		outcome := Outcome{Kind: c.Kind, Action: c.Action, ID: c.ID, Title: c.Title, Note: note(c)}
+		restoredID, err := revert(c, client, rec)
+		if err != nil {
+			outcome.Error = err.Error()
+		} else {
+			now := time.Now()
+			c.UndoneAt = &now
+			c.RestoredID = restoredID
+			outcome.OK = true
+			outcome.RestoredID = restoredID
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	if err := j.Save(op); err != nil {
+		return outcomes, err
+	}
+	return outcomes, nil
+}
+
+// note explains the limits of reverting a change
+func note(c *Change) string {
+	switch {
+	case c.Kind == KindFolder && c.Action == ActionDelete:
+		return "routines that were in the folder are not moved back"
+	case c.Action == ActionDelete:
+		return "the restored " + string(c.Kind) + " has a new ID"
+	}
+	return ""
+}
+
+// revert applies the snapshot of one change and returns the ID of the object
+// recreated by reverting a delete
+func revert(c *Change, client *api.Client, rec *Recorder) (string, error) {
+	if c.Action == ActionCreate {
+		switch c.Kind {
+		case KindWorkout:
+			return "", client.DeleteWorkout(c.ID)
+		case KindRoutine:
+			return "", client.DeleteRoutine(c.ID)
+		case KindFolder:
+			return "", client.DeleteRoutineFolder(c.ID)
+		}
+	}
+
+	switch c.Kind {
+	case KindWorkout:
+		var w api.Workout
+		if err := json.Unmarshal(c.Before, &w); err != nil {
+			return "", fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		req := edit.FromWorkout(&w).Request()
+		if c.Action == ActionDelete {
+			created, err := client.CreateWorkout(&api.CreateWorkoutRequest{Workout: api.CreateWorkoutData{
+				Title:       req.Workout.Title,
+				Description: req.Workout.Description,
+				StartTime:   req.Workout.StartTime,
+				EndTime:     req.Workout.EndTime,
+				Exercises:   req.Workout.Exercises,
+			}})
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		}
+		current, err := client.GetWorkout(c.ID)
+		if err != nil {
+			return "", err
+		}
+		return "", rec.Track(ActionUpdate, current, func() error {
+			_, err := client.UpdateWorkout(c.ID, req)
+			return err
+		})
+
+	case KindRoutine:
+		var r api.Routine
+		if err := json.Unmarshal(c.Before, &r); err != nil {
+			return "", fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		req := edit.FromRoutine(&r).Request()
+		if r.FolderID != nil {
+			if id, err := strconv.Atoi(*r.FolderID); err == nil {
+				req.Routine.FolderID = &id
+			}
+		}
+		if c.Action == ActionDelete {
+			data := api.CreateRoutineData{
+				Title:     req.Routine.Title,
+				FolderID:  req.Routine.FolderID,
+				Notes:     req.Routine.Notes,
+				Exercises: req.Routine.Exercises,
+			}
+			created, err := client.CreateRoutine(&api.CreateRoutineRequest{Routine: data})
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		}
+		current, err := client.GetRoutine(c.ID)
+		if err != nil {
+			return "", err
+		}
+		return "", rec.Track(ActionUpdate, current, func() error {
+			_, err := client.UpdateRoutine(c.ID, req)
+			return err
+		})
+
+	case KindFolder:
+		var f api.RoutineFolder
+		if err := json.Unmarshal(c.Before, &f); err != nil {
+			return "", fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		if c.Action == ActionDelete {
+			created, err := client.CreateRoutineFolder(&api.CreateRoutineFolderRequest{
+				RoutineFolder: api.CreateRoutineFolderData{Title: f.Title},
+			})
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		}
+		current, err := client.GetRoutineFolder(c.ID)
+		if err != nil {
+			return "", err
+		}
+		return "", rec.Track(ActionUpdate, current, func() error {
+			_, err := client.UpdateRoutineFolder(c.ID, &api.UpdateRoutineFolderRequest{
+				RoutineFolder: api.UpdateRoutineFolderData{Title: f.Title, Index: &f.Index},
+			})
+			return err
+		})
+	}
+	return "", fmt.Errorf("unknown object kind %q", c.Kind)
+}
@@ -0,0 +1,414 @@
+// Package notation parses the compact workout notation used by hevycli log.
+//
+// Exercises are separated by semicolons or newlines, sets by commas:
+//
+//	Bench 60x10 wu, 100x8x3 @8; Pull-up BW+10x6x3; Plank 60s x3
+//
+// Each exercise starts with its name, optionally preceded by a superset
+// label such as a1 or a2, and may contain a quoted note. A set is made of
+// space-separated parts:
+//
+//	100x8        weight × reps; weights use the default unit unless suffixed with kg or lb
+//	100x8x3      weight × reps × sets
+//	BWx10        bodyweight reps; BW+10x6 adds weight, BW-20x8 is assistance
+//	             and needs an assisted bodyweight exercise
+//	12           reps only
+//	60s 2min 1h 1:30
+//	             duration
+//	400m 5km 3mi distance
+//	x3           repeat the set three times
+//	@8           RPE
+//	wu drop fail set type (warmup, dropset, failure)
+//
+// Lines starting with # are comments and a line "title: Push Day" sets the
+// workout title.
+package notation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+)
+
+// warmupRatio is the fraction of an exercise's heaviest weight at or below
+// which leading sets are inferred to be warmups
+const warmupRatio = 0.5
+
+// Options configures how notation is parsed
+type Options struct {
+	// Imperial interprets weights without a unit as pounds
+	Imperial bool
+	// NoInfer disables inferring warmup sets from their weight
+	NoInfer bool
+}
+
+// Workout is a parsed workout
+type Workout struct {
+	Title     string
+	Exercises []Exercise
+}
+
+// Exercise is a parsed exercise. TemplateID is empty until the name is resolved.
+type Exercise struct {
+	Name       string
+	TemplateID string
+	Superset   string
+	Notes      string
+	Sets       []Set
+}
+
+// Set is a parsed set
+type Set struct {
+	Type            api.SetType
+	WeightKg        *float64
+	Reps            *int
+	DistanceMeters  *int
+	DurationSeconds *int
+	RPE             *float64
+	// Assisted is set for BW- sets: the weight is assistance, not load
+	Assisted bool
+}
+
+// Error reports a problem in the notation
+type Error struct {
+	Exercise string
+	Msg      string
+}
+
+func (e *Error) Error() string {
+	if e.Exercise == "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Exercise, e.Msg)
+}
+
+func errorf(exercise, format string, args ...any) error {
+	return &Error{Exercise: exercise, Msg: fmt.Sprintf(format, args...)}
+}
+
+var (
+	labelPattern    = regexp.MustCompile(`^([a-z])(\d+)[.):]?$`)
+	setPattern      = regexp.MustCompile(`^(bw(?:[+-]\d*\.?\d+(?:kg|lbs?)?)?|\d*\.?\d+(?:kg|lbs?)?)x(\d+)(?:x(\d+))?$`)
+	weightPattern   = regexp.MustCompile(`^(\d*\.?\d+)(kg|lbs?)?$`)
+	countPattern    = regexp.MustCompile(`^x(\d+)$`)
+	rpePattern      = regexp.MustCompile(`^@(\d+(?:\.\d+)?)$`)
+	durationPattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)min)?(?:(\d+)(?:s|sec))?$`)
+	clockPattern    = regexp.MustCompile(`^(\d+):(\d{2})(?::(\d{2}))?$`)
+	distancePattern = regexp.MustCompile(`^(\d*\.?\d+)(m|km|mi)$`)
+)
+
+var setTypes = map[string]api.SetType{
+	"wu":      api.SetTypeWarmup,
+	"w":       api.SetTypeWarmup,
+	"warmup":  api.SetTypeWarmup,
+	"d":       api.SetTypeDropset,
+	"ds":      api.SetTypeDropset,
+	"drop":    api.SetTypeDropset,
+	"dropset": api.SetTypeDropset,
+	"f":       api.SetTypeFailure,
+	"fail":    api.SetTypeFailure,
+	"failure": api.SetTypeFailure,
+}
+
+// Parse parses workout notation
+func Parse(src string, opts Options) (*Workout, error) {
+	w := &Workout{}
+	var segments []string
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), "title") {
+			w.Title = strings.TrimSpace(value)
+			continue
+		}
+		segments = append(segments, splitOutsideQuotes(line, ';')...)
+	}
+
+	for _, seg := range segments {
+		if strings.TrimSpace(seg) == "" {
+			continue
+		}
+		ex, err := parseExercise(seg, opts)
+		if err != nil {
+			return nil, err
+		}
+		w.Exercises = append(w.Exercises, *ex)
+	}
+	if len(w.Exercises) == 0 {
+		return nil, errorf("", "no exercises found")
+	}
+	return w, nil
+}
+
+// parseExercise parses one exercise: [label] name sets [, sets...] ["note"]
+func parseExercise(seg string, opts Options) (*Exercise, error) {
+	rest, notes, err := extractNotes(seg)
+	if err != nil {
+		return nil, errorf(strings.TrimSpace(seg), "%v", err)
+	}
+	ex := &Exercise{Notes: notes}
+
+	tokens := strings.Fields(strings.ReplaceAll(rest, "×", "x"))
+	if len(tokens) > 1 {
+		if m := labelPattern.FindStringSubmatch(strings.ToLower(tokens[0])); m != nil {
+			ex.Superset = m[1]
+			tokens = tokens[1:]
+		}
+	}
+
+	i := 0
+	for i < len(tokens) && !isSetToken(tokens[i]) {
+		i++
+	}
+	ex.Name = strings.TrimRight(strings.Join(tokens[:i], " "), ",")
+	if ex.Name == "" {
+		return nil, errorf(strings.TrimSpace(seg), "missing exercise name")
+	}
+	if i == len(tokens) {
+		return nil, errorf(ex.Name, "no sets")
+	}
+
+	for _, group := range strings.Split(strings.Join(tokens[i:], " "), ",") {
+		if strings.TrimSpace(group) == "" {
+			continue
+		}
+		sets, err := parseSets(group, opts)
+		if err != nil {
+			return nil, errorf(ex.Name, "%v", err)
+		}
+		ex.Sets = append(ex.Sets, sets...)
+	}
+	if !opts.NoInfer {
+		inferWarmups(ex.Sets)
+	}
+	return ex, nil
+}
+
+// isSetToken reports whether a token starts the set list rather than
+// continuing the exercise name
+func isSetToken(tok string) bool {
+	tok = strings.ToLower(strings.TrimRight(tok, ","))
+	if tok == "" {
+		return false
+	}
+	if tok[0] >= '0' && tok[0] <= '9' || tok[0] == '@' || tok[0] == '.' {
+		return true
+	}
+	return setPattern.MatchString(tok) || countPattern.MatchString(tok)
+}
+
+// parseSets parses a comma-separated group into one or more identical sets
+func parseSets(group string, opts Options) ([]Set, error) {
+	var set Set
+	count := 1
+	explicit := false
+
+	for _, tok := range strings.Fields(strings.ToLower(group)) {
+		if t, ok := setTypes[tok]; ok {
+			set.Type = t
+			explicit = true
+			continue
+		}
+		if m := countPattern.FindStringSubmatch(tok); m != nil {
+			count, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if m := rpePattern.FindStringSubmatch(tok); m != nil {
+			rpe, _ := strconv.ParseFloat(m[1], 64)
+			if err := api.CheckRPE(rpe); err != nil {
+				return nil, fmt.Errorf("RPE %v, got %s", err, m[1])
+			}
+			set.RPE = &rpe
+			continue
+		}
+		if m := setPattern.FindStringSubmatch(tok); m != nil {
+			if m[1] != "bw" {
+				kg, err := parseWeight(strings.TrimLeft(m[1], "bw+-"), opts)
+				if err != nil {
+					return nil, err
+				}
+				set.WeightKg = &kg
+				set.Assisted = strings.HasPrefix(m[1], "bw-")
+			}
+			reps, _ := strconv.Atoi(m[2])
+			set.Reps = &reps
+			if m[3] != "" {
+				count, _ = strconv.Atoi(m[3])
+			}
+			continue
+		}
+		if reps, err := strconv.Atoi(tok); err == nil {
+			set.Reps = &reps
+			continue
+		}
+		if m := weightPattern.FindStringSubmatch(tok); m != nil && m[2] != "" {
+			kg, err := parseWeight(tok, opts)
+			if err != nil {
+				return nil, err
+			}
+			set.WeightKg = &kg
+			continue
+		}
+		if secs, ok := parseDuration(tok); ok {
+			set.DurationSeconds = &secs
+			continue
+		}
+		if m := distancePattern.FindStringSubmatch(tok); m != nil {
+			meters := parseDistance(m[1], m[2])
+			set.DistanceMeters = &meters
+			continue
+		}
+		return nil, fmt.Errorf("unrecognized set %q", tok)
+	}
+
+	if set.Reps == nil && set.DurationSeconds == nil && set.DistanceMeters == nil {
+		return nil, fmt.Errorf("set %q has no reps, duration or distance", strings.TrimSpace(group))
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("set count must be at least 1")
+	}
+	if !explicit {
+		set.Type = api.SetTypeNormal
+	}
+
+	sets := make([]Set, count)
+	for i := range sets {
+		sets[i] = set
+	}
+	return sets, nil
+}
+
+// parseWeight parses a weight such as 100, 100kg or 225lb into kilograms
+func parseWeight(s string, opts Options) (float64, error) {
+	m := weightPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid weight %q", s)
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	unit := m[2]
+	if unit == "" && opts.Imperial {
+		unit = "lb"
+	}
+	if strings.HasPrefix(unit, "lb") {
+		v *= analysis.KgPerLb
+	}
+	return roundTo(v, 2), nil
+}
+
+// parseDuration parses durations such as 60s, 2min, 1h30min or 1:30
+func parseDuration(s string) (int, bool) {
+	if m := clockPattern.FindStringSubmatch(s); m != nil {
+		a, _ := strconv.Atoi(m[1])
+		b, _ := strconv.Atoi(m[2])
+		if m[3] != "" {
+			c, _ := strconv.Atoi(m[3])
+			return a*3600 + b*60 + c, true
+		}
+		return a*60 + b, true
+	}
+	m := durationPattern.FindStringSubmatch(s)
+	if m == nil || s == "" {
+		return 0, false
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if m[i+1] != "" {
+			n, _ := strconv.Atoi(m[i+1])
+			d += time.Duration(n) * unit
+		}
+	}
+	return int(d.Seconds()), true
+}
+
+// parseDistance converts a distance to meters
+func parseDistance(value, unit string) int {
+	v, _ := strconv.ParseFloat(value, 64)
+	switch unit {
+	case "km":
+		v *= 1000
+	case "mi":
+		v *= 1609.344
+	}
+	return int(v + 0.5)
+}
+
+// inferWarmups marks leading light sets as warmups when no set of the
+// exercise has an explicit type
+func inferWarmups(sets []Set) {
+	var max float64
+	for _, s := range sets {
+		if s.Type != api.SetTypeNormal {
+			return
+		}
+		if s.WeightKg != nil && *s.WeightKg > max {
+			max = *s.WeightKg
+		}
+	}
+	if max == 0 {
+		return
+	}
+	for i := range sets {
+		if sets[i].WeightKg == nil || *sets[i].WeightKg > max*warmupRatio {
+			return
+		}
+		sets[i].Type = api.SetTypeWarmup
+	}
+}
+
+// extractNotes removes double-quoted notes from a segment
+func extractNotes(seg string) (string, string, error) {
+	var rest strings.Builder
+	var notes []string
+	for {
+		start := strings.IndexByte(seg, '"')
+		if start < 0 {
+			rest.WriteString(seg)
+			break
+		}
+		end := strings.IndexByte(seg[start+1:], '"')
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated note")
+		}
+		rest.WriteString(seg[:start])
+		rest.WriteString(" ")
+		if note := strings.TrimSpace(seg[start+1 : start+1+end]); note != "" {
+			notes = append(notes, note)
+		}
+		seg = seg[start+end+2:]
+	}
+	return rest.String(), strings.Join(notes, " "), nil
+}
+
+// splitOutsideQuotes splits s on sep, ignoring separators inside double quotes
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func roundTo(v float64, places int) float64 {
+	p := 1.0
+	for i := 0; i < places; i++ {
+		p *= 10
+	}
+	return float64(int64(v*p+0.5)) / p
+}
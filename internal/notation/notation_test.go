@@ -0,0 +1,218 @@
+package notation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+func TestParse_Example(t *testing.T) {
+	w, err := Parse("Bench 60x10 wu, 100x8x3 @8; Pull-up BW+10x6x3; Plank 60s x3", Options{})
+	require.NoError(t, err)
+	require.Len(t, w.Exercises, 3)
+
+	bench := w.Exercises[0]
+	assert.Equal(t, "Bench", bench.Name)
+	require.Len(t, bench.Sets, 4)
+	assert.Equal(t, api.SetTypeWarmup, bench.Sets[0].Type)
+	assert.Equal(t, 60.0, *bench.Sets[0].WeightKg)
+	assert.Equal(t, 10, *bench.Sets[0].Reps)
+	for _, s := range bench.Sets[1:] {
+		assert.Equal(t, api.SetTypeNormal, s.Type)
+		assert.Equal(t, 100.0, *s.WeightKg)
+		assert.Equal(t, 8, *s.Reps)
+		assert.Equal(t, 8.0, *s.RPE)
+	}
+
+	pullup := w.Exercises[1]
+	assert.Equal(t, "Pull-up", pullup.Name)
+	require.Len(t, pullup.Sets, 3)
+	assert.Equal(t, 10.0, *pullup.Sets[0].WeightKg)
+	assert.Equal(t, 6, *pullup.Sets[0].Reps)
+
+	plank := w.Exercises[2]
+	require.Len(t, plank.Sets, 3)
+	assert.Equal(t, 60, *plank.Sets[0].DurationSeconds)
+	assert.Nil(t, plank.Sets[0].Reps)
+}
+
+func TestParse_Sets(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  Options
+		want  Set
+		count int
+	}{
+		{"bodyweight", "Dip BWx12", Options{}, Set{Reps: intPtr(12)}, 1},
+		{"weighted", "Pull Up BW+20x8", Options{}, Set{WeightKg: floatPtr(20), Reps: intPtr(8)}, 1},
+		{"assisted", "Pull Up BW-20x8x2", Options{}, Set{WeightKg: floatPtr(20), Reps: intPtr(8), Assisted: true}, 2},
+		{"reps only", "Push Up 15 x2", Options{}, Set{Reps: intPtr(15)}, 2},
+		{"pounds", "Squat 225lbx5", Options{}, Set{WeightKg: floatPtr(102.06), Reps: intPtr(5)}, 1},
+		{"imperial default", "Squat 225x5", Options{Imperial: true}, Set{WeightKg: floatPtr(102.06), Reps: intPtr(5)}, 1},
+		{"kg with imperial", "Squat 100kgx5", Options{Imperial: true}, Set{WeightKg: floatPtr(100), Reps: intPtr(5)}, 1},
+		{"clock duration", "Row 5km 21:30", Options{}, Set{DistanceMeters: intPtr(5000), DurationSeconds: intPtr(1290)}, 1},
+		{"compound duration", "Bike 1h30min", Options{}, Set{DurationSeconds: intPtr(5400)}, 1},
+		{"weighted carry", "Farmer Walk 40kg 60s x2", Options{}, Set{WeightKg: floatPtr(40), DurationSeconds: intPtr(60)}, 2},
+		{"failure", "Curl 20x12 fail", Options{}, Set{Type: api.SetTypeFailure, WeightKg: floatPtr(20), Reps: intPtr(12)}, 1},
+		{"unicode times", "Curl 20×12×3", Options{}, Set{WeightKg: floatPtr(20), Reps: intPtr(12)}, 3},
+		{"half rpe", "Curl 20x12 @9.5", Options{}, Set{WeightKg: floatPtr(20), Reps: intPtr(12), RPE: floatPtr(9.5)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := Parse(tt.input, tt.opts)
+			require.NoError(t, err)
+			require.Len(t, w.Exercises, 1)
+			sets := w.Exercises[0].Sets
+			require.Len(t, sets, tt.count)
+			if tt.want.Type == "" {
+				tt.want.Type = api.SetTypeNormal
+			}
+			assert.Equal(t, tt.want, sets[0])
+		})
+	}
+}
+
+func TestParse_InferWarmups(t *testing.T) {
+	w, err := Parse("Squat 20x10, 60x5, 140x5x3", Options{})
+	require.NoError(t, err)
+	types := []api.SetType{}
+	for _, s := range w.Exercises[0].Sets {
+		types = append(types, s.Type)
+	}
+	assert.Equal(t, []api.SetType{api.SetTypeWarmup, api.SetTypeWarmup, api.SetTypeNormal, api.SetTypeNormal, api.SetTypeNormal}, types)
+
+	// explicit types disable inference
+	w, err = Parse("Squat 20x10, 60x5 wu, 140x5", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, api.SetTypeNormal, w.Exercises[0].Sets[0].Type)
+
+	w, err = Parse("Squat 20x10, 140x5", Options{NoInfer: true})
+	require.NoError(t, err)
+	assert.Equal(t, api.SetTypeNormal, w.Exercises[0].Sets[0].Type)
+}
+
+func TestParse_SupersetsNotesAndTitle(t *testing.T) {
+	src := `# push day
+title: Push
+a1 Bench Press 80x8x3 "pause reps; slow"
+a2) Row 60x10x3
+Lateral Raise 10x15`
+	w, err := Parse(src, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "Push", w.Title)
+	require.Len(t, w.Exercises, 3)
+	assert.Equal(t, "Bench Press", w.Exercises[0].Name)
+	assert.Equal(t, "a", w.Exercises[0].Superset)
+	assert.Equal(t, "pause reps; slow", w.Exercises[0].Notes)
+	assert.Equal(t, "a", w.Exercises[1].Superset)
+	assert.Equal(t, "", w.Exercises[2].Superset)
+
+	for i := range w.Exercises {
+		w.Exercises[i].TemplateID = "T" + w.Exercises[i].Name
+	}
+	start := time.Date(2026, 10, 18, 17, 0, 0, 0, time.UTC)
+	req, err := w.Request(start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-18T17:00:00Z", req.Workout.StartTime)
+	assert.Equal(t, "2026-10-18T18:00:00Z", req.Workout.EndTime)
+	require.NotNil(t, req.Workout.Exercises[0].SupersetID)
+	assert.Equal(t, 0, *req.Workout.Exercises[0].SupersetID)
+	assert.Equal(t, 0, *req.Workout.Exercises[1].SupersetID)
+	assert.Nil(t, req.Workout.Exercises[2].SupersetID)
+	assert.Equal(t, "pause reps; slow", *req.Workout.Exercises[0].Notes)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "no exercises found"},
+		{"Bench", "Bench: no sets"},
+		{"60x10", "missing exercise name"},
+		{"Bench 60x10 heavy", `Bench: unrecognized set "heavy"`},
+		{"Bench @8", `Bench: set "@8" has no reps, duration or distance`},
+		{"Bench 60x10 @11", "Bench: RPE must be one of 6, 7, 7.5, 8, 8.5, 9, 9.5, 10, got 11"},
+		{"Bench 60x10 @5", "Bench: RPE must be one of 6, 7, 7.5, 8, 8.5, 9, 9.5, 10, got 5"},
+		{"Bench 60x10 @8.3", "got 8.3"},
+		{`Bench 60x10 "note`, "unterminated note"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input, Options{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			var nerr *Error
+			assert.True(t, errors.As(err, &nerr))
+		})
+	}
+}
+
+func TestRequest_Unresolved(t *testing.T) {
+	w, err := Parse("Bench 60x10", Options{})
+	require.NoError(t, err)
+	_, err = w.Request(time.Now(), time.Now())
+	assert.EqualError(t, err, "Bench: exercise is not resolved to a template")
+}
+
+func TestCheckTemplate(t *testing.T) {
+	w, err := Parse("Pull Up BW-20x8; Dip BW+10x8", Options{})
+	require.NoError(t, err)
+	assisted := api.ExerciseTemplate{Title: "Pull Up (Assisted)", Type: api.ExerciseTypeBodyweightAssisted}
+	weighted := api.ExerciseTemplate{Title: "Pull Up (Weighted)", Type: api.ExerciseTypeBodyweightReps}
+
+	assert.NoError(t, w.Exercises[0].CheckTemplate(assisted))
+	assert.EqualError(t, w.Exercises[0].CheckTemplate(weighted),
+		"Pull Up: BW- is assistance, but Pull Up (Weighted) is a Bodyweight Reps exercise; use BW+ for added weight")
+	assert.NoError(t, w.Exercises[1].CheckTemplate(weighted))
+}
+
+func TestMatch(t *testing.T) {
+	templates := []api.ExerciseTemplate{
+		{ID: "1", Title: "Bench Press (Barbell)"},
+		{ID: "2", Title: "Bench Press (Dumbbell)"},
+		{ID: "3", Title: "Incline Bench Press (Barbell)"},
+		{ID: "4", Title: "Pull Up"},
+		{ID: "5", Title: "Pull Up (Weighted)"},
+		{ID: "6", Title: "Plank"},
+	}
+	ids := func(ts []api.ExerciseTemplate) []string {
+		out := []string{}
+		for _, t := range ts {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"4"}, ids(Match("pull-up", templates)))
+	assert.Equal(t, []string{"4"}, ids(Match("PULL UP", templates)))
+	assert.Equal(t, []string{"6"}, ids(Match("6", templates)))
+	assert.Equal(t, []string{"1", "2", "3"}, ids(Match("bench", templates)))
+	assert.Equal(t, []string{"1", "3"}, ids(Match("bench barbell", templates)))
+	assert.Equal(t, []string{"4", "5"}, ids(Match("pullup", templates)))
+	assert.Empty(t, Match("deadlift", templates))
+
+	assert.True(t, Exact("pull-up", templates[3]))
+	assert.True(t, Exact("5", templates[4]))
+	assert.False(t, Exact("bench", templates[0]))
+}
+
+func TestSetString(t *testing.T) {
+	s := Set{WeightKg: floatPtr(100), Reps: intPtr(8), RPE: floatPtr(8)}
+	assert.Equal(t, "100.0 kg × 8 @8", s.String(false))
+	assert.Equal(t, "220.5 lbs × 8 @8", s.String(true))
+	assert.Equal(t, "20.0 kg assistance × 8", Set{WeightKg: floatPtr(20), Reps: intPtr(8), Assisted: true}.String(false))
+	assert.Equal(t, "1m30s", Set{DurationSeconds: intPtr(90)}.String(false))
+	assert.Equal(t, "1m", Set{DurationSeconds: intPtr(60)}.String(false))
+	assert.Equal(t, "1h", Set{DurationSeconds: intPtr(3600)}.String(false))
+	assert.Equal(t, "400m 45s", Set{DistanceMeters: intPtr(400), DurationSeconds: intPtr(45)}.String(false))
+}
+
+func floatPtr(v float64) *float64 { return &v }
+func intPtr(v int) *int           { return &v }
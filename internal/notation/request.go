@@ -0,0 +1,209 @@
+package notation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+)
+
+// Request builds a create-workout request from the parsed workout.
+// Every exercise must have a resolved TemplateID.
+func (w *Workout) Request(start, end time.Time) (*api.CreateWorkoutRequest, error) {
+	supersets := w.supersetIDs()
+
+	data := api.CreateWorkoutData{
+		Title:     w.Title,
+		StartTime: start.Format(time.RFC3339),
+		EndTime:   end.Format(time.RFC3339),
+		Exercises: make([]api.CreateWorkoutExercise, len(w.Exercises)),
+	}
+	for i, ex := range w.Exercises {
+		if ex.TemplateID == "" {
+			return nil, errorf(ex.Name, "exercise is not resolved to a template")
+		}
+		out := api.CreateWorkoutExercise{
+			ExerciseTemplateID: ex.TemplateID,
+			Sets:               make([]api.CreateWorkoutSet, len(ex.Sets)),
+		}
+		if id, ok := supersets[ex.Superset]; ok {
+			id := id
+			out.SupersetID = &id
+		}
+		if ex.Notes != "" {
+			notes := ex.Notes
+			out.Notes = &notes
+		}
+		for j, s := range ex.Sets {
+			out.Sets[j] = api.CreateWorkoutSet{
+				Type:            s.Type,
+				WeightKg:        s.WeightKg,
+				Reps:            s.Reps,
+				DistanceMeters:  s.DistanceMeters,
+				DurationSeconds: s.DurationSeconds,
+				RPE:             s.RPE,
+			}
+		}
+		data.Exercises[i] = out
+	}
+	return &api.CreateWorkoutRequest{Workout: data}, nil
+}
+
+// CheckTemplate reports sets that the resolved template cannot hold: BW-
+// assistance only makes sense on an assisted bodyweight exercise, where the
+// API reads the weight as assistance rather than added load.
+func (e Exercise) CheckTemplate(t api.ExerciseTemplate) error {
+	if t.Type == api.ExerciseTypeBodyweightAssisted {
+		return nil
+	}
+	for _, s := range e.Sets {
+		if s.Assisted {
+			return errorf(e.Name, "BW- is assistance, but %s is a %s exercise; use BW+ for added weight", t.Title, t.Type.DisplayName())
+		}
+	}
+	return nil
+}
+
+// supersetIDs numbers the superset labels shared by two or more exercises
+// in order of first appearance
+func (w *Workout) supersetIDs() map[string]int {
+	counts := make(map[string]int)
+	var order []string
+	for _, ex := range w.Exercises {
+		if ex.Superset == "" {
+			continue
+		}
+		if counts[ex.Superset] == 0 {
+			order = append(order, ex.Superset)
+		}
+		counts[ex.Superset]++
+	}
+	ids := make(map[string]int)
+	for _, label := range order {
+		if counts[label] > 1 {
+			ids[label] = len(ids)
+		}
+	}
+	return ids
+}
+
+// String formats a set in notation, with weights in pounds when imperial is set
+func (s Set) String(imperial bool) string {
+	var parts []string
+	switch {
+	case s.WeightKg != nil && s.Reps != nil && s.Assisted:
+		parts = append(parts, fmt.Sprintf("%s assistance × %d", analysis.FormatWeight(*s.WeightKg, imperial), *s.Reps))
+	case s.WeightKg != nil && s.Reps != nil:
+		parts = append(parts, fmt.Sprintf("%s × %d", analysis.FormatWeight(*s.WeightKg, imperial), *s.Reps))
+	case s.Reps != nil:
+		parts = append(parts, fmt.Sprintf("%d reps", *s.Reps))
+	case s.WeightKg != nil:
+		parts = append(parts, analysis.FormatWeight(*s.WeightKg, imperial))
+	}
+	if s.DistanceMeters != nil {
+		parts = append(parts, fmt.Sprintf("%dm", *s.DistanceMeters))
+	}
+	if s.DurationSeconds != nil {
+		parts = append(parts, formatDuration(*s.DurationSeconds))
+	}
+	if s.RPE != nil {
+		parts = append(parts, fmt.Sprintf("@%g", *s.RPE))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatDuration formats seconds like 45s, 1m30s or 2m, without zero units
+func formatDuration(seconds int) string {
+	d := (time.Duration(seconds) * time.Second).String()
+	if strings.HasSuffix(d, "m0s") {
+		d = strings.TrimSuffix(d, "0s")
+	}
+	if strings.HasSuffix(d, "h0m") {
+		d = strings.TrimSuffix(d, "0m")
+	}
+	return d
+}
+
+// Match returns the exercise templates whose titles match name, best first.
+// A template ID or an exact title (ignoring case and punctuation) is a single
+// match; otherwise every word of name must appear in the title.
+func Match(name string, templates []api.ExerciseTemplate) []api.ExerciseTemplate {
+	query := normalize(name)
+	var exact []api.ExerciseTemplate
+	for _, t := range templates {
+		if Exact(name, t) {
+			exact = append(exact, t)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+
+	words := strings.Fields(query)
+	type candidate struct {
+		template api.ExerciseTemplate
+		extra    int
+	}
+	var candidates []candidate
+	for _, t := range templates {
+		title := normalize(t.Title)
+		titleWords := strings.Fields(title)
+		if !containsWords(title, titleWords, words) {
+			continue
+		}
+		candidates = append(candidates, candidate{t, len(titleWords) - len(words)})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].extra != candidates[j].extra {
+			return candidates[i].extra < candidates[j].extra
+		}
+		return len(candidates[i].template.Title) < len(candidates[j].template.Title)
+	})
+
+	matches := make([]api.ExerciseTemplate, len(candidates))
+	for i, c := range candidates {
+		matches[i] = c.template
+	}
+	return matches
+}
+
+// Exact reports whether name is the template's ID or its title, ignoring
+// case and punctuation
+func Exact(name string, t api.ExerciseTemplate) bool {
+	return strings.EqualFold(t.ID, name) || normalize(t.Title) == normalize(name)
+}
+
+// containsWords reports whether each query word starts a title word or,
+// for words like "pullup", appears in the title with spaces removed
+func containsWords(title string, titleWords, words []string) bool {
+	compact := strings.ReplaceAll(title, " ", "")
+	for _, w := range words {
+		found := false
+		for _, tw := range titleWords {
+			if strings.HasPrefix(tw, w) {
+				found = true
+				break
+			}
+		}
+		if !found && !strings.Contains(compact, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize lowercases s and replaces punctuation with spaces
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127 {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
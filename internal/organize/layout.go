@@ -0,0 +1,98 @@
+package organize
+
+import (
+	"sort"
+
+	"github.com/obay/hevycli/internal/api"
+)
+
+// Folder is a folder with the routines in it
+type Folder struct {
+	Folder   api.RoutineFolder `json:"folder"`
+	Routines []api.Routine     `json:"routines"`
+}
+
+// Layout is the folders in order, each with its routines, and the routines
+// that are not in any folder
+type Layout struct {
+	Folders  []Folder      `json:"folders"`
+	Unfiled  []api.Routine `json:"unfiled"`
+	original map[string]string
+	order    []string
+}
+
+// NewLayout groups routines by folder, with folders sorted by index.
+// Routines in unknown folders are unfiled.
+func NewLayout(folders []api.RoutineFolder, routines []api.Routine) *Layout {
+	sorted := make([]api.RoutineFolder, len(folders))
+	copy(sorted, folders)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	l := &Layout{
+		Folders:  make([]Folder, len(sorted)),
+		Unfiled:  []api.Routine{},
+		original: make(map[string]string, len(routines)),
+		order:    make([]string, len(sorted)),
+	}
+	position := make(map[string]int, len(sorted))
+	for i, f := range sorted {
+		l.Folders[i] = Folder{Folder: f, Routines: []api.Routine{}}
+		l.order[i] = f.ID
+		position[f.ID] = i
+	}
+	for _, r := range routines {
+		folderID := ""
+		if r.FolderID != nil {
+			folderID = *r.FolderID
+		}
+		l.original[r.ID] = folderID
+		if i, ok := position[folderID]; ok {
+			l.Folders[i].Routines = append(l.Folders[i].Routines, r)
+		} else {
+			l.Unfiled = append(l.Unfiled, r)
+		}
+	}
+	return l
+}
+
+// Move is a routine to move into a folder
+type Move struct {
+	Routine api.Routine
+	Folder  api.RoutineFolder
+}
+
+// Plan is the changes needed to turn the original layout into the current one
+type Plan struct {
+	// Order is the folder IDs in their new order, or nil if unchanged
+	Order []string
+	Moves []Move
+}
+
+// Empty reports whether the plan changes nothing
+func (p Plan) Empty() bool {
+	return len(p.Order) == 0 && len(p.Moves) == 0
+}
+
+// Plan compares the layout with the one it was created from. Routines can
+// only be moved into folders, not out of them, so unfiled routines are
+// never moved.
+func (l *Layout) Plan() Plan {
+	var p Plan
+	for i, f := range l.Folders {
+		if i >= len(l.order) || l.order[i] != f.Folder.ID {
+			p.Order = make([]string, len(l.Folders))
+			for j, f := range l.Folders {
+				p.Order[j] = f.Folder.ID
+			}
+			break
+		}
+	}
+	for _, f := range l.Folders {
+		for _, r := range f.Routines {
+			if l.original[r.ID] != f.Folder.ID {
+				p.Moves = append(p.Moves, Move{Routine: r, Folder: f.Folder})
+			}
+		}
+	}
+	return p
+}
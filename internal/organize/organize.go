@@ -0,0 +1,171 @@
+// Package organize moves routines between folders and reorders folders.
+//
+// The API may not support every change directly: a routine whose folder
+// cannot be updated in place is copied into the target folder and the
+// original deleted. Every change is recorded in the undo journal.
+package organize
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/edit"
+	"github.com/obay/hevycli/internal/journal"
+)
+
+// ErrOrderUnsupported is returned when the API does not apply folder indexes
+var ErrOrderUnsupported = errors.New("the API did not apply the new folder order")
+
+// Method is how a routine was moved
+type Method string
+
+const (
+	// MethodUnchanged means the routine was already in the folder
+	MethodUnchanged Method = "unchanged"
+	// MethodUpdated means the routine's folder was updated in place
+	MethodUpdated Method = "updated"
+	// MethodCopied means the routine was copied into the folder and the
+	// original deleted, giving it a new ID
+	MethodCopied Method = "copied"
+)
+
+// InFolder reports whether the routine is in the folder
+func InFolder(r *api.Routine, folderID string) bool {
+	return r.FolderID != nil && *r.FolderID == folderID
+}
+
+// MoveRoutine moves a routine into a folder and returns the moved routine.
+// It first asks the API to update the routine's folder; if the API rejects
+// or ignores the change, the routine is copied into the folder and the
+// original deleted.
+func MoveRoutine(client *api.Client, rec *journal.Recorder, r *api.Routine, folderID string) (*api.Routine, Method, error) {
+	if InFolder(r, folderID) {
+		return r, MethodUnchanged, nil
+	}
+	id, err := strconv.Atoi(folderID)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid folder ID %q", folderID)
+	}
+
+	req := edit.FromRoutine(r).Request()
+	req.Routine.FolderID = &id
+
+	change, err := journal.Snapshot(journal.ActionUpdate, r)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := rec.Record(change); err != nil {
+		return nil, "", fmt.Errorf("failed to record undo journal: %w", err)
+	}
+	updated, err := client.UpdateRoutine(r.ID, req)
+	if err == nil && InFolder(updated, folderID) {
+		return updated, MethodUpdated, nil
+	}
+	rec.Forget(change)
+	if apiErr, ok := api.AsAPIError(err); err != nil && (!ok || apiErr.ErrorCode != "VALIDATION_ERROR") {
+		return nil, "", err
+	}
+
+	// The folder cannot be changed in place: copy and delete the original
+	created, err := client.CreateRoutine(&api.CreateRoutineRequest{Routine: api.CreateRoutineData{
+		Title:     req.Routine.Title,
+		FolderID:  &id,
+		Notes:     req.Routine.Notes,
+		Exercises: req.Routine.Exercises,
+	}})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to copy routine into folder: %w", err)
+	}
+	copied, err := journal.Snapshot(journal.ActionCreate, created)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := rec.Record(copied); err != nil {
+		return nil, "", fmt.Errorf("failed to record undo journal: %w", err)
+	}
+	err = rec.Track(journal.ActionDelete, r, func() error {
+		return client.DeleteRoutine(r.ID)
+	})
+	if err != nil {
+		return created, MethodCopied, fmt.Errorf("copied routine to %s but failed to delete the original: %w", created.ID, err)
+	}
+	return created, MethodCopied, nil
+}
+
+// Order returns the folders in a new order: the folders named by ids come
+// first in that order, followed by the others in their current order. The
+// returned folders have their Index set to their new position.
+func Order(folders []api.RoutineFolder, ids []string) ([]api.RoutineFolder, error) {
+	current := make([]api.RoutineFolder, len(folders))
+	copy(current, folders)
+	sort.SliceStable(current, func(i, j int) bool { return current[i].Index < current[j].Index })
+
+	byID := make(map[string]api.RoutineFolder, len(current))
+	for _, f := range current {
+		byID[f.ID] = f
+	}
+
+	ordered := make([]api.RoutineFolder, 0, len(current))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		f, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("folder %s not found", id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("folder %s is listed more than once", id)
+		}
+		seen[id] = true
+		ordered = append(ordered, f)
+	}
+	for _, f := range current {
+		if !seen[f.ID] {
+			ordered = append(ordered, f)
+		}
+	}
+	for i := range ordered {
+		ordered[i].Index = i
+	}
+	return ordered, nil
+}
+
+// ApplyOrder updates the index of every folder in ordered whose position
+// differs from its index in current, and returns the updated folders in
+// their new order. It stops with ErrOrderUnsupported if the API ignores the
+// new index.
+func ApplyOrder(client *api.Client, rec *journal.Recorder, current, ordered []api.RoutineFolder) ([]api.RoutineFolder, error) {
+	before := make(map[string]api.RoutineFolder, len(current))
+	for _, f := range current {
+		before[f.ID] = f
+	}
+
+	result := make([]api.RoutineFolder, len(ordered))
+	for i, f := range ordered {
+		old := before[f.ID]
+		if old.Index == f.Index {
+			result[i] = f
+			continue
+		}
+
+		index := f.Index
+		var updated *api.RoutineFolder
+		err := rec.Track(journal.ActionUpdate, &old, func() error {
+			var err error
+			updated, err = client.UpdateRoutineFolder(f.ID, &api.UpdateRoutineFolderRequest{
+				RoutineFolder: api.UpdateRoutineFolderData{Title: f.Title, Index: &index},
+			})
+			if err == nil && updated.Index != index {
+				return ErrOrderUnsupported
+			}
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to move folder %q: %w", f.Title, err)
+		}
+		result[i] = *updated
+	}
+	return result, nil
+}
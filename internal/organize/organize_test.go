@@ -0,0 +1,192 @@
+package organize
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/journal"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testFolders() []api.RoutineFolder {
+	return []api.RoutineFolder{
+		{ID: "2", Title: "Pull", Index: 1},
+		{ID: "1", Title: "Push", Index: 0},
+		{ID: "3", Title: "Legs", Index: 2},
+	}
+}
+
+func folderIDs(folders []api.RoutineFolder) []string {
+	ids := make([]string, len(folders))
+	for i, f := range folders {
+		ids[i] = f.ID
+	}
+	return ids
+}
+
+func TestOrder(t *testing.T) {
+	ordered, err := Order(testFolders(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, folderIDs(ordered))
+
+	ordered, err = Order(testFolders(), []string{"3"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3", "1", "2"}, folderIDs(ordered))
+	for i, f := range ordered {
+		assert.Equal(t, i, f.Index)
+	}
+
+	_, err = Order(testFolders(), []string{"9"})
+	assert.EqualError(t, err, "folder 9 not found")
+	_, err = Order(testFolders(), []string{"1", "1"})
+	assert.EqualError(t, err, "folder 1 is listed more than once")
+}
+
+func TestLayoutPlan(t *testing.T) {
+	routines := []api.Routine{
+		{ID: "a", Title: "Bench", FolderID: strPtr("1")},
+		{ID: "b", Title: "Rows", FolderID: strPtr("2")},
+		{ID: "c", Title: "Loose"},
+		{ID: "d", Title: "Gone", FolderID: strPtr("99")},
+	}
+	layout := NewLayout(testFolders(), routines)
+	require.Len(t, layout.Folders, 3)
+	assert.Equal(t, "Push", layout.Folders[0].Folder.Title)
+	assert.Len(t, layout.Folders[0].Routines, 1)
+	assert.Len(t, layout.Unfiled, 2)
+	assert.True(t, layout.Plan().Empty())
+
+	// Swap the first two folders and move an unfiled routine into Legs
+	layout.Folders[0], layout.Folders[1] = layout.Folders[1], layout.Folders[0]
+	layout.Folders[2].Routines = append(layout.Folders[2].Routines, layout.Unfiled[0])
+	layout.Unfiled = layout.Unfiled[1:]
+
+	plan := layout.Plan()
+	assert.Equal(t, []string{"2", "1", "3"}, plan.Order)
+	require.Len(t, plan.Moves, 1)
+	assert.Equal(t, "c", plan.Moves[0].Routine.ID)
+	assert.Equal(t, "3", plan.Moves[0].Folder.ID)
+}
+
+// fakeAPI records requests and answers routine and folder updates
+type fakeAPI struct {
+	t *testing.T
+	// ignoreFolder makes routine updates keep their old folder
+	ignoreFolder bool
+	// ignoreIndex makes folder updates keep their old index
+	ignoreIndex bool
+	requests    []string
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodPut && r.URL.Path == "/routines/a":
+		var req api.UpdateRoutineRequest
+		require.NoError(f.t, json.Unmarshal(body, &req))
+		routine := api.Routine{ID: "a", Title: req.Routine.Title, FolderID: strPtr("1")}
+		if !f.ignoreFolder && req.Routine.FolderID != nil {
+			routine.FolderID = strPtr("3")
+		}
+		json.NewEncoder(w).Encode(map[string]any{"routine": routine})
+	case r.Method == http.MethodPost && r.URL.Path == "/routines":
+		var req api.CreateRoutineRequest
+		require.NoError(f.t, json.Unmarshal(body, &req))
+		assert.Equal(f.t, 3, *req.Routine.FolderID)
+		json.NewEncoder(w).Encode(map[string]any{"routine": api.Routine{ID: "copy", Title: req.Routine.Title, FolderID: strPtr("3")}})
+	case r.Method == http.MethodDelete && r.URL.Path == "/routines/a":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPut:
+		var req api.UpdateRoutineFolderRequest
+		require.NoError(f.t, json.Unmarshal(body, &req))
+		index := *req.RoutineFolder.Index
+		if f.ignoreIndex {
+			index = -1
+		}
+		json.NewEncoder(w).Encode(map[string]any{"routine_folder": api.RoutineFolder{ID: r.URL.Path[len("/routine_folders/"):], Title: req.RoutineFolder.Title, Index: index}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFake(t *testing.T, fake *fakeAPI) (*api.Client, *journal.Journal) {
+	fake.t = t
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return api.NewClient("key", api.WithBaseURL(server.URL)), journal.Open(t.TempDir())
+}
+
+func TestMoveRoutine_Updated(t *testing.T) {
+	fake := &fakeAPI{}
+	client, j := newFake(t, fake)
+	rec := j.Begin("move")
+
+	routine := &api.Routine{ID: "a", Title: "Bench", FolderID: strPtr("1")}
+	moved, method, err := MoveRoutine(client, rec, routine, "3")
+	require.NoError(t, err)
+	assert.Equal(t, MethodUpdated, method)
+	assert.Equal(t, "a", moved.ID)
+	assert.Equal(t, []string{"PUT /routines/a"}, fake.requests)
+
+	op, err := j.Get(rec.ID())
+	require.NoError(t, err)
+	require.Len(t, op.Changes, 1)
+	assert.Equal(t, journal.ActionUpdate, op.Changes[0].Action)
+
+	_, method, err = MoveRoutine(client, rec, moved, "3")
+	require.NoError(t, err)
+	assert.Equal(t, MethodUnchanged, method)
+}
+
+func TestMoveRoutine_CopiedWhenIgnored(t *testing.T) {
+	fake := &fakeAPI{ignoreFolder: true}
+	client, j := newFake(t, fake)
+	rec := j.Begin("move")
+
+	routine := &api.Routine{ID: "a", Title: "Bench", FolderID: strPtr("1")}
+	moved, method, err := MoveRoutine(client, rec, routine, "3")
+	require.NoError(t, err)
+	assert.Equal(t, MethodCopied, method)
+	assert.Equal(t, "copy", moved.ID)
+	assert.Equal(t, []string{"PUT /routines/a", "POST /routines", "DELETE /routines/a"}, fake.requests)
+
+	op, err := j.Get(rec.ID())
+	require.NoError(t, err)
+	require.Len(t, op.Changes, 2)
+	assert.Equal(t, journal.ActionCreate, op.Changes[0].Action)
+	assert.Equal(t, "copy", op.Changes[0].ID)
+	assert.Equal(t, journal.ActionDelete, op.Changes[1].Action)
+	assert.Equal(t, "a", op.Changes[1].ID)
+}
+
+func TestApplyOrder(t *testing.T) {
+	fake := &fakeAPI{}
+	client, j := newFake(t, fake)
+
+	ordered, err := Order(testFolders(), []string{"2"})
+	require.NoError(t, err)
+	result, err := ApplyOrder(client, j.Begin("reorder"), testFolders(), ordered)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "1", "3"}, folderIDs(result))
+	// Legs keeps its index and is not updated
+	assert.Equal(t, []string{"PUT /routine_folders/2", "PUT /routine_folders/1"}, fake.requests)
+
+	fake = &fakeAPI{ignoreIndex: true}
+	client, j = newFake(t, fake)
+	_, err = ApplyOrder(client, j.Begin("reorder"), testFolders(), ordered)
+	assert.ErrorIs(t, err, ErrOrderUnsupported)
+	ops, err := j.List()
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+}
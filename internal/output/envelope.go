@@ -0,0 +1,93 @@
+package output
+
+import (
+	"reflect"
+
+	"github.com/obay/hevycli/internal/schema"
+)
+
+// Envelope wraps JSON and YAML output with the schema it follows
+type Envelope struct {
+	Schema string                 `json:"schema"`
+	Data   interface{}            `json:"data"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+var envelopeSettings struct {
+	name    string
+	version int
+}
+
+func init() {
+	schema.RegisterDocument(schema.ErrorName, "Error output", ErrorResponse{})
+}
+
+// SetSchema selects the schema name and version of the running command.
+// An empty name or the legacy version disables the envelope.
+func SetSchema(name string, version int) {
+	envelopeSettings.name = name
+	envelopeSettings.version = version
+}
+
+// SetSchemaName replaces the schema name of the running command, for flags
+// that change the shape of its output
+func SetSchemaName(name string) {
+	if envelopeSettings.name != "" {
+		envelopeSettings.name = name
+	}
+}
+
+// enveloped reports whether output is wrapped in an envelope
+func enveloped() bool {
+	return envelopeSettings.name != "" && envelopeSettings.version != schema.Legacy
+}
+
+// wrap puts data in an envelope. Lists given as an object holding a single
+// list, such as {"workouts": [...], "count": 10}, become the data and the
+// other fields become meta; a "pagination" object is merged into meta.
+func wrap(data interface{}) interface{} {
+	if !enveloped() {
+		return data
+	}
+	if _, ok := data.(Envelope); ok {
+		return data
+	}
+
+	env := Envelope{
+		Schema: schema.ID(envelopeSettings.name, envelopeSettings.version),
+		Data:   data,
+	}
+
+	if m, ok := data.(map[string]interface{}); ok {
+		listKey := ""
+		lists := 0
+		for k, v := range m {
+			if v != nil && reflect.TypeOf(v).Kind() == reflect.Slice {
+				listKey = k
+				lists++
+			}
+		}
+		if lists == 1 {
+			env.Data = m[listKey]
+			env.Meta = map[string]interface{}{}
+			for k, v := range m {
+				if k == listKey {
+					continue
+				}
+				if pagination, ok := v.(map[string]interface{}); ok && k == "pagination" {
+					for pk, pv := range pagination {
+						env.Meta[pk] = pv
+					}
+					continue
+				}
+				env.Meta[k] = v
+			}
+		}
+	}
+
+	// Empty lists are [] rather than null
+	if v := reflect.ValueOf(env.Data); v.Kind() == reflect.Slice && v.IsNil() {
+		env.Data = []interface{}{}
+	}
+	return env
+}
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withSchema(t *testing.T, name string, version int) {
+	t.Helper()
+	SetSchema(name, version)
+	t.Cleanup(func() { SetSchema("", 0) })
+}
+
+func TestJSONFormatter_Envelope(t *testing.T) {
+	withSchema(t, "workout-list", 1)
+
+	out, err := NewJSONFormatter(Options{}).Format(map[string]interface{}{
+		"workouts": []string{"a", "b"},
+		"count":    2,
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schema":"hevycli/workout-list@1","data":["a","b"],"meta":{"count":2}}`, out)
+}
+
+func TestJSONFormatter_EnvelopeObject(t *testing.T) {
+	withSchema(t, "workout-get", 1)
+
+	out, err := NewJSONFormatter(Options{}).Format(testItem{ID: "a1", Title: "Push"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schema":"hevycli/workout-get@1","data":{"id":"a1","title":"Push","weight_kg":null}}`, out)
+}
+
+func TestSetSchemaName(t *testing.T) {
+	withSchema(t, "folder-list", 1)
+	SetSchemaName("folder-list-tree")
+
+	out, err := NewJSONFormatter(Options{}).Format(testItem{ID: "a1", Title: "Push"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `"schema": "hevycli/folder-list-tree@1"`)
+
+	// Without an envelope there is nothing to rename
+	withSchema(t, "", 0)
+	SetSchemaName("folder-list-tree")
+	assert.False(t, enveloped())
+}
+
+func TestJSONFormatter_EnvelopePagination(t *testing.T) {
+	withSchema(t, "workout-events", 1)
+
+	var events []string
+	out, err := NewJSONFormatter(Options{}).Format(map[string]interface{}{
+		"events":     events,
+		"pagination": map[string]interface{}{"page": 1, "page_count": 3},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schema":"hevycli/workout-events@1","data":[],"meta":{"page":1,"page_count":3}}`, out)
+}
+
+func TestJSONFormatter_LegacyVersion(t *testing.T) {
+	withSchema(t, "workout-list", 0)
+
+	data := map[string]interface{}{"workouts": []string{"a"}, "count": 1}
+	out, err := NewJSONFormatter(Options{}).Format(data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"workouts":["a"],"count":1}`, out)
+
+	assert.NotContains(t, NewJSONFormatter(Options{}).FormatError(errors.New("boom")), "schema")
+}
+
+func TestJSONFormatter_ErrorEnvelope(t *testing.T) {
+	withSchema(t, "workout-get", 1)
+
+	out := NewJSONFormatter(Options{}).FormatError(errors.New("boom"))
+	assert.Contains(t, out, `"schema": "hevycli/error@1"`)
+	assert.Contains(t, out, `"message": "boom"`)
+}
+
+func TestYAMLFormatter_Envelope(t *testing.T) {
+	withSchema(t, "alias-list", 1)
+
+	out, err := NewYAMLFormatter(Options{}).Format([]string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, "schema: hevycli/alias-list@1\ndata:\n  - a", out)
+}
+
+func TestNDJSONFormatter_NoEnvelope(t *testing.T) {
+	withSchema(t, "workout-list", 1)
+
+	out, err := NewNDJSONFormatter(Options{}).Format(map[string]interface{}{"workouts": []string{"a", "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, "\"a\"\n\"b\"", out)
+}
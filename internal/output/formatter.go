@@ -3,6 +3,7 @@ package output
 import (
 	"io"
 	"os"
+	"strings"
 )
 
 // Formatter defines the interface for output formatting
@@ -21,6 +22,14 @@ const (
 	FormatJSON  FormatType = "json"
 	FormatTable FormatType = "table"
 	FormatPlain FormatType = "plain"
+	FormatYAML  FormatType = "yaml"
+	FormatCSV   FormatType = "csv"
+
+	// FormatNDJSON writes one JSON object per line for each list item
+	FormatNDJSON FormatType = "ndjson"
+
+	// FormatTemplate is selected with "template=<name or text>"
+	FormatTemplate FormatType = "template"
 )
 
 // Options holds formatting options
@@ -44,11 +53,21 @@ func NewFormatter(opts Options) Formatter {
 		opts.Writer = os.Stdout
 	}
 
+	if text, ok := strings.CutPrefix(string(opts.Format), TemplatePrefix); ok {
+		return NewTemplateFormatter(opts, text)
+	}
+
 	switch opts.Format {
 	case FormatJSON:
 		return NewJSONFormatter(opts)
 	case FormatPlain:
 		return NewPlainFormatter(opts)
+	case FormatYAML:
+		return NewYAMLFormatter(opts)
+	case FormatNDJSON:
+		return NewNDJSONFormatter(opts)
+	case FormatCSV:
+		return NewCSVFormatter(opts)
 	default:
 		return NewTableFormatter(opts)
 	}
@@ -1,10 +1,15 @@
 package output
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
 )
 
 func TestNewFormatter(t *testing.T) {
@@ -28,6 +33,21 @@ func TestNewFormatter(t *testing.T) {
 			format:     FormatPlain,
 			expectType: "*output.PlainFormatter",
 		},
+		{
+			name:       "yaml formatter",
+			format:     FormatYAML,
+			expectType: "*output.YAMLFormatter",
+		},
+		{
+			name:       "ndjson formatter",
+			format:     FormatNDJSON,
+			expectType: "*output.NDJSONFormatter",
+		},
+		{
+			name:       "csv formatter",
+			format:     FormatCSV,
+			expectType: "*output.CSVFormatter",
+		},
 		{
 			name:       "default is table",
 			format:     "",
@@ -81,6 +101,34 @@ func TestJSONFormatter_FormatError(t *testing.T) {
 	result := f.FormatError(assert.AnError)
 	assert.Contains(t, result, `"error"`)
 	assert.Contains(t, result, `"message"`)
+	assert.Contains(t, result, `"code": "GENERAL_ERROR"`)
+	assert.Contains(t, result, `"exit_code": 1`)
+}
+
+func TestJSONFormatter_FormatAPIError(t *testing.T) {
+	f := NewJSONFormatter(Options{})
+
+	err := fmt.Errorf("failed to create workout: %w", &api.APIError{
+		ErrorCode:    "VALIDATION_ERROR",
+		ErrorMessage: "Invalid workout",
+		StatusCode:   400,
+		RequestID:    "req-1",
+		Fields:       []api.FieldError{{Field: "title", Message: "is required"}},
+	})
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal([]byte(f.FormatError(err)), &resp))
+	assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+	assert.Equal(t, 400, resp.Error.Status)
+	assert.Equal(t, "req-1", resp.Error.RequestID)
+	assert.Equal(t, api.ExitValidationError, resp.Error.ExitCode)
+	assert.False(t, resp.Error.Retryable)
+	assert.Equal(t, []api.FieldError{{Field: "title", Message: "is required"}}, resp.Error.Fields)
+
+	rateLimited := &api.APIError{ErrorCode: "RATE_LIMITED", StatusCode: 429}
+	require.NoError(t, json.Unmarshal([]byte(f.FormatError(rateLimited)), &resp))
+	assert.True(t, resp.Error.Retryable)
+	assert.Equal(t, api.ExitRateLimited, resp.Error.ExitCode)
 }
 
 func TestPlainFormatter_Format(t *testing.T) {
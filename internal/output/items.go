@@ -0,0 +1,102 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// listItems returns the JSON encoding of each item in a list result.
+// Data that is a list yields its elements; an object holding exactly one
+// list alongside at most two other fields, such as {"workouts": [...],
+// "count": 10}, yields the elements of that list; anything else is a
+// single item. Table data yields one object per row keyed by header.
+func listItems(data interface{}) ([]json.RawMessage, error) {
+	if td, ok := data.(TableData); ok {
+		data = tableObjects(td)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		return nil, nil
+	case b[0] == '[':
+		var list []json.RawMessage
+		if err := json.Unmarshal(b, &list); err != nil {
+			return nil, err
+		}
+		return list, nil
+	case b[0] == '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return nil, err
+		}
+		var list json.RawMessage
+		lists := 0
+		for _, v := range fields {
+			if len(v) > 0 && v[0] == '[' {
+				list = v
+				lists++
+			}
+		}
+		if lists == 1 && len(fields) <= 3 {
+			var items []json.RawMessage
+			if err := json.Unmarshal(list, &items); err != nil {
+				return nil, err
+			}
+			return items, nil
+		}
+	}
+	return []json.RawMessage{b}, nil
+}
+
+// tableObjects converts table rows to objects keyed by column header
+func tableObjects(td TableData) []map[string]string {
+	headers := td.Headers()
+	rows := make([]map[string]string, 0, len(td.Rows()))
+	for _, row := range td.Rows() {
+		m := make(map[string]string, len(headers))
+		for i, h := range headers {
+			if i < len(row) {
+				m[h] = row[i]
+			}
+		}
+		rows = append(rows, m)
+	}
+	return rows
+}
+
+// objectKeys returns the keys of a JSON object in the order they appear
+func objectKeys(raw json.RawMessage) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, nil
+	}
+
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object key %v", tok)
+		}
+		keys = append(keys, key)
+
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
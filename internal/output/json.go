@@ -2,7 +2,11 @@ package output
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/schema"
 )
 
 // JSONFormatter formats output as JSON
@@ -24,6 +28,7 @@ func (f *JSONFormatter) Format(data interface{}) (string, error) {
 	var b []byte
 	var err error
 
+	data = wrap(data)
 	if f.indent {
 		b, err = json.MarshalIndent(data, "", "  ")
 	} else {
@@ -38,6 +43,19 @@ func (f *JSONFormatter) Format(data interface{}) (string, error) {
 
 // FormatError formats an error as JSON
 func (f *JSONFormatter) FormatError(err error) string {
+	errObj := errorResponse(err)
+
+	var b []byte
+	if f.indent {
+		b, _ = json.MarshalIndent(errObj, "", "  ")
+	} else {
+		b, _ = json.Marshal(errObj)
+	}
+	return string(b)
+}
+
+// errorResponse builds the structured form of an error
+func errorResponse(err error) ErrorResponse {
 	errObj := ErrorResponse{
 		Error: ErrorDetail{
 			Message:   err.Error(),
@@ -45,24 +63,49 @@ func (f *JSONFormatter) FormatError(err error) string {
 		},
 	}
 
-	// Try to extract code if it's an APIError
-	if apiErr, ok := err.(interface{ Code() string }); ok {
-		errObj.Error.Code = apiErr.Code()
+	errObj.Error.Code = "GENERAL_ERROR"
+	errObj.Error.ExitCode = api.ExitGeneralError
+
+	var coded interface{ Code() string }
+	if errors.As(err, &coded) {
+		errObj.Error.Code = coded.Code()
+	}
+	var exiter interface{ ExitCode() int }
+	if errors.As(err, &exiter) {
+		errObj.Error.ExitCode = exiter.ExitCode()
+	}
+	if apiErr, ok := api.AsAPIError(err); ok {
+		errObj.Error.Details = apiErr.ErrorDetails
+		errObj.Error.Status = apiErr.StatusCode
+		errObj.Error.RequestID = apiErr.RequestID
+		errObj.Error.Retryable = apiErr.Retryable()
+		errObj.Error.Fields = apiErr.Fields
 	}
 
-	b, _ := json.MarshalIndent(errObj, "", "  ")
-	return string(b)
+	if enveloped() {
+		errObj.Schema = schema.ID(schema.ErrorName, envelopeSettings.version)
+	}
+	return errObj
 }
 
 // ErrorResponse represents a JSON error response
 type ErrorResponse struct {
-	Error ErrorDetail `json:"error"`
+	Schema string      `json:"schema,omitempty"`
+	Error  ErrorDetail `json:"error"`
 }
 
 // ErrorDetail contains error details
 type ErrorDetail struct {
-	Code      string `json:"code,omitempty"`
-	Message   string `json:"message"`
-	Details   string `json:"details,omitempty"`
-	Timestamp string `json:"timestamp"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+	// Status is the HTTP status of a failed API request
+	Status    int    `json:"status,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// ExitCode is the process exit code
+	ExitCode int `json:"exit_code"`
+	// Retryable is true when the request may succeed if retried later
+	Retryable bool             `json:"retryable"`
+	Fields    []api.FieldError `json:"fields,omitempty"`
+	Timestamp string           `json:"timestamp"`
 }
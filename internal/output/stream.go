@@ -0,0 +1,206 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Stream writes the items of a list one at a time, so long listings can
+// be printed as pages arrive instead of being buffered
+type Stream interface {
+	// Write outputs a single item
+	Write(item interface{}) error
+	// Flush writes any buffered output
+	Flush() error
+}
+
+// NewStream creates a stream for formats that support it (ndjson and csv).
+// It reports false for formats that need the whole result at once.
+func NewStream(opts Options) (Stream, bool) {
+	if opts.Writer == nil {
+		opts.Writer = os.Stdout
+	}
+
+	switch opts.Format {
+	case FormatNDJSON:
+		return &ndjsonStream{w: opts.Writer}, true
+	case FormatCSV:
+		return &csvStream{w: csv.NewWriter(opts.Writer)}, true
+	default:
+		return nil, false
+	}
+}
+
+// ndjsonStream writes one compact JSON object per line
+type ndjsonStream struct {
+	w io.Writer
+}
+
+func (s *ndjsonStream) Write(item interface{}) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
+
+func (s *ndjsonStream) Flush() error {
+	return nil
+}
+
+// csvStream writes one row per item. The columns are the fields of the
+// first item; nested values are written as compact JSON.
+type csvStream struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (s *csvStream) Write(item interface{}) error {
+	raw, ok := item.(json.RawMessage)
+	if !ok {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		raw = b
+	}
+
+	if s.columns == nil {
+		columns, err := objectKeys(raw)
+		if err != nil {
+			return err
+		}
+		if columns == nil {
+			columns = []string{"value"}
+		}
+		s.columns = columns
+		if err := s.w.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	var fields map[string]json.RawMessage
+	if raw[0] == '{' {
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+	} else {
+		fields = map[string]json.RawMessage{"value": raw}
+	}
+
+	row := make([]string, len(s.columns))
+	for i, c := range s.columns {
+		row[i] = csvValue(fields[c])
+	}
+	return s.w.Write(row)
+}
+
+func (s *csvStream) Flush() error {
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// csvValue formats a JSON value for a CSV cell
+func csvValue(raw json.RawMessage) string {
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+		return ""
+	case raw[0] == '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+	case raw[0] == '{' || raw[0] == '[':
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, raw); err == nil {
+			return buf.String()
+		}
+	}
+	return string(raw)
+}
+
+// NDJSONFormatter formats lists as newline-delimited JSON, one compact
+// object per line
+type NDJSONFormatter struct {
+	opts Options
+}
+
+// NewNDJSONFormatter creates a new NDJSON formatter
+func NewNDJSONFormatter(opts Options) *NDJSONFormatter {
+	return &NDJSONFormatter{opts: opts}
+}
+
+// Format converts each item of a list to a line of JSON
+func (f *NDJSONFormatter) Format(data interface{}) (string, error) {
+	items, err := listItems(data)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	s := &ndjsonStream{w: &buf}
+	for _, item := range items {
+		if err := s.Write(item); err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// FormatError formats an error as a single line of JSON
+func (f *NDJSONFormatter) FormatError(err error) string {
+	return (&JSONFormatter{opts: f.opts}).FormatError(err)
+}
+
+// CSVFormatter formats tables and lists as CSV
+type CSVFormatter struct {
+	opts Options
+}
+
+// NewCSVFormatter creates a new CSV formatter
+func NewCSVFormatter(opts Options) *CSVFormatter {
+	return &CSVFormatter{opts: opts}
+}
+
+// Format converts data to CSV. Table data keeps its headers and rows;
+// other data has a row per list item and a column per JSON field.
+func (f *CSVFormatter) Format(data interface{}) (string, error) {
+	var buf bytes.Buffer
+
+	if td, ok := data.(TableData); ok {
+		w := csv.NewWriter(&buf)
+		if err := w.Write(td.Headers()); err != nil {
+			return "", err
+		}
+		if err := w.WriteAll(td.Rows()); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(buf.String(), "\n"), nil
+	}
+
+	items, err := listItems(data)
+	if err != nil {
+		return "", err
+	}
+	s := &csvStream{w: csv.NewWriter(&buf)}
+	for _, item := range items {
+		if err := s.Write(item); err != nil {
+			return "", err
+		}
+	}
+	if err := s.Flush(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// FormatError formats an error as plain text
+func (f *CSVFormatter) FormatError(err error) string {
+	return fmt.Sprintf("Error: %s", err.Error())
+}
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Weight *float64 `json:"weight_kg"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+func testItems() map[string]interface{} {
+	w := 102.5
+	return map[string]interface{}{
+		"items": []testItem{
+			{ID: "a1", Title: "Push, Day", Weight: &w, Tags: []string{"upper"}},
+			{ID: "b2", Title: "Legs"},
+		},
+		"count": 2,
+	}
+}
+
+func TestYAMLFormatter_Format(t *testing.T) {
+	out, err := NewYAMLFormatter(Options{}).Format(testItems())
+	require.NoError(t, err)
+
+	assert.Equal(t, `count: 2
+items:
+  - id: a1
+    title: Push, Day
+    weight_kg: 102.5
+    tags:
+      - upper
+  - id: b2
+    title: Legs
+    weight_kg: null`, out)
+}
+
+func TestYAMLFormatter_QuotesAmbiguousStrings(t *testing.T) {
+	out, err := NewYAMLFormatter(Options{}).Format(map[string]string{"a": "true", "b": "10"})
+	require.NoError(t, err)
+	assert.Equal(t, "a: \"true\"\nb: \"10\"", out)
+}
+
+func TestYAMLFormatter_FormatError(t *testing.T) {
+	out := NewYAMLFormatter(Options{}).FormatError(errors.New("boom"))
+	assert.Contains(t, out, "error:\n  code: GENERAL_ERROR\n  message: boom")
+}
+
+func TestNDJSONFormatter_Format(t *testing.T) {
+	out, err := NewNDJSONFormatter(Options{}).Format(testItems())
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"id":"a1","title":"Push, Day","weight_kg":102.5,"tags":["upper"]}
+{"id":"b2","title":"Legs","weight_kg":null}`, out)
+}
+
+func TestNDJSONFormatter_SingleObject(t *testing.T) {
+	out, err := NewNDJSONFormatter(Options{}).Format(testItem{ID: "a1", Title: "Push"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"a1","title":"Push","weight_kg":null}`, out)
+}
+
+func TestNDJSONFormatter_FormatError(t *testing.T) {
+	out := NewNDJSONFormatter(Options{}).FormatError(errors.New("boom"))
+	assert.NotContains(t, out, "\n")
+	assert.Contains(t, out, `"message":"boom"`)
+}
+
+func TestCSVFormatter_Format(t *testing.T) {
+	out, err := NewCSVFormatter(Options{}).Format(testItems())
+	require.NoError(t, err)
+
+	assert.Equal(t, `id,title,weight_kg,tags
+a1,"Push, Day",102.5,"[""upper""]"
+b2,Legs,,`, out)
+}
+
+func TestCSVFormatter_FormatTable(t *testing.T) {
+	data := &testTableData{
+		headers: []string{"Name", "Reps"},
+		rows:    [][]string{{"Squat", "5"}, {"Bench, Flat", "8"}},
+	}
+
+	out, err := NewCSVFormatter(Options{}).Format(data)
+	require.NoError(t, err)
+	assert.Equal(t, "Name,Reps\nSquat,5\n\"Bench, Flat\",8", out)
+}
+
+func TestNewStream(t *testing.T) {
+	_, ok := NewStream(Options{Format: FormatJSON})
+	assert.False(t, ok)
+	_, ok = NewStream(Options{Format: FormatTable})
+	assert.False(t, ok)
+
+	var buf bytes.Buffer
+	s, ok := NewStream(Options{Format: FormatCSV, Writer: &buf})
+	require.True(t, ok)
+	require.NoError(t, s.Write(testItem{ID: "a1", Title: "Push"}))
+	require.NoError(t, s.Write(testItem{ID: "b2", Title: "Legs", Tags: []string{"lower"}}))
+	require.NoError(t, s.Flush())
+
+	// Columns come from the first item
+	assert.Equal(t, "id,title,weight_kg\na1,Push,\nb2,Legs,\n", buf.String())
+
+	buf.Reset()
+	s, ok = NewStream(Options{Format: FormatNDJSON, Writer: &buf})
+	require.True(t, ok)
+	require.NoError(t, s.Write(testItem{ID: "a1"}))
+	assert.Equal(t, `{"id":"a1","title":"","weight_kg":null}`+"\n", buf.String())
+}
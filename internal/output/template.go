@@ -0,0 +1,517 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/obay/hevycli/internal/analysis"
+)
+
+// TemplatePrefix selects template output, e.g. "-o template=one-line"
+const TemplatePrefix = "template="
+
+// TemplateSettings holds the display preferences used by template helpers
+type TemplateSettings struct {
+	// Units is "metric" or "imperial"
+	Units string
+	// DateFormat and TimeFormat are Go time layouts
+	DateFormat string
+	TimeFormat string
+	// Location is the timezone dates are shown in
+	Location *time.Location
+	// Dir holds user-defined named templates (<name>.tmpl)
+	Dir string
+}
+
+var templateSettings = TemplateSettings{
+	Units:      "metric",
+	DateFormat: "2006-01-02",
+	TimeFormat: "15:04",
+	Location:   time.Local,
+}
+
+// SetTemplateSettings sets the display preferences used by template helpers
+func SetTemplateSettings(s TemplateSettings) {
+	if s.Units == "" {
+		s.Units = "metric"
+	}
+	if s.DateFormat == "" {
+		s.DateFormat = "2006-01-02"
+	}
+	if s.TimeFormat == "" {
+		s.TimeFormat = "15:04"
+	}
+	if s.Location == nil {
+		s.Location = time.Local
+	}
+	templateSettings = s
+}
+
+// IsStructured reports whether an output format renders the command's data
+// rather than a table
+func IsStructured(format string) bool {
+	switch FormatType(format) {
+	case FormatJSON, FormatYAML, FormatNDJSON, FormatCSV:
+		return true
+	}
+	return strings.HasPrefix(format, TemplatePrefix)
+}
+
+// TemplateFormatter renders data through a Go text/template
+type TemplateFormatter struct {
+	opts Options
+	text string
+}
+
+// NewTemplateFormatter creates a formatter for a template.
+// text is either the name of a built-in or user-defined template, or the
+// template itself.
+func NewTemplateFormatter(opts Options, text string) *TemplateFormatter {
+	return &TemplateFormatter{opts: opts, text: text}
+}
+
+// Format renders data through the template. The template sees the same
+// fields as JSON output, so {{.title}} and {{range .workouts}} work as
+// they appear in -o json. Table data is passed as a list of rows keyed by
+// column header.
+func (f *TemplateFormatter) Format(data interface{}) (string, error) {
+	text, err := resolveTemplate(f.text)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("output").Funcs(TemplateFuncs()).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+
+	value, err := templateData(data)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, value); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// FormatError formats an error as plain text
+func (f *TemplateFormatter) FormatError(err error) string {
+	return fmt.Sprintf("Error: %s", err.Error())
+}
+
+// resolveTemplate returns the text of a named template, or text itself
+func resolveTemplate(text string) (string, error) {
+	if strings.ContainsAny(text, "{}\n") {
+		return text, nil
+	}
+	if templateSettings.Dir != "" {
+		data, err := os.ReadFile(filepath.Join(templateSettings.Dir, text+".tmpl"))
+		if err == nil {
+			return string(data), nil
+		}
+	}
+	if builtin, ok := builtinTemplates[text]; ok {
+		return builtin, nil
+	}
+	return "", fmt.Errorf("unknown template: %s (built-in templates: %s)", text, strings.Join(TemplateNames(), ", "))
+}
+
+// templateData converts data to the generic form seen in JSON output
+func templateData(data interface{}) (interface{}, error) {
+	if td, ok := data.(TableData); ok {
+		data = tableObjects(td)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// TemplateNames returns the names of the built-in templates
+func TemplateNames() []string {
+	names := make([]string, 0, len(builtinTemplates))
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// builtinTemplates are the named templates available with -o template=<name>
+var builtinTemplates = map[string]string{
+	"one-line": `{{- range items . -}}
+{{ with .start_time }}{{ date . }}  {{ end }}{{ or .title .name .id }}
+{{- with .exercises }}  · {{ plural (len .) "exercise" }}{{ end }}
+{{- if and .start_time .end_time }}  · {{ duration .start_time .end_time }}{{ end }}
+{{- with .id }}  [{{ . }}]{{ end }}
+{{ end -}}`,
+
+	"markdown-log": `{{- range items . -}}
+## {{ .title }}{{ with .start_time }} — {{ date . }}{{ end }}
+{{ with .description }}
+_{{ . }}_
+{{ end }}
+{{ if .start_time }}**Duration:** {{ duration .start_time .end_time }} · {{ end }}**Volume:** {{ weight (volume .) }}
+{{ range .exercises }}
+### {{ .title }}
+{{ with .notes }}_{{ . }}_
+{{ end }}{{ range .sets }}- {{ set . }}
+{{ end }}{{ end }}
+{{ end -}}`,
+
+	"markdown-table": `{{- $rows := items . -}}
+{{- with $rows }}{{ $first := index . 0 }}{{ $keys := keys $first -}}
+|{{ range $keys }} {{ . }} |{{ end }}
+|{{ range $keys }} --- |{{ end }}
+{{ range $rows }}{{ $row := . }}|{{ range $keys }} {{ cell (index $row .) }} |{{ end }}
+{{ end }}{{ end -}}`,
+}
+
+// TemplateFuncs returns the helper functions available to templates
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// Data
+		"items":  items,
+		"keys":   keys,
+		"cell":   cell,
+		"json":   toJSON,
+		"volume": volume,
+		"set":    formatSet,
+
+		// Units and numbers
+		"weight":  formatWeight,
+		"kg":      func(v interface{}) string { return trimFloat(roundTo(toFloat(v), 2)) + " kg" },
+		"lb":      func(v interface{}) string { return trimFloat(roundTo(toFloat(v)/analysis.KgPerLb, 1)) + " lb" },
+		"round":   func(places int, v interface{}) float64 { return roundTo(toFloat(v), places) },
+		"add":     func(a, b interface{}) float64 { return toFloat(a) + toFloat(b) },
+		"sub":     func(a, b interface{}) float64 { return toFloat(a) - toFloat(b) },
+		"mul":     func(a, b interface{}) float64 { return toFloat(a) * toFloat(b) },
+		"div":     divide,
+		"plural":  plural,
+		"seconds": func(v interface{}) string { return formatDuration(time.Duration(toFloat(v) * float64(time.Second))) },
+
+		// Durations and dates
+		"duration": durationBetween,
+		"date":     func(v interface{}) string { return formatTime(v, templateSettings.DateFormat) },
+		"time":     func(v interface{}) string { return formatTime(v, templateSettings.TimeFormat) },
+		"datetime": func(v interface{}) string {
+			return formatTime(v, templateSettings.DateFormat+" "+templateSettings.TimeFormat)
+		},
+		"formatDate": func(layout string, v interface{}) string { return formatTime(v, layout) },
+
+		// Strings and padding
+		"pad":     func(n int, v interface{}) string { return fmt.Sprintf("%-*s", n, toString(v)) },
+		"padLeft": func(n int, v interface{}) string { return fmt.Sprintf("%*s", n, toString(v)) },
+		"trunc":   truncate,
+		"upper":   func(v interface{}) string { return strings.ToUpper(toString(v)) },
+		"lower":   func(v interface{}) string { return strings.ToLower(toString(v)) },
+		"repeat":  func(n int, s string) string { return strings.Repeat(s, max(n, 0)) },
+		"join":    join,
+		"default": func(def, v interface{}) interface{} {
+			if isEmpty(v) {
+				return def
+			}
+			return v
+		},
+	}
+}
+
+// items returns the list in data: data itself if it is a list, the only
+// list-valued field of an object (e.g. workouts in workout list), or data
+// wrapped in a list
+func items(data interface{}) []interface{} {
+	switch v := data.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		var list []interface{}
+		lists := 0
+		for _, field := range v {
+			if l, ok := field.([]interface{}); ok {
+				list = l
+				lists++
+			}
+		}
+		if lists == 1 && len(v) <= 3 {
+			return list
+		}
+		return []interface{}{v}
+	case nil:
+		return nil
+	default:
+		return []interface{}{v}
+	}
+}
+
+// keys returns the sorted keys of an object
+func keys(data interface{}) []string {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cell formats a value for a single table cell
+func cell(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return toJSON(v)
+	default:
+		return strings.ReplaceAll(toString(v), "|", "\\|")
+	}
+}
+
+func toJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// volume returns the total weight × reps of a workout, exercise or set list
+func volume(v interface{}) float64 {
+	var total float64
+	switch d := v.(type) {
+	case map[string]interface{}:
+		if exercises, ok := d["exercises"].([]interface{}); ok {
+			for _, ex := range exercises {
+				total += volume(ex)
+			}
+		} else if sets, ok := d["sets"].([]interface{}); ok {
+			total += volume(sets)
+		} else if d["weight_kg"] != nil && d["reps"] != nil {
+			total += toFloat(d["weight_kg"]) * toFloat(d["reps"])
+		}
+	case []interface{}:
+		for _, item := range d {
+			total += volume(item)
+		}
+	}
+	return total
+}
+
+// formatSet describes a set, e.g. "100 kg × 5 @ RPE 8 (warmup)"
+func formatSet(v interface{}) string {
+	s, ok := v.(map[string]interface{})
+	if !ok {
+		return toString(v)
+	}
+
+	var parts []string
+	switch {
+	case s["weight_kg"] != nil && s["reps"] != nil:
+		parts = append(parts, fmt.Sprintf("%s × %s", formatWeight(s["weight_kg"]), toString(s["reps"])))
+	case s["reps"] != nil:
+		parts = append(parts, toString(s["reps"])+" reps")
+	case s["weight_kg"] != nil:
+		parts = append(parts, formatWeight(s["weight_kg"]))
+	}
+	if s["distance_meters"] != nil {
+		parts = append(parts, trimFloat(toFloat(s["distance_meters"]))+" m")
+	}
+	if s["duration_seconds"] != nil {
+		parts = append(parts, formatDuration(time.Duration(toFloat(s["duration_seconds"])*float64(time.Second))))
+	}
+	if s["rpe"] != nil {
+		parts = append(parts, "@ RPE "+trimFloat(toFloat(s["rpe"])))
+	}
+	if t, _ := s["type"].(string); t != "" && t != "normal" {
+		parts = append(parts, "("+t+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatWeight formats kilograms in the configured units
+func formatWeight(v interface{}) string {
+	kg := toFloat(v)
+	if templateSettings.Units == "imperial" {
+		return trimFloat(roundTo(kg/analysis.KgPerLb, 1)) + " lb"
+	}
+	return trimFloat(roundTo(kg, 2)) + " kg"
+}
+
+// durationBetween formats the time between two timestamps, or a single
+// number of seconds or Go duration string
+func durationBetween(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		if s, ok := args[0].(string); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return "", err
+			}
+			return formatDuration(d), nil
+		}
+		return formatDuration(time.Duration(toFloat(args[0]) * float64(time.Second))), nil
+	case 2:
+		start, ok1 := toTime(args[0])
+		end, ok2 := toTime(args[1])
+		if !ok1 || !ok2 {
+			return "", nil
+		}
+		return formatDuration(end.Sub(start)), nil
+	default:
+		return "", fmt.Errorf("duration takes a number of seconds or two timestamps")
+	}
+}
+
+// formatDuration formats a duration as "1h 5m", "45m" or "30s"
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+func formatTime(v interface{}, layout string) string {
+	t, ok := toTime(v)
+	if !ok {
+		return toString(v)
+	}
+	return t.In(templateSettings.Location).Format(layout)
+}
+
+func toTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		parsed, err := time.Parse(time.RFC3339, t)
+		return parsed, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+func truncate(n int, v interface{}) string {
+	s := []rune(toString(v))
+	if len(s) <= n {
+		return string(s)
+	}
+	if n <= 3 {
+		return string(s[:n])
+	}
+	return string(s[:n-3]) + "..."
+}
+
+func join(sep string, v interface{}) string {
+	list, ok := v.([]interface{})
+	if !ok {
+		if strs, ok := v.([]string); ok {
+			return strings.Join(strs, sep)
+		}
+		return toString(v)
+	}
+	parts := make([]string, len(list))
+	for i, item := range list {
+		parts[i] = toString(item)
+	}
+	return strings.Join(parts, sep)
+}
+
+func plural(n interface{}, word string) string {
+	count := toFloat(n)
+	if count == 1 {
+		return "1 " + word
+	}
+	return trimFloat(count) + " " + word + "s"
+}
+
+func divide(a, b interface{}) float64 {
+	d := toFloat(b)
+	if d == 0 {
+		return 0
+	}
+	return toFloat(a) / d
+}
+
+func isEmpty(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case float64:
+		return t == 0
+	case bool:
+		return !t
+	case []interface{}:
+		return len(t) == 0
+	case map[string]interface{}:
+		return len(t) == 0
+	default:
+		return false
+	}
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case json.Number:
+		f, _ := n.Float64()
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(n, 64)
+		return f
+	default:
+		return 0
+	}
+}
+
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return trimFloat(s)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// trimFloat formats a number without trailing zeros
+func trimFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
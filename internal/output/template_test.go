@@ -0,0 +1,143 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSet struct {
+	Type     string   `json:"type"`
+	WeightKg *float64 `json:"weight_kg,omitempty"`
+	Reps     *int     `json:"reps,omitempty"`
+	RPE      *float64 `json:"rpe,omitempty"`
+}
+
+type testExercise struct {
+	Title string    `json:"title"`
+	Sets  []testSet `json:"sets"`
+}
+
+type testWorkout struct {
+	ID        string         `json:"id"`
+	Title     string         `json:"title"`
+	StartTime time.Time      `json:"start_time"`
+	EndTime   time.Time      `json:"end_time"`
+	Exercises []testExercise `json:"exercises"`
+}
+
+func floatPtr(v float64) *float64 { return &v }
+func intPtr(v int) *int           { return &v }
+
+func testWorkouts() map[string]interface{} {
+	start := time.Date(2026, 4, 13, 18, 0, 0, 0, time.UTC)
+	return map[string]interface{}{
+		"workouts": []testWorkout{{
+			ID:        "w1",
+			Title:     "Leg Day",
+			StartTime: start,
+			EndTime:   start.Add(75 * time.Minute),
+			Exercises: []testExercise{{
+				Title: "Squat",
+				Sets: []testSet{
+					{Type: "warmup", WeightKg: floatPtr(60), Reps: intPtr(5)},
+					{Type: "normal", WeightKg: floatPtr(100), Reps: intPtr(5), RPE: floatPtr(8.5)},
+				},
+			}},
+		}},
+		"count": 1,
+	}
+}
+
+func withTemplateSettings(t *testing.T, s TemplateSettings) {
+	t.Helper()
+	previous := templateSettings
+	SetTemplateSettings(s)
+	t.Cleanup(func() { templateSettings = previous })
+}
+
+func TestTemplateFormatter_Inline(t *testing.T) {
+	withTemplateSettings(t, TemplateSettings{Location: time.UTC})
+	f := NewFormatter(Options{Format: FormatType(TemplatePrefix + `{{range .workouts}}{{pad 10 .title}}|{{date .start_time}}|{{duration .start_time .end_time}}|{{weight (volume .)}}{{end}}`)})
+
+	out, err := f.Format(testWorkouts())
+	require.NoError(t, err)
+	assert.Equal(t, "Leg Day   |2026-04-13|1h 15m|800 kg", out)
+}
+
+func TestTemplateFormatter_Builtin(t *testing.T) {
+	withTemplateSettings(t, TemplateSettings{Location: time.UTC, Units: "imperial"})
+
+	out, err := NewTemplateFormatter(Options{}, "one-line").Format(testWorkouts())
+	require.NoError(t, err)
+	assert.Equal(t, "2026-04-13  Leg Day  · 1 exercise  · 1h 15m  [w1]", out)
+
+	out, err = NewTemplateFormatter(Options{}, "markdown-log").Format(testWorkouts())
+	require.NoError(t, err)
+	assert.Contains(t, out, "## Leg Day — 2026-04-13")
+	assert.Contains(t, out, "**Duration:** 1h 15m · **Volume:** 1763.7 lb")
+	assert.Contains(t, out, "### Squat")
+	assert.Contains(t, out, "- 132.3 lb × 5 (warmup)")
+	assert.Contains(t, out, "- 220.5 lb × 5 @ RPE 8.5")
+}
+
+func TestTemplateFormatter_TableData(t *testing.T) {
+	data := &testTableData{
+		headers: []string{"ID", "Name"},
+		rows:    [][]string{{"1", "First"}, {"2", "Second|Third"}},
+	}
+
+	out, err := NewTemplateFormatter(Options{}, "markdown-table").Format(data)
+	require.NoError(t, err)
+	assert.Equal(t, "| ID | Name |\n| --- | --- |\n| 1 | First |\n| 2 | Second\\|Third |", out)
+}
+
+func TestTemplateFormatter_Errors(t *testing.T) {
+	_, err := NewTemplateFormatter(Options{}, "no-such-template").Format(nil)
+	assert.ErrorContains(t, err, "unknown template")
+
+	_, err = NewTemplateFormatter(Options{}, "{{ .title ").Format(nil)
+	assert.ErrorContains(t, err, "invalid template")
+
+	_, err = NewTemplateFormatter(Options{}, "{{ duration 1 2 3 }}").Format(nil)
+	assert.ErrorContains(t, err, "failed to render template")
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	withTemplateSettings(t, TemplateSettings{Location: time.UTC})
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{`{{ "abcdefgh" | trunc 6 }}`, "abc..."},
+		{`{{ padLeft 5 "ab" }}|`, "   ab|"},
+		{`{{ seconds 3700 }}`, "1h 1m"},
+		{`{{ duration "90s" }}`, "1m"},
+		{`{{ plural 3 "set" }}`, "3 sets"},
+		{`{{ default "none" "" }}`, "none"},
+		{`{{ round 1 (div 10 3) }}`, "3.3"},
+		{`{{ lb 100 }} / {{ kg 100 }}`, "220.5 lb / 100 kg"},
+		{`{{ formatDate "Jan 2" "2026-04-13T18:00:00Z" }}`, "Apr 13"},
+		{`{{ join ", " .list }}`, "a, b"},
+		{`{{ json .list }}`, `["a","b"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			out, err := NewTemplateFormatter(Options{}, tt.tmpl).Format(map[string]interface{}{"list": []string{"a", "b"}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestIsStructured(t *testing.T) {
+	assert.True(t, IsStructured("json"))
+	assert.True(t, IsStructured("yaml"))
+	assert.True(t, IsStructured("ndjson"))
+	assert.True(t, IsStructured("csv"))
+	assert.True(t, IsStructured("template=one-line"))
+	assert.False(t, IsStructured("table"))
+	assert.False(t, IsStructured("plain"))
+}
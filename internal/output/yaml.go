@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFormatter formats output as YAML. Field names and order match the
+// JSON output.
+type YAMLFormatter struct {
+	opts Options
+}
+
+// NewYAMLFormatter creates a new YAML formatter
+func NewYAMLFormatter(opts Options) *YAMLFormatter {
+	return &YAMLFormatter{opts: opts}
+}
+
+// Format converts data to YAML
+func (f *YAMLFormatter) Format(data interface{}) (string, error) {
+	if td, ok := data.(TableData); ok {
+		data = tableObjects(td)
+	}
+
+	b, err := json.Marshal(wrap(data))
+	if err != nil {
+		return "", err
+	}
+	return jsonToYAML(b)
+}
+
+// FormatError formats an error as YAML
+func (f *YAMLFormatter) FormatError(err error) string {
+	b, jsonErr := json.Marshal(errorResponse(err))
+	if jsonErr != nil {
+		return fmt.Sprintf("Error: %s", err.Error())
+	}
+	out, yamlErr := jsonToYAML(b)
+	if yamlErr != nil {
+		return fmt.Sprintf("Error: %s", err.Error())
+	}
+	return out
+}
+
+// jsonToYAML converts a JSON document to YAML
+func jsonToYAML(b []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	node, err := yamlNode(dec)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// yamlNode reads the next JSON value from dec as a YAML node, keeping
+// object keys in order
+func yamlNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if t == '{' {
+			node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(key)})
+			}
+			child, err := yamlNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		// Consume the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	case json.Number:
+		tag := "!!float"
+		if _, err := t.Int64(); err == nil {
+			tag = "!!int"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(t)}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+}
@@ -0,0 +1,252 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+)
+
+// fieldKind is the value type of a field
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindNumber
+	kindWeight
+	kindDuration
+	kindWeekday
+	kindDate
+)
+
+// field describes a queryable property of a workout
+type field struct {
+	kind fieldKind
+	help string
+	// text returns the values of text fields; a condition matches if any value matches
+	text func(w *api.Workout, e *env) []string
+	// number returns the value of numeric fields (kg for weights, seconds for durations)
+	number func(w *api.Workout) float64
+}
+
+var fields = map[string]field{
+	"title": {kind: kindText, help: "workout title",
+		text: func(w *api.Workout, _ *env) []string {
+			return []string{w.Title}
+		}},
+	"notes": {kind: kindText, help: "workout description and exercise notes",
+		text: func(w *api.Workout, _ *env) []string {
+			notes := []string{w.Description}
+			for _, ex := range w.Exercises {
+				notes = append(notes, ex.Notes)
+			}
+			return notes
+		}},
+	"exercise": {kind: kindText, help: "exercise titles",
+		text: func(w *api.Workout, _ *env) []string {
+			titles := make([]string, len(w.Exercises))
+			for i, ex := range w.Exercises {
+				titles[i] = ex.Title
+			}
+			return titles
+		}},
+	"template": {kind: kindText, help: "exercise template IDs",
+		text: func(w *api.Workout, _ *env) []string {
+			ids := make([]string, len(w.Exercises))
+			for i, ex := range w.Exercises {
+				ids[i] = ex.ExerciseTemplateID
+			}
+			return ids
+		}},
+	"muscle": {kind: kindText, help: "primary and secondary muscle groups",
+		text: func(w *api.Workout, e *env) []string {
+			var muscles []string
+			for _, ex := range w.Exercises {
+				if t, ok := e.templates[ex.ExerciseTemplateID]; ok {
+					muscles = append(muscles, string(t.PrimaryMuscleGroup))
+					for _, m := range t.SecondaryMuscleGroups {
+						muscles = append(muscles, string(m))
+					}
+				}
+			}
+			return muscles
+		}},
+	"routine": {kind: kindText, help: "routine ID the workout was started from",
+		text: func(w *api.Workout, _ *env) []string {
+			return []string{w.RoutineID}
+		}},
+	"weekday": {kind: kindWeekday, help: "day of the week the workout started",
+		text: func(w *api.Workout, e *env) []string {
+			return []string{strings.ToLower(w.StartTime.In(e.loc).Weekday().String())}
+		}},
+	"date": {kind: kindDate, help: "start date, compared with date expressions"},
+	"duration": {kind: kindDuration, help: "workout duration (e.g. 45m, 1h30m)",
+		number: func(w *api.Workout) float64 {
+			return w.Duration().Seconds()
+		}},
+	"volume": {kind: kindWeight, help: "total weight × reps (e.g. 10000kg)",
+		number: func(w *api.Workout) float64 {
+			return analysis.WorkoutVolume(*w)
+		}},
+	"max_weight": {kind: kindWeight, help: "heaviest weight lifted",
+		number: func(w *api.Workout) float64 {
+			var max float64
+			for _, ex := range w.Exercises {
+				for _, s := range ex.Sets {
+					if s.WeightKg != nil && *s.WeightKg > max {
+						max = *s.WeightKg
+					}
+				}
+			}
+			return max
+		}},
+	"sets": {kind: kindNumber, help: "number of sets",
+		number: func(w *api.Workout) float64 {
+			return float64(w.TotalSets())
+		}},
+	"reps": {kind: kindNumber, help: "total reps",
+		number: func(w *api.Workout) float64 {
+			var reps int
+			for _, ex := range w.Exercises {
+				for _, s := range ex.Sets {
+					if s.Reps != nil {
+						reps += *s.Reps
+					}
+				}
+			}
+			return float64(reps)
+		}},
+	"exercises": {kind: kindNumber, help: "number of exercises",
+		number: func(w *api.Workout) float64 {
+			return float64(w.ExerciseCount())
+		}},
+}
+
+// fieldAliases maps alternative names to fields
+var fieldAliases = map[string]string{
+	"name":        "title",
+	"description": "notes",
+	"note":        "notes",
+	"muscles":     "muscle",
+	"day":         "weekday",
+	"weight":      "max_weight",
+}
+
+// FieldNames returns the sorted names of the queryable fields
+func FieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FieldHelp returns a description of the queryable fields for command help
+func FieldHelp() string {
+	var b strings.Builder
+	for i, name := range FieldNames() {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "  %-11s %s", name, fields[name].help)
+	}
+	return b.String()
+}
+
+// lookupField resolves a field name or alias
+func lookupField(name string) (string, field, bool) {
+	name = strings.ToLower(name)
+	if canonical, ok := fieldAliases[name]; ok {
+		name = canonical
+	}
+	f, ok := fields[name]
+	return name, f, ok
+}
+
+// validOps lists the operators each kind of field supports
+var validOps = map[fieldKind][]string{
+	kindText:     {"=", "!=", "~", "!~"},
+	kindWeekday:  {"=", "!="},
+	kindNumber:   {"=", "!=", ">", ">=", "<", "<="},
+	kindWeight:   {"=", "!=", ">", ">=", "<", "<="},
+	kindDuration: {"=", "!=", ">", ">=", "<", "<="},
+	kindDate:     {"=", "!=", ">", ">=", "<", "<="},
+}
+
+var unitPattern = regexp.MustCompile(`^(-?[0-9]*\.?[0-9]+)\s*([a-z]*)$`)
+
+// parseWeight parses a weight such as 100, 100kg or 225lb into kilograms
+func parseWeight(s string) (float64, error) {
+	m := unitPattern.FindStringSubmatch(strings.ToLower(s))
+	if m == nil {
+		return 0, fmt.Errorf("invalid weight: %q", s)
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	switch m[2] {
+	case "", "kg", "kgs":
+		return v, nil
+	case "lb", "lbs":
+		return v * analysis.KgPerLb, nil
+	case "t":
+		return v * 1000, nil
+	default:
+		return 0, fmt.Errorf("invalid weight unit %q (use kg or lb)", m[2])
+	}
+}
+
+// parseDuration parses a duration such as 60, 60m, 1h30m or 90min into seconds.
+// Bare numbers are minutes.
+func parseDuration(s string) (float64, error) {
+	s = strings.ToLower(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		return d.Seconds(), nil
+	}
+	m := unitPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration: %q", s)
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	switch m[2] {
+	case "", "min", "mins", "minute", "minutes":
+		return v * 60, nil
+	case "sec", "secs", "second", "seconds":
+		return v, nil
+	case "hr", "hrs", "hour", "hours":
+		return v * 3600, nil
+	default:
+		return 0, fmt.Errorf("invalid duration unit %q (use s, m or h)", m[2])
+	}
+}
+
+// parseValue converts a literal into the representation used by a field
+func parseValue(f field, raw string, dates *cmdutil.DateParser) (any, error) {
+	switch f.kind {
+	case kindText:
+		return strings.ToLower(raw), nil
+	case kindWeekday:
+		d, err := cmdutil.ParseWeekday(raw)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToLower(d.String()), nil
+	case kindNumber:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %q", raw)
+		}
+		return v, nil
+	case kindWeight:
+		return parseWeight(raw)
+	case kindDuration:
+		return parseDuration(raw)
+	default:
+		return dates.Parse(raw)
+	}
+}
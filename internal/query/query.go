@@ -0,0 +1,331 @@
+// Package query implements the --where expression language for filtering workouts.
+//
+// An expression compares fields with values and combines the comparisons
+// with and, or, not and parentheses:
+//
+//	exercise ~ "squat" and duration > 60m and volume > 10000kg
+//	weekday = monday or (muscle ~ chest and not title ~ "deload")
+//
+// Text fields support = and != (case-insensitive equality) and ~ and !~
+// (substring match). Fields with several values, such as exercise, match
+// when any value matches. Numeric fields support = != > >= < <= and accept
+// units: kg, lb for weights and s, m, h for durations.
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+)
+
+// Options configures how a query is parsed
+type Options struct {
+	// Dates parses values of the date field; defaults to the local timezone
+	Dates *cmdutil.DateParser
+}
+
+// Query is a parsed --where expression
+type Query struct {
+	src  string
+	root node
+	env  env
+}
+
+// env holds the data needed to evaluate fields
+type env struct {
+	templates map[string]api.ExerciseTemplate
+	loc       *time.Location
+}
+
+// Parse parses a query expression
+func Parse(src string, opts Options) (*Query, error) {
+	if opts.Dates == nil {
+		dates, err := cmdutil.NewDateParser("", "")
+		if err != nil {
+			return nil, err
+		}
+		opts.Dates = dates
+	}
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, dates: opts.Dates}
+	if p.peek().kind == tokenEOF {
+		return nil, syntaxErrorf(0, "empty expression")
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, syntaxErrorf(t.pos, "unexpected %q", t.text)
+	}
+	return &Query{src: src, root: root, env: env{loc: opts.Dates.Location}}, nil
+}
+
+// String returns the source expression
+func (q *Query) String() string {
+	return q.src
+}
+
+// NeedsTemplates reports whether the query uses fields that require
+// exercise templates, such as muscle
+func (q *Query) NeedsTemplates() bool {
+	return q.root.uses("muscle")
+}
+
+// SetTemplates provides the exercise templates used to resolve muscle groups
+func (q *Query) SetTemplates(templates []api.ExerciseTemplate) {
+	q.env.templates = make(map[string]api.ExerciseTemplate, len(templates))
+	for _, t := range templates {
+		q.env.templates[t.ID] = t
+	}
+}
+
+// Match reports whether a workout satisfies the query
+func (q *Query) Match(w *api.Workout) bool {
+	return q.root.eval(w, &q.env)
+}
+
+// Filter returns the workouts that satisfy the query
+func (q *Query) Filter(workouts []api.Workout) []api.Workout {
+	var matched []api.Workout
+	for i := range workouts {
+		if q.Match(&workouts[i]) {
+			matched = append(matched, workouts[i])
+		}
+	}
+	return matched
+}
+
+// node is an expression tree node
+type node interface {
+	eval(w *api.Workout, e *env) bool
+	uses(field string) bool
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ expr node }
+
+func (n andNode) eval(w *api.Workout, e *env) bool {
+	return n.left.eval(w, e) && n.right.eval(w, e)
+}
+func (n andNode) uses(f string) bool { return n.left.uses(f) || n.right.uses(f) }
+
+func (n orNode) eval(w *api.Workout, e *env) bool {
+	return n.left.eval(w, e) || n.right.eval(w, e)
+}
+func (n orNode) uses(f string) bool { return n.left.uses(f) || n.right.uses(f) }
+
+func (n notNode) eval(w *api.Workout, e *env) bool {
+	return !n.expr.eval(w, e)
+}
+func (n notNode) uses(f string) bool { return n.expr.uses(f) }
+
+// compareNode compares a field with a literal value
+type compareNode struct {
+	name  string
+	field field
+	op    string
+	value any
+}
+
+func (n compareNode) uses(f string) bool { return n.name == f }
+
+func (n compareNode) eval(w *api.Workout, e *env) bool {
+	switch n.field.kind {
+	case kindText, kindWeekday:
+		want := n.value.(string)
+		negate := n.op == "!=" || n.op == "!~"
+		for _, v := range n.field.text(w, e) {
+			v = strings.ToLower(v)
+			var match bool
+			if n.op == "~" || n.op == "!~" {
+				match = strings.Contains(v, want)
+			} else {
+				match = v == want
+			}
+			if match {
+				return !negate
+			}
+		}
+		return negate
+
+	case kindDate:
+		r := n.value.(cmdutil.DateRange)
+		return compareDate(w.StartTime, n.op, r)
+
+	default:
+		return compareNumber(n.field.number(w), n.op, n.value.(float64))
+	}
+}
+
+func compareNumber(v float64, op string, want float64) bool {
+	switch op {
+	case "=":
+		return v == want
+	case "!=":
+		return v != want
+	case ">":
+		return v > want
+	case ">=":
+		return v >= want
+	case "<":
+		return v < want
+	default:
+		return v <= want
+	}
+}
+
+// compareDate compares a time with the span covered by a date expression.
+// "date > march" means after the end of March; "date >= march" means from
+// the start of March.
+func compareDate(t time.Time, op string, r cmdutil.DateRange) bool {
+	switch op {
+	case "=":
+		return r.Contains(t)
+	case "!=":
+		return !r.Contains(t)
+	case ">":
+		return r.End.IsZero() || !t.Before(r.End)
+	case ">=":
+		return r.Start.IsZero() || !t.Before(r.Start)
+	case "<":
+		return !r.Start.IsZero() && t.Before(r.Start)
+	default:
+		return r.End.IsZero() || t.Before(r.End)
+	}
+}
+
+// parser is a recursive descent parser over the token list
+type parser struct {
+	tokens []token
+	pos    int
+	dates  *cmdutil.DateParser
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// parseOr parses: and ("or" and)*
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary ("and" unary)*
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+// parseUnary parses: "not" unary | "(" or ")" | comparison
+func (p *parser) parseUnary() (node, error) {
+	switch t := p.peek(); t.kind {
+	case tokenNot:
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{expr}, nil
+
+	case tokenLParen:
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, syntaxErrorf(closing.pos, "expected )")
+		}
+		return expr, nil
+
+	default:
+		return p.parseComparison()
+	}
+}
+
+// parseComparison parses: field operator value
+func (p *parser) parseComparison() (node, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		if t.kind == tokenEOF {
+			return nil, syntaxErrorf(t.pos, "expected a field name")
+		}
+		return nil, syntaxErrorf(t.pos, "expected a field name, got %q", t.text)
+	}
+	name, f, ok := lookupField(t.text)
+	if !ok {
+		return nil, syntaxErrorf(t.pos, "unknown field %q (available: %s)", t.text, strings.Join(FieldNames(), ", "))
+	}
+
+	opTok := p.next()
+	if opTok.kind != tokenOp {
+		return nil, syntaxErrorf(opTok.pos, "expected an operator after %s", t.text)
+	}
+	op := opTok.text
+	if op == "==" {
+		op = "="
+	}
+	if !containsOp(validOps[f.kind], op) {
+		return nil, syntaxErrorf(opTok.pos, "operator %s is not supported for %s (use %s)", op, name, strings.Join(validOps[f.kind], " "))
+	}
+
+	valTok := p.next()
+	if valTok.kind != tokenWord && valTok.kind != tokenString {
+		return nil, syntaxErrorf(valTok.pos, "expected a value after %s %s", t.text, opTok.text)
+	}
+	value, err := parseValue(f, valTok.text, p.dates)
+	if err != nil {
+		return nil, syntaxErrorf(valTok.pos, "%v", err)
+	}
+
+	return compareNode{name: name, field: f, op: op, value: value}, nil
+}
+
+func containsOp(ops []string, op string) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
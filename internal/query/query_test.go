@@ -0,0 +1,170 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/cmdutil"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+func intPtr(v int) *int           { return &v }
+
+func set(weight float64, reps int) api.Set {
+	return api.Set{WeightKg: floatPtr(weight), Reps: intPtr(reps)}
+}
+
+// testWorkouts returns a leg day on Monday 2026-04-13 (75 minutes, 9 000 kg)
+// and a push day on Wednesday 2026-04-15 (45 minutes, 2 400 kg)
+func testWorkouts() []api.Workout {
+	legStart := time.Date(2026, 4, 13, 18, 0, 0, 0, time.UTC)
+	pushStart := time.Date(2026, 4, 15, 7, 0, 0, 0, time.UTC)
+	return []api.Workout{
+		{
+			ID: "legs", Title: "Leg Day", Description: "felt strong",
+			StartTime: legStart, EndTime: legStart.Add(75 * time.Minute),
+			Exercises: []api.Exercise{
+				{Title: "Squat (Barbell)", ExerciseTemplateID: "sq", Sets: []api.Set{set(100, 5), set(100, 5), set(100, 5)}},
+				{Title: "Leg Press", ExerciseTemplateID: "lp", Notes: "seat 4", Sets: []api.Set{set(250, 10), set(250, 10), set(250, 10)}},
+				{Title: "Walking Lunge", ExerciseTemplateID: "wl", Sets: []api.Set{set(0, 20)}},
+			},
+		},
+		{
+			ID: "push", Title: "Push", RoutineID: "r1",
+			StartTime: pushStart, EndTime: pushStart.Add(45 * time.Minute),
+			Exercises: []api.Exercise{
+				{Title: "Bench Press (Barbell)", ExerciseTemplateID: "bp", Sets: []api.Set{set(80, 10), set(80, 10), set(80, 10)}},
+			},
+		},
+	}
+}
+
+func testOptions(t *testing.T) Options {
+	t.Helper()
+	return Options{Dates: &cmdutil.DateParser{
+		Now:       time.Date(2026, 4, 15, 12, 0, 0, 0, time.UTC),
+		Location:  time.UTC,
+		WeekStart: time.Monday,
+	}}
+}
+
+func matchIDs(t *testing.T, expr string) []string {
+	t.Helper()
+	q, err := Parse(expr, testOptions(t))
+	require.NoError(t, err, expr)
+	q.SetTemplates([]api.ExerciseTemplate{
+		{ID: "sq", PrimaryMuscleGroup: "quadriceps", SecondaryMuscleGroups: []api.MuscleGroup{"glutes"}},
+		{ID: "bp", PrimaryMuscleGroup: "chest", SecondaryMuscleGroups: []api.MuscleGroup{"triceps"}},
+	})
+	ids := []string{}
+	for _, w := range q.Filter(testWorkouts()) {
+		ids = append(ids, w.ID)
+	}
+	return ids
+}
+
+func TestQuery_Match(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{`exercise ~ "squat" and duration > 60m and volume >= 9000kg and title ~ "Leg"`, []string{"legs"}},
+		{`exercise ~ squat`, []string{"legs"}},
+		{`exercise = "bench press (barbell)"`, []string{"push"}},
+		{`exercise !~ squat`, []string{"push"}},
+		{`title != push`, []string{"legs"}},
+		{`duration < 1h`, []string{"push"}},
+		{`duration > 50`, []string{"legs"}},
+		{`volume > 6000lb`, []string{"legs"}},
+		{`max_weight >= 250`, []string{"legs"}},
+		{`sets = 3 or exercises = 3`, []string{"legs", "push"}},
+		{`reps > 40`, []string{"legs"}},
+		{`weekday = mon`, []string{"legs"}},
+		{`weekday != monday`, []string{"push"}},
+		{`muscle ~ glute`, []string{"legs"}},
+		{`muscle = chest`, []string{"push"}},
+		{`notes ~ "seat" or notes ~ strong`, []string{"legs"}},
+		{`routine = r1`, []string{"push"}},
+		{`template = bp`, []string{"push"}},
+		{`date = today`, []string{"push"}},
+		{`date >= "this week" and date < wednesday`, []string{"legs"}},
+		{`date > 2026-04-13`, []string{"push"}},
+		{`date <= 2026-04-13`, []string{"legs"}},
+		{`not (title ~ leg or duration < 30m)`, []string{"push"}},
+		{`title ~ leg && !(weekday = friday) || title ~ nope`, []string{"legs"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchIDs(t, tt.expr))
+		})
+	}
+}
+
+func TestQuery_Precedence(t *testing.T) {
+	// and binds tighter than or
+	assert.Equal(t, []string{"legs", "push"}, matchIDs(t, `title ~ push or title ~ leg and duration > 60m`))
+	assert.Equal(t, []string{"legs"}, matchIDs(t, `(title ~ push or title ~ leg) and duration > 60m`))
+}
+
+func TestQuery_NeedsTemplates(t *testing.T) {
+	q, err := Parse(`title ~ leg or not muscles ~ chest`, testOptions(t))
+	require.NoError(t, err)
+	assert.True(t, q.NeedsTemplates())
+
+	q, err = Parse(`title ~ leg`, testOptions(t))
+	require.NoError(t, err)
+	assert.False(t, q.NeedsTemplates())
+	assert.Equal(t, "title ~ leg", q.String())
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{``, 1},
+		{`colour = red`, 1},
+		{`title > 5`, 7},
+		{`duration > soon`, 12},
+		{`volume > 10furlongs`, 10},
+		{`weekday = someday`, 11},
+		{`title ~ "leg`, 9},
+		{`title ~ leg and`, 16},
+		{`(title ~ leg`, 13},
+		{`title ~ leg extra`, 13},
+		{`title leg`, 7},
+		{`title ~ leg # x`, 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr, testOptions(t))
+			require.Error(t, err)
+			var syntaxErr *SyntaxError
+			require.True(t, errors.As(err, &syntaxErr), err.Error())
+			assert.Equal(t, tt.pos, syntaxErr.Pos+1, err.Error())
+		})
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	v, err := parseDuration("1h30m")
+	require.NoError(t, err)
+	assert.Equal(t, 5400.0, v)
+
+	v, err = parseDuration("90min")
+	require.NoError(t, err)
+	assert.Equal(t, 5400.0, v)
+
+	v, err = parseWeight("225lb")
+	require.NoError(t, err)
+	assert.InDelta(t, 102.06, v, 0.01)
+
+	v, err = parseWeight("1.5t")
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, v)
+}
@@ -0,0 +1,203 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of generated documents
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Enum is implemented by string types limited to a set of values, which
+// the schema lists
+type Enum interface {
+	EnumValues() []string
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	enumType       = reflect.TypeOf((*Enum)(nil)).Elem()
+)
+
+// Document generates the JSON Schema document for a registered output
+func Document(name string) (map[string]interface{}, error) {
+	o, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown schema: %s", name)
+	}
+
+	g := &generator{defs: map[string]interface{}{}, names: map[reflect.Type]string{}}
+	data := g.schemaFor(o.Data)
+
+	var doc map[string]interface{}
+	if o.Document {
+		doc = data
+		if ref, ok := data["$ref"].(string); ok {
+			// Inline the top-level definition
+			def := strings.TrimPrefix(ref, "#/$defs/")
+			doc = g.defs[def].(map[string]interface{})
+			delete(g.defs, def)
+		}
+	} else {
+		doc = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"schema": map[string]interface{}{"const": ID(name, Version)},
+				"data":   data,
+				"meta": map[string]interface{}{
+					"type":        "object",
+					"description": "List metadata such as count, page and page_count",
+					"properties": map[string]interface{}{
+						"count":      map[string]interface{}{"type": "integer"},
+						"page":       map[string]interface{}{"type": "integer"},
+						"page_count": map[string]interface{}{"type": "integer"},
+					},
+				},
+			},
+			"required": []string{"schema", "data"},
+		}
+	}
+
+	doc["$schema"] = Draft
+	doc["$id"] = ID(name, Version)
+	doc["title"] = name
+	if o.Description != "" {
+		doc["description"] = o.Description
+	}
+	if len(g.defs) > 0 {
+		doc["$defs"] = g.defs
+	}
+	return doc, nil
+}
+
+// generator builds JSON Schema from Go types. Named struct types are
+// placed in $defs and referenced, so shared types appear once.
+type generator struct {
+	defs  map[string]interface{}
+	names map[reflect.Type]string
+}
+
+func (g *generator) schemaFor(t reflect.Type) map[string]interface{} {
+	switch {
+	case t == nil:
+		return map[string]interface{}{}
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.schemaFor(t.Elem()))
+	case reflect.String:
+		if t.Implements(enumType) {
+			values := reflect.Zero(t).Interface().(Enum).EnumValues()
+			return map[string]interface{}{"type": "string", "enum": values}
+		}
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		// nil slices are encoded as null
+		s := map[string]interface{}{"type": "array", "items": g.schemaFor(t.Elem())}
+		if t.Kind() == reflect.Slice {
+			s = nullable(s)
+		}
+		return s
+	case reflect.Map:
+		return nullable(map[string]interface{}{"type": "object", "additionalProperties": g.schemaFor(t.Elem())})
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + g.define(t)}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// define adds a named struct type to $defs and returns its name
+func (g *generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := g.defs[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+	g.names[t] = name
+	g.defs[name] = map[string]interface{}{}
+	g.defs[name] = g.structSchema(t)
+	return name
+}
+
+// structSchema describes a struct by its JSON field names. Fields without
+// omitempty are required.
+func (g *generator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	g.addFields(t, properties, &required)
+
+	s := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (g *generator) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(ft, properties, required)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		properties[name] = g.schemaFor(f.Type)
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// nullable allows null in addition to a schema
+func nullable(s map[string]interface{}) map[string]interface{} {
+	if typ, ok := s["type"].(string); ok {
+		s["type"] = []string{typ, "null"}
+		return s
+	}
+	if _, ok := s["type"]; ok || len(s) == 0 {
+		return s
+	}
+	return map[string]interface{}{"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}}}
+}
@@ -0,0 +1,93 @@
+// Package schema describes the versioned structured output of commands.
+//
+// JSON and YAML output is wrapped in an envelope naming the schema it
+// follows:
+//
+//	{"schema": "hevycli/workout-list@1", "data": [...], "meta": {"count": 10}}
+//
+// Fields may be added within a version; removing or changing a field
+// requires a new version. Commands register the Go type of their data so
+// that JSON Schema documents can be generated for every output.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Version is the current output schema version
+const Version = 1
+
+// Legacy selects the unversioned output of earlier releases: the command's
+// data without an envelope
+const Legacy = 0
+
+// ErrorName is the schema name of error output
+const ErrorName = "error"
+
+// Output describes the structured output of a command
+type Output struct {
+	// Name identifies the command, e.g. "workout-list" for "workout list"
+	Name string
+	// Description summarizes the data
+	Description string
+	// Data is the Go type of the data field
+	Data reflect.Type
+	// Document is true when Data is the whole document rather than the
+	// data field of an envelope
+	Document bool
+}
+
+var registry = map[string]Output{}
+
+// Register records the data type of a command's output. data is a value
+// of that type, e.g. []api.Workout{}.
+func Register(name, description string, data interface{}) {
+	registry[name] = Output{Name: name, Description: description, Data: reflect.TypeOf(data)}
+}
+
+// RegisterDocument records a document that is not wrapped in an envelope
+func RegisterDocument(name, description string, doc interface{}) {
+	registry[name] = Output{Name: name, Description: description, Data: reflect.TypeOf(doc), Document: true}
+}
+
+// Lookup returns the registered output for a name
+func Lookup(name string) (Output, bool) {
+	o, ok := registry[name]
+	return o, ok
+}
+
+// Names returns the sorted names of all registered outputs
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CommandName converts a command path such as "hevycli workout list" to a
+// schema name such as "workout-list"
+func CommandName(path string) string {
+	fields := strings.Fields(path)
+	if len(fields) > 0 {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, "-")
+}
+
+// ID returns the identifier of a schema version, e.g. "hevycli/workout-list@1"
+func ID(name string, version int) string {
+	return fmt.Sprintf("hevycli/%s@%d", name, version)
+}
+
+// CheckVersion returns an error if version is not supported
+func CheckVersion(version int) error {
+	if version < Legacy || version > Version {
+		return fmt.Errorf("unsupported schema version: %d (supported: %d to %d)", version, Legacy, Version)
+	}
+	return nil
+}
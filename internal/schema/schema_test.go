@@ -0,0 +1,122 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testSet struct {
+	Reps     *int     `json:"reps"`
+	WeightKg *float64 `json:"weight_kg,omitempty"`
+}
+
+type testWorkout struct {
+	testBase
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"start_time"`
+	Sets      []testSet `json:"sets"`
+	Warmup    []testSet `json:"warmup,omitempty"`
+	internal  string
+	Skipped   string `json:"-"`
+}
+
+func TestCommandName(t *testing.T) {
+	assert.Equal(t, "workout-list", CommandName("hevycli workout list"))
+	assert.Equal(t, "config-profile-list", CommandName("hevycli config profile list"))
+	assert.Equal(t, "", CommandName("hevycli"))
+}
+
+func TestID(t *testing.T) {
+	assert.Equal(t, "hevycli/workout-list@1", ID("workout-list", 1))
+}
+
+func TestCheckVersion(t *testing.T) {
+	assert.NoError(t, CheckVersion(Legacy))
+	assert.NoError(t, CheckVersion(Version))
+	assert.Error(t, CheckVersion(Version+1))
+	assert.Error(t, CheckVersion(-1))
+}
+
+func TestDocument(t *testing.T) {
+	Register("test-list", "Test workouts", []testWorkout{})
+	t.Cleanup(func() { delete(registry, "test-list") })
+
+	doc, err := Document("test-list")
+	require.NoError(t, err)
+
+	// Compare through JSON to ignore Go map and slice types
+	b, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &got))
+
+	assert.Equal(t, "hevycli/test-list@1", got["$id"])
+	assert.Equal(t, Draft, got["$schema"])
+	assert.Equal(t, []interface{}{"schema", "data"}, got["required"])
+
+	props := got["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"const": "hevycli/test-list@1"}, props["schema"])
+	assert.Equal(t, map[string]interface{}{
+		"type":  []interface{}{"array", "null"},
+		"items": map[string]interface{}{"$ref": "#/$defs/testWorkout"},
+	}, props["data"])
+
+	defs := got["$defs"].(map[string]interface{})
+	workout := defs["testWorkout"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"id", "title", "start_time", "sets"}, workout["required"])
+
+	fields := workout["properties"].(map[string]interface{})
+	assert.Len(t, fields, 5)
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, fields["start_time"])
+	assert.Contains(t, fields, "id")
+	assert.NotContains(t, fields, "Skipped")
+
+	set := defs["testSet"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"integer", "null"}}, set["properties"].(map[string]interface{})["reps"])
+	assert.Equal(t, []interface{}{"reps"}, set["required"])
+}
+
+func TestDocument_Unenveloped(t *testing.T) {
+	RegisterDocument("test-error", "Test error", struct {
+		Message string `json:"message"`
+	}{})
+	t.Cleanup(func() { delete(registry, "test-error") })
+
+	doc, err := Document("test-error")
+	require.NoError(t, err)
+	assert.Equal(t, "object", doc["type"])
+	assert.Contains(t, doc["properties"], "message")
+	assert.NotContains(t, doc, "$defs")
+}
+
+func TestDocument_Unknown(t *testing.T) {
+	_, err := Document("nope")
+	assert.Error(t, err)
+}
+
+type testColor string
+
+func (testColor) EnumValues() []string { return []string{"red", "green"} }
+
+func TestDocument_Enum(t *testing.T) {
+	RegisterDocument("test-enum", "Test enum", struct {
+		Color  testColor   `json:"color"`
+		Colors []testColor `json:"colors"`
+	}{})
+	t.Cleanup(func() { delete(registry, "test-enum") })
+
+	doc, err := Document("test-enum")
+	require.NoError(t, err)
+	props := doc["properties"].(map[string]interface{})
+	enum := map[string]interface{}{"type": "string", "enum": []string{"red", "green"}}
+	assert.Equal(t, enum, props["color"])
+	assert.Equal(t, enum, props["colors"].(map[string]interface{})["items"])
+}
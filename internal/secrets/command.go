@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// CommandTimeout bounds how long a key command may run
+const CommandTimeout = 30 * time.Second
+
+// RunCommand runs a shell command such as "pass show hevy" and returns the
+// first line of its output as the secret
+func RunCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Let tools like pass or op prompt on the terminal
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return "", fmt.Errorf("api key command failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("api key command failed: %w", err)
+	}
+
+	secret, _, _ := strings.Cut(stdout.String(), "\n")
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("api key command returned no output")
+	}
+	return secret, nil
+}
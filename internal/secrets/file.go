@@ -0,0 +1,156 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+)
+
+// scryptWorkFactor is the age scrypt cost (log2 N) used when encrypting
+var scryptWorkFactor = 18
+
+// fileStore keeps secrets in a passphrase-encrypted age file
+type fileStore struct {
+	path       string
+	passphrase PassphraseFunc
+}
+
+// NewFileStore returns a store backed by an age file encrypted with a passphrase
+func NewFileStore(path string, passphrase PassphraseFunc) Store {
+	return &fileStore{path: path, passphrase: passphrase}
+}
+
+func (s *fileStore) Name() string {
+	return BackendFile
+}
+
+func (s *fileStore) Get(account string) (string, error) {
+	entries, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := entries[account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (s *fileStore) Set(account, secret string) error {
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+	entries[account] = secret
+	return s.write(entries)
+}
+
+func (s *fileStore) Delete(account string) error {
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[account]; !ok {
+		return nil
+	}
+	delete(entries, account)
+	return s.write(entries)
+}
+
+func (s *fileStore) getPassphrase() (string, error) {
+	if s.passphrase == nil {
+		return "", fmt.Errorf("no passphrase available for %s", s.path)
+	}
+	_, statErr := os.Stat(s.path)
+	pass, err := s.passphrase(errors.Is(statErr, os.ErrNotExist))
+	if err != nil {
+		return "", err
+	}
+	if pass == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	return pass, nil
+}
+
+// read decrypts the secrets file; a missing file is an empty store
+func (s *fileStore) read() (map[string]string, error) {
+	entries := make(map[string]string)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	pass, err := s.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := age.NewScryptIdentity(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(data), identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file (wrong passphrase?): %w", err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file: %w", err)
+	}
+	if err := json.Unmarshal(plain, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+	return entries, nil
+}
+
+// write encrypts the secrets and replaces the file atomically
+func (s *fileStore) write(entries map[string]string) error {
+	pass, err := s.getPassphrase()
+	if err != nil {
+		return err
+	}
+	recipient, err := age.NewScryptRecipient(pass)
+	if err != nil {
+		return err
+	}
+	recipient.SetWorkFactor(scryptWorkFactor)
+
+	plain, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+	if _, err := w.Write(plain); err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	return nil
+}
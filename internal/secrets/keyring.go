@@ -0,0 +1,49 @@
+package secrets
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// KeyringService is the service name entries are stored under
+const KeyringService = "hevycli"
+
+// keyringStore keeps secrets in the system keyring (the freedesktop
+// Secret Service over D-Bus on Linux)
+type keyringStore struct{}
+
+// NewKeyringStore returns a store backed by the system keyring
+func NewKeyringStore() Store {
+	return keyringStore{}
+}
+
+// KeyringAvailable reports whether the system keyring can be reached
+func KeyringAvailable() bool {
+	_, err := keyring.Get(KeyringService, "__probe__")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+func (keyringStore) Name() string {
+	return BackendKeyring
+}
+
+func (keyringStore) Get(account string) (string, error) {
+	secret, err := keyring.Get(KeyringService, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return secret, err
+}
+
+func (keyringStore) Set(account, secret string) error {
+	return keyring.Set(KeyringService, account, secret)
+}
+
+func (keyringStore) Delete(account string) error {
+	err := keyring.Delete(KeyringService, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
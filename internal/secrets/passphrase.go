@@ -0,0 +1,73 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// PassphraseEnv is the environment variable holding the secrets file passphrase
+const PassphraseEnv = "HEVYCLI_PASSPHRASE"
+
+var (
+	passphraseMu     sync.Mutex
+	cachedPassphrase string
+)
+
+// TerminalPassphrase reads the passphrase from HEVYCLI_PASSPHRASE or prompts
+// for it on the terminal. The passphrase is remembered for the rest of the run.
+func TerminalPassphrase(create bool) (string, error) {
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+
+	if cachedPassphrase != "" {
+		return cachedPassphrase, nil
+	}
+	if pass := os.Getenv(PassphraseEnv); pass != "" {
+		cachedPassphrase = pass
+		return pass, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("secrets file is locked: set %s or run interactively", PassphraseEnv)
+	}
+
+	prompt := "Secrets passphrase: "
+	if create {
+		prompt = "Choose a passphrase for the secrets file: "
+	}
+	pass, err := readPassword(fd, prompt)
+	if err != nil {
+		return "", err
+	}
+	if create {
+		confirm, err := readPassword(fd, "Confirm passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if confirm != pass {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+
+	cachedPassphrase = pass
+	return pass, nil
+}
+
+// PassphraseAvailable reports whether TerminalPassphrase can obtain a passphrase
+func PassphraseAvailable() bool {
+	return os.Getenv(PassphraseEnv) != "" || term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+func readPassword(fd int, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(b), nil
+}
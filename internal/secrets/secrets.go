@@ -0,0 +1,86 @@
+// Package secrets stores API keys outside of the plaintext config file
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Backend names
+const (
+	BackendAuto    = "auto"
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
+	BackendPlain   = "plain"
+)
+
+// ErrNotFound is returned when a store has no secret for an account
+var ErrNotFound = errors.New("secret not found")
+
+// Store keeps secrets keyed by account name
+type Store interface {
+	// Name returns the backend name
+	Name() string
+	// Get returns the secret for an account, or ErrNotFound
+	Get(account string) (string, error)
+	// Set stores the secret for an account
+	Set(account, secret string) error
+	// Delete removes the secret for an account; missing secrets are not an error
+	Delete(account string) error
+}
+
+// Options configures the backends
+type Options struct {
+	// FilePath is the location of the encrypted secrets file
+	FilePath string
+	// Passphrase returns the passphrase for the encrypted file
+	Passphrase PassphraseFunc
+}
+
+// PassphraseFunc returns the passphrase for the encrypted file.
+// create is true when the file does not exist yet and a new passphrase is being chosen.
+type PassphraseFunc func(create bool) (string, error)
+
+// Backends returns the backend names accepted in configuration
+func Backends() []string {
+	return []string{BackendAuto, BackendKeyring, BackendFile, BackendPlain}
+}
+
+// ParseBackend validates a backend name; an empty name means plain
+func ParseBackend(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return BackendPlain, nil
+	}
+	for _, b := range Backends() {
+		if name == b {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("invalid secrets backend: %s (must be one of: %s)", name, strings.Join(Backends(), ", "))
+}
+
+// Open returns the store for a backend.
+// The auto backend uses the system keyring when available and the
+// encrypted file otherwise. The plain backend has no store and returns nil.
+func Open(backend string, opts Options) (Store, error) {
+	backend, err := ParseBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend {
+	case BackendKeyring:
+		return NewKeyringStore(), nil
+	case BackendFile:
+		return NewFileStore(opts.FilePath, opts.Passphrase), nil
+	case BackendAuto:
+		if KeyringAvailable() {
+			return NewKeyringStore(), nil
+		}
+		return NewFileStore(opts.FilePath, opts.Passphrase), nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// Keep encryption fast in tests
+	scryptWorkFactor = 10
+}
+
+func fixedPassphrase(pass string) PassphraseFunc {
+	return func(bool) (string, error) { return pass, nil }
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.age")
+	store := NewFileStore(path, fixedPassphrase("hunter2"))
+
+	_, err := store.Get("default")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Set("default", "key-1"))
+	require.NoError(t, store.Set("coach", "key-2"))
+
+	got, err := store.Get("coach")
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", got)
+
+	require.NoError(t, store.Delete("coach"))
+	_, err = store.Get("coach")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	wrong := NewFileStore(path, fixedPassphrase("wrong"))
+	_, err = wrong.Get("default")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong passphrase")
+}
+
+func TestParseBackend(t *testing.T) {
+	b, err := ParseBackend("")
+	require.NoError(t, err)
+	assert.Equal(t, BackendPlain, b)
+
+	b, err = ParseBackend("Keyring")
+	require.NoError(t, err)
+	assert.Equal(t, BackendKeyring, b)
+
+	_, err = ParseBackend("vault")
+	assert.Error(t, err)
+}
+
+func TestRunCommand(t *testing.T) {
+	got, err := RunCommand("printf 'secret\\nsecond line\\n'")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got)
+
+	_, err = RunCommand("true")
+	assert.Error(t, err)
+}
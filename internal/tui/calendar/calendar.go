@@ -0,0 +1,301 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/tui/common"
+)
+
+// Heatmap glyphs by intensity level, used when color is disabled
+var plainGlyphs = []string{"·", "░", "▒", "▓", "█"}
+
+// Heatmap colors by intensity level
+var levelColors = []lipgloss.Color{"237", "22", "28", "34", "46"}
+
+// RenderOptions controls how the heatmap is drawn
+type RenderOptions struct {
+	Metric   analysis.CalendarMetric
+	Color    bool
+	FromWeek int
+	ToWeek   int
+	Cursor   time.Time
+}
+
+// Render draws a contribution-style heatmap with weeks as columns and weekdays as rows.
+// Only weeks in [FromWeek, ToWeek) are drawn; a zero ToWeek draws through the last week.
+func Render(cal *analysis.Calendar, opts RenderOptions) string {
+	from, to := opts.FromWeek, opts.ToWeek
+	if to <= 0 || to > len(cal.Weeks) {
+		to = len(cal.Weeks)
+	}
+	if from < 0 {
+		from = 0
+	}
+
+	max := cal.MaxValue(opts.Metric)
+	first := cal.FirstDay()
+	cursor := ""
+	if !opts.Cursor.IsZero() {
+		cursor = opts.Cursor.Format("2006-01-02")
+	}
+
+	var b strings.Builder
+
+	// Month labels above the first week of each month
+	header := []byte(strings.Repeat(" ", 4+2*(to-from)+2))
+	lastMonth, nextFree := time.Month(0), 0
+	for w := from; w < to; w++ {
+		start := first.AddDate(0, 0, w*7)
+		pos := 4 + 2*(w-from)
+		if start.Month() != lastMonth && pos >= nextFree {
+			copy(header[pos:], start.Format("Jan"))
+			nextFree = pos + 4
+		}
+		lastMonth = start.Month()
+	}
+	b.WriteString(strings.TrimRight(string(header), " "))
+	b.WriteString("\n")
+
+	// Label every other weekday, starting with the first day of the week
+	for row := 0; row < 7; row++ {
+		label := ""
+		if row%2 == 0 {
+			label = first.AddDate(0, 0, row).Format("Mon")
+		}
+		fmt.Fprintf(&b, "%-4s", label)
+		for w := from; w < to; w++ {
+			i := w*7 + row
+			if i >= len(cal.Days) {
+				b.WriteString("  ")
+				continue
+			}
+			day := cal.Days[i]
+			b.WriteString(cell(analysis.Level(day, opts.Metric, max), day.Date == cursor, opts.Color))
+			b.WriteString(" ")
+		}
+		b.WriteString("\n")
+	}
+
+	if cal.WeeklyTarget > 0 {
+		b.WriteString("    ")
+		for w := from; w < to; w++ {
+			b.WriteString(targetMark(cal.Weeks[w].Status, opts.Color))
+			b.WriteString(" ")
+		}
+		b.WriteString("\n")
+	}
+
+	// Legend
+	b.WriteString("\n    Less ")
+	for level := range plainGlyphs {
+		b.WriteString(cell(level, false, opts.Color))
+		b.WriteString(" ")
+	}
+	b.WriteString("More")
+	if cal.WeeklyTarget > 0 {
+		fmt.Fprintf(&b, "   Target %d/week: %s hit %s missed",
+			cal.WeeklyTarget, targetMark(analysis.TargetHit, opts.Color), targetMark(analysis.TargetMiss, opts.Color))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// cell renders one heatmap square
+func cell(level int, selected, color bool) string {
+	if !color {
+		if selected {
+			return "◆"
+		}
+		return plainGlyphs[level]
+	}
+	style := lipgloss.NewStyle().Foreground(levelColors[level])
+	if selected {
+		style = style.Background(common.PrimaryColor)
+	}
+	return style.Render("■")
+}
+
+// targetMark renders the weekly target status
+func targetMark(status string, color bool) string {
+	mark, style := " ", lipgloss.NewStyle()
+	switch status {
+	case analysis.TargetHit:
+		mark, style = "✓", common.SuccessStyle
+	case analysis.TargetMiss:
+		mark, style = "✗", common.ErrorStyle
+	case analysis.TargetInProgress:
+		mark, style = "…", common.BlurredStyle
+	}
+	if !color {
+		return mark
+	}
+	return style.Render(mark)
+}
+
+// Model is the interactive calendar model
+type Model struct {
+	cal      *analysis.Calendar
+	metric   analysis.CalendarMetric
+	color    bool
+	cursor   time.Time
+	last     time.Time
+	from     int
+	width    int
+	quitting bool
+}
+
+// NewModel creates a calendar model with the cursor on the last day
+func NewModel(cal *analysis.Calendar, metric analysis.CalendarMetric, color bool) Model {
+	last, _ := time.ParseInLocation("2006-01-02", cal.End, cal.FirstDay().Location())
+	m := Model{
+		cal:    cal,
+		metric: metric,
+		color:  color,
+		cursor: last,
+		last:   last,
+		width:  80,
+	}
+	m.scroll()
+	return m
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.scroll()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "left", "h":
+			m.move(m.cursor.AddDate(0, 0, -7))
+		case "right", "l":
+			m.move(m.cursor.AddDate(0, 0, 7))
+		case "up", "k":
+			m.move(m.cursor.AddDate(0, 0, -1))
+		case "down", "j":
+			m.move(m.cursor.AddDate(0, 0, 1))
+		case "[", "pgup":
+			m.move(m.cursor.AddDate(0, -1, 0))
+		case "]", "pgdown":
+			m.move(m.cursor.AddDate(0, 1, 0))
+		case "t", "end":
+			m.move(m.last)
+		case "m":
+			metrics := analysis.CalendarMetrics()
+			for i, metric := range metrics {
+				if metric == m.metric {
+					m.metric = metrics[(i+1)%len(metrics)]
+					break
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// move sets the cursor, clamped to the calendar range
+func (m *Model) move(t time.Time) {
+	if t.Before(m.cal.FirstDay()) {
+		t = m.cal.FirstDay()
+	}
+	if t.After(m.last) {
+		t = m.last
+	}
+	m.cursor = t
+	m.scroll()
+}
+
+// visibleWeeks returns how many week columns fit in the terminal
+func (m Model) visibleWeeks() int {
+	n := (m.width - 8) / 2
+	if n < 4 {
+		n = 4
+	}
+	return n
+}
+
+// scroll keeps the cursor's week inside the visible window
+func (m *Model) scroll() {
+	week := int(m.cursor.Sub(m.cal.FirstDay()).Hours()/24) / 7
+	visible := m.visibleWeeks()
+	if week < m.from {
+		m.from = week
+	}
+	if week >= m.from+visible {
+		m.from = week - visible + 1
+	}
+	if m.from < 0 {
+		m.from = 0
+	}
+}
+
+// View renders the model
+func (m Model) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(common.TitleStyle.Render(fmt.Sprintf("📅 Training Calendar (%s)", m.metric)))
+	b.WriteString("\n")
+	b.WriteString(Render(m.cal, RenderOptions{
+		Metric:   m.metric,
+		Color:    m.color,
+		FromWeek: m.from,
+		ToWeek:   m.from + m.visibleWeeks(),
+		Cursor:   m.cursor,
+	}))
+	b.WriteString("\n")
+
+	day, _ := m.cal.Day(m.cursor)
+	detail := fmt.Sprintf("%s (%s)\n", m.cursor.Format("Mon, Jan 2 2006"), day.Weekday)
+	if day.Workouts == 0 {
+		detail += "Rest day"
+	} else {
+		detail += fmt.Sprintf("Workouts: %d   Sets: %d\nVolume: %.0f kg   Duration: %.0f min",
+			day.Workouts, day.Sets, day.VolumeKg, day.DurationMinutes)
+	}
+	if week, ok := m.cal.Week(m.cursor); ok {
+		detail += fmt.Sprintf("\n\nWeek of %s: %d training days, %.0f kg", week.Start, week.TrainingDays, week.VolumeKg)
+		if week.Target > 0 {
+			detail += fmt.Sprintf(" (target %d: %s)", week.Target, strings.ReplaceAll(week.Status, "_", " "))
+		}
+	}
+	b.WriteString(common.BoxStyle.Render(detail))
+	b.WriteString("\n")
+
+	b.WriteString(common.RenderHelp(
+		"←/→", "week  ",
+		"↑/↓", "day  ",
+		"[/]", "month  ",
+		"t", "today  ",
+		"m", "metric  ",
+		"q", "quit",
+	))
+
+	return b.String()
+}
+
+// Run starts the interactive calendar
+func Run(cal *analysis.Calendar, metric analysis.CalendarMetric, color bool) error {
+	p := tea.NewProgram(NewModel(cal, metric, color), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
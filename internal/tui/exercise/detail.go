@@ -0,0 +1,161 @@
+package exercise
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/obay/hevycli/internal/analysis"
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/tui/common"
+)
+
+// DetailOptions configures the detail pane of the exercise table
+type DetailOptions struct {
+	// Estimator computes the estimated 1RM trend
+	Estimator analysis.Estimator
+	// Imperial shows weights in lbs
+	Imperial bool
+	// Recent is the number of latest sessions to show
+	Recent int
+}
+
+// history is the user's workouts and routines, loaded once for the detail pane
+type history struct {
+	workouts []api.Workout
+	routines []api.Routine
+}
+
+// historyLoadedMsg is sent when the history is loaded
+type historyLoadedMsg struct {
+	history *history
+}
+
+// historyErrorMsg is sent when loading the history fails
+type historyErrorMsg struct {
+	err error
+}
+
+// loadHistory fetches all workouts and routines
+func loadHistory(client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		workouts, err := client.GetAllWorkouts()
+		if err != nil {
+			return historyErrorMsg{err: err}
+		}
+		routines, err := client.GetAllRoutines()
+		if err != nil {
+			return historyErrorMsg{err: err}
+		}
+		return historyLoadedMsg{history: &history{workouts: workouts, routines: routines}}
+	}
+}
+
+// renderDetail renders an exercise template and, once loaded, the user's
+// history for it
+func renderDetail(ex *api.ExerciseTemplate, ins *analysis.ExerciseInsights, loadErr error, imperial bool) string {
+	var b strings.Builder
+	label := lipgloss.NewStyle().Foreground(common.MutedColor).Width(16)
+	section := lipgloss.NewStyle().Bold(true).Foreground(common.SecondaryColor)
+	muted := lipgloss.NewStyle().Foreground(common.MutedColor)
+
+	field := func(name, value string) {
+		if value != "" {
+			b.WriteString(label.Render(name) + value + "\n")
+		}
+	}
+
+	b.WriteString(common.TitleStyle.Render(ex.Title))
+	b.WriteString("\n")
+	field("ID", ex.ID)
+	field("Primary muscle", ex.PrimaryMuscleGroup.DisplayName())
+	field("Secondary", ex.SecondaryMuscles())
+	if ex.Equipment != "" {
+		field("Equipment", ex.Equipment.DisplayName())
+	}
+	if ex.Type != "" {
+		field("Type", ex.Type.DisplayName())
+	}
+	if ex.IsCustom {
+		field("Custom", "Yes")
+	}
+	b.WriteString("\n")
+
+	switch {
+	case loadErr != nil:
+		b.WriteString(common.ErrorStyle.Render(fmt.Sprintf("Error loading history: %v", loadErr)))
+		b.WriteString("\n")
+		return b.String()
+	case ins == nil:
+		b.WriteString(muted.Render("Loading your history..."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	b.WriteString(section.Render("History"))
+	b.WriteString("\n")
+	if ins.TimesPerformed == 0 {
+		b.WriteString(muted.Render("You have not done this exercise yet."))
+		b.WriteString("\n")
+	} else {
+		field("Performed", fmt.Sprintf("%d times (%d sets)", ins.TimesPerformed, ins.TotalSets))
+		field("First", ins.FirstPerformed)
+		field("Last", ins.LastPerformed)
+		if r := ins.TypicalReps; r != nil {
+			reps := fmt.Sprintf("%d–%d", r.Low, r.High)
+			if r.Low == r.High {
+				reps = fmt.Sprintf("%d", r.Low)
+			}
+			field("Typical reps", fmt.Sprintf("%s (most often %d)", reps, r.MostCommon))
+		}
+	}
+
+	if n := len(ins.E1RMTrend); n > 0 {
+		values := make([]float64, n)
+		for i, p := range ins.E1RMTrend {
+			values[i] = p.Value
+		}
+		if len(values) > 40 {
+			values = values[len(values)-40:]
+		}
+		field("e1RM ("+ins.Formula+")", fmt.Sprintf("%s  %s → %s",
+			common.SuccessStyle.Render(analysis.Sparkline(values)),
+			analysis.FormatWeight(values[0], imperial),
+			analysis.FormatWeight(values[len(values)-1], imperial)))
+	}
+
+	if len(ins.RepMaxes) > 0 {
+		b.WriteString("\n")
+		b.WriteString(section.Render("Rep Maxes"))
+		b.WriteString("\n")
+		for _, r := range ins.RepMaxes {
+			b.WriteString(fmt.Sprintf("  %2dRM  %-12s %s\n", r.Reps, analysis.FormatWeight(r.Value, imperial), muted.Render(r.Date)))
+		}
+	}
+
+	if len(ins.RecentSessions) > 0 {
+		b.WriteString("\n")
+		b.WriteString(section.Render("Recent Sessions"))
+		b.WriteString("\n")
+		for _, s := range ins.RecentSessions {
+			b.WriteString(fmt.Sprintf("  %s  %s\n", s.Date, muted.Render(s.WorkoutTitle)))
+			for i, set := range s.Sets {
+				b.WriteString(fmt.Sprintf("     %2d  %-8s %s\n", i+1, set.SetType, analysis.FormatSet(set, imperial)))
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(section.Render("Routines"))
+	b.WriteString("\n")
+	if len(ins.Routines) == 0 {
+		b.WriteString(muted.Render("  Not in any routine."))
+		b.WriteString("\n")
+	}
+	for _, r := range ins.Routines {
+		b.WriteString("  " + r.Title + "\n")
+	}
+	return b.String()
+}
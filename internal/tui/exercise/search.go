@@ -19,7 +19,7 @@ type ExerciseItem struct {
 }
 
 func (i ExerciseItem) Title() string       { return i.exercise.Title }
-func (i ExerciseItem) Description() string { return i.exercise.PrimaryMuscleGroup + " • " + i.exercise.Equipment }
+func (i ExerciseItem) Description() string { return i.exercise.Summary() }
 func (i ExerciseItem) FilterValue() string { return i.exercise.Title }
 
 // SearchModel is the interactive exercise search model
@@ -172,8 +172,8 @@ func (m *SearchModel) filterExercises() {
 		m.filtered = nil
 		for _, ex := range m.exercises {
 			if strings.Contains(strings.ToLower(ex.Title), query) ||
-				strings.Contains(strings.ToLower(ex.PrimaryMuscleGroup), query) ||
-				strings.Contains(strings.ToLower(ex.Equipment), query) {
+				strings.Contains(string(ex.PrimaryMuscleGroup), query) ||
+				strings.Contains(string(ex.Equipment), query) {
 				m.filtered = append(m.filtered, ex)
 			}
 		}
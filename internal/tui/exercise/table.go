@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/obay/hevycli/internal/analysis"
 	"github.com/obay/hevycli/internal/api"
 	"github.com/obay/hevycli/internal/tui/common"
 )
@@ -33,10 +34,19 @@ type TableModel struct {
 	searchFocus  bool
 	width        int
 	height       int
+
+	// Detail pane
+	opts           DetailOptions
+	detail         *api.ExerciseTemplate
+	scroll         int
+	history        *history
+	historyLoading bool
+	historyErr     error
+	insights       map[string]*analysis.ExerciseInsights
 }
 
 // NewTableModel creates a new exercise table model
-func NewTableModel(client *api.Client) TableModel {
+func NewTableModel(client *api.Client, opts DetailOptions) TableModel {
 	// Search input
 	ti := textinput.New()
 	ti.Placeholder = "Type to search exercises..."
@@ -79,6 +89,8 @@ func NewTableModel(client *api.Client) TableModel {
 		textInput:   ti,
 		loading:     true,
 		searchFocus: false,
+		opts:        opts,
+		insights:    make(map[string]*analysis.ExerciseInsights),
 	}
 }
 
@@ -123,6 +135,9 @@ func (m TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.detail != nil {
+			return m.updateDetail(msg)
+		}
 		if m.searchFocus {
 			switch msg.String() {
 			case "esc":
@@ -161,11 +176,18 @@ func (m TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.textInput.Focus()
 			return m, textinput.Blink
 		case "enter":
+			// Open the detail pane, loading the history the first time
 			if !m.loading && len(m.filtered) > 0 {
 				idx := m.table.Cursor()
 				if idx >= 0 && idx < len(m.filtered) {
-					m.selected = &m.filtered[idx]
-					return m, tea.Quit
+					m.detail = &m.filtered[idx]
+					m.scroll = 0
+					if m.history == nil && !m.historyLoading {
+						m.historyLoading = true
+						m.historyErr = nil
+						return m, loadHistory(m.client)
+					}
+					return m, nil
 				}
 			}
 		}
@@ -191,11 +213,98 @@ func (m TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tableLoadErrorMsg:
 		m.err = msg.err
 		m.loading = false
+
+	case historyLoadedMsg:
+		m.history = msg.history
+		m.historyLoading = false
+
+	case historyErrorMsg:
+		m.historyErr = msg.err
+		m.historyLoading = false
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// updateDetail handles keys while the detail pane is open
+func (m TableModel) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.quitting = true
+		return m, tea.Quit
+	case "esc", "backspace", "left", "h":
+		m.detail = nil
+	case "enter":
+		m.selected = m.detail
+		return m, tea.Quit
+	case "up", "k":
+		if m.scroll > 0 {
+			m.scroll--
+		}
+	case "down", "j":
+		if m.height > 3 && m.scroll < len(m.detailLines())-m.detailHeight() {
+			m.scroll++
+		}
+	case "r":
+		// Retry after a failed load
+		if m.historyErr != nil && !m.historyLoading {
+			m.historyLoading = true
+			m.historyErr = nil
+			return m, loadHistory(m.client)
+		}
+	}
+	return m, nil
+}
+
+// detailInsights returns the insights for the open exercise, computing them
+// once the history is loaded
+func (m TableModel) detailInsights() *analysis.ExerciseInsights {
+	if m.history == nil {
+		return nil
+	}
+	if ins, ok := m.insights[m.detail.ID]; ok {
+		return ins
+	}
+	ins := analysis.BuildExerciseInsights(m.detail.ID, m.detail.Title, m.history.workouts, m.history.routines, m.opts.Estimator, m.opts.Recent)
+	m.insights[m.detail.ID] = ins
+	return ins
+}
+
+// detailLines returns the lines of the detail pane
+func (m TableModel) detailLines() []string {
+	text := renderDetail(m.detail, m.detailInsights(), m.historyErr, m.opts.Imperial)
+	return strings.Split(strings.TrimRight(text, "\n"), "\n")
+}
+
+// detailHeight is the number of detail lines that fit above the help line
+func (m TableModel) detailHeight() int {
+	return m.height - 3
+}
+
+// detailView renders the detail pane, scrolled to fit the window
+func (m TableModel) detailView() string {
+	lines := m.detailLines()
+
+	visible := len(lines)
+	if m.height > 3 {
+		visible = min(len(lines), m.detailHeight())
+	}
+	start := m.scroll
+	if start > len(lines)-visible {
+		start = len(lines) - visible
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[start:start+visible], "\n"))
+	b.WriteString("\n\n")
+	help := "esc back • ↑/↓ scroll • enter select • q quit"
+	if m.historyErr != nil {
+		help = "esc back • r retry • enter select • q quit"
+	}
+	b.WriteString(common.HelpStyle.Render(help))
+	return b.String()
+}
+
 // filterExercises filters exercises based on search input
 func (m *TableModel) filterExercises() {
 	query := strings.ToLower(m.textInput.Value())
@@ -205,8 +314,8 @@ func (m *TableModel) filterExercises() {
 		m.filtered = nil
 		for _, ex := range m.allExercises {
 			if strings.Contains(strings.ToLower(ex.Title), query) ||
-				strings.Contains(strings.ToLower(ex.PrimaryMuscleGroup), query) ||
-				strings.Contains(strings.ToLower(ex.Equipment), query) {
+				strings.Contains(string(ex.PrimaryMuscleGroup), query) ||
+				strings.Contains(string(ex.Equipment), query) {
 				m.filtered = append(m.filtered, ex)
 			}
 		}
@@ -224,8 +333,8 @@ func (m *TableModel) updateTableRows() {
 		}
 		rows[i] = table.Row{
 			truncate(ex.Title, 38),
-			ex.PrimaryMuscleGroup,
-			ex.Equipment,
+			string(ex.PrimaryMuscleGroup),
+			string(ex.Equipment),
 			custom,
 		}
 	}
@@ -234,6 +343,10 @@ func (m *TableModel) updateTableRows() {
 
 // View renders the model
 func (m TableModel) View() string {
+	if m.detail != nil {
+		return m.detailView()
+	}
+
 	var b strings.Builder
 
 	// Title
@@ -272,7 +385,7 @@ func (m TableModel) View() string {
 
 	// Help
 	b.WriteString("\n")
-	helpText := "↑/↓ navigate • enter select • / search • esc clear/quit • q quit"
+	helpText := "↑/↓ navigate • enter details • / search • esc clear/quit • q quit"
 	b.WriteString(common.HelpStyle.Render(helpText))
 
 	return b.String()
@@ -286,9 +399,11 @@ func (m TableModel) GetResult() TableResult {
 	}
 }
 
-// RunTable runs the interactive table and returns the result
-func RunTable(client *api.Client) (TableResult, error) {
-	model := NewTableModel(client)
+// RunTable runs the interactive table and returns the result. Enter opens a
+// detail pane with the user's history for the exercise; enter in the pane
+// selects the exercise.
+func RunTable(client *api.Client, opts DetailOptions) (TableResult, error) {
+	model := NewTableModel(client, opts)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
 	finalModel, err := p.Run()
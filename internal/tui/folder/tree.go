@@ -0,0 +1,260 @@
+package folder
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/obay/hevycli/internal/api"
+	"github.com/obay/hevycli/internal/organize"
+	"github.com/obay/hevycli/internal/tui/common"
+)
+
+// row is a line of the tree: a folder, or a routine within a folder.
+// folder is len(Folders) for the unfiled group.
+type row struct {
+	folder  int
+	routine int // -1 for the folder line itself
+}
+
+// TreeModel shows folders with their routines and lets the user reorder
+// folders and move routines between them
+type TreeModel struct {
+	layout   *organize.Layout
+	cursor   int
+	grabbed  bool
+	saved    bool
+	quitting bool
+	height   int
+}
+
+// NewTreeModel creates a tree model for a layout. The layout is changed in
+// place as the user moves things around.
+func NewTreeModel(layout *organize.Layout) TreeModel {
+	return TreeModel{layout: layout}
+}
+
+// rows flattens the layout into lines
+func (m TreeModel) rows() []row {
+	var rows []row
+	for i, f := range m.layout.Folders {
+		rows = append(rows, row{folder: i, routine: -1})
+		for j := range f.Routines {
+			rows = append(rows, row{folder: i, routine: j})
+		}
+	}
+	if len(m.layout.Unfiled) > 0 {
+		unfiled := len(m.layout.Folders)
+		rows = append(rows, row{folder: unfiled, routine: -1})
+		for j := range m.layout.Unfiled {
+			rows = append(rows, row{folder: unfiled, routine: j})
+		}
+	}
+	return rows
+}
+
+// routines returns the routines of a folder index, including the unfiled group
+func (m TreeModel) routines(folder int) []api.Routine {
+	if folder == len(m.layout.Folders) {
+		return m.layout.Unfiled
+	}
+	return m.layout.Folders[folder].Routines
+}
+
+// indexOf returns the line of a folder or routine
+func (m TreeModel) indexOf(target row) int {
+	for i, r := range m.rows() {
+		if r == target {
+			return i
+		}
+	}
+	return 0
+}
+
+// Init initializes the model
+func (m TreeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m TreeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		rows := m.rows()
+		if len(rows) == 0 {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		current := rows[m.cursor]
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "enter", "s":
+			m.saved = true
+			m.quitting = true
+			return m, tea.Quit
+
+		case " ":
+			// Only real folders and routines can be picked up
+			if current.routine >= 0 || current.folder < len(m.layout.Folders) {
+				m.grabbed = !m.grabbed
+			}
+
+		case "up", "k":
+			if m.grabbed {
+				m.cursor = m.indexOf(m.shift(current, -1))
+			} else if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down", "j":
+			if m.grabbed {
+				m.cursor = m.indexOf(m.shift(current, 1))
+			} else if m.cursor < len(rows)-1 {
+				m.cursor++
+			}
+		}
+	}
+	return m, nil
+}
+
+// shift moves a grabbed folder or routine one step and returns its new row.
+// Folders swap places with their neighbours; routines move to the previous
+// or next folder. Nothing can be moved into or within the unfiled group.
+func (m TreeModel) shift(r row, step int) row {
+	folders := m.layout.Folders
+	if r.routine < 0 {
+		to := r.folder + step
+		if to < 0 || to >= len(folders) {
+			return r
+		}
+		folders[r.folder], folders[to] = folders[to], folders[r.folder]
+		return row{folder: to, routine: -1}
+	}
+
+	to := r.folder + step
+	if r.folder == len(folders) {
+		// Unfiled routines can only move up into the last folder
+		to = len(folders) - 1
+		if step > 0 || to < 0 {
+			return r
+		}
+	}
+	if to < 0 || to >= len(folders) {
+		return r
+	}
+
+	var routine api.Routine
+	if r.folder == len(folders) {
+		routine = m.layout.Unfiled[r.routine]
+		m.layout.Unfiled = append(m.layout.Unfiled[:r.routine:r.routine], m.layout.Unfiled[r.routine+1:]...)
+	} else {
+		src := folders[r.folder].Routines
+		routine = src[r.routine]
+		folders[r.folder].Routines = append(src[:r.routine:r.routine], src[r.routine+1:]...)
+	}
+	folders[to].Routines = append(folders[to].Routines, routine)
+	return row{folder: to, routine: len(folders[to].Routines) - 1}
+}
+
+// View renders the model
+func (m TreeModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(common.TitleStyle.Render("Routine Folders"))
+	b.WriteString("\n")
+
+	rows := m.rows()
+	if len(rows) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(common.MutedColor).Render("No folders or routines"))
+		b.WriteString("\n")
+	}
+
+	// Keep the cursor visible on small terminals
+	start, end := 0, len(rows)
+	if visible := m.height - 6; visible > 0 && len(rows) > visible {
+		start = m.cursor - visible/2
+		if start < 0 {
+			start = 0
+		}
+		if start+visible > len(rows) {
+			start = len(rows) - visible
+		}
+		end = start + visible
+	}
+
+	for i := start; i < end; i++ {
+		r := rows[i]
+		var line string
+		if r.routine < 0 {
+			if r.folder == len(m.layout.Folders) {
+				line = fmt.Sprintf("No folder (%d)", len(m.layout.Unfiled))
+			} else {
+				f := m.layout.Folders[r.folder]
+				line = fmt.Sprintf("📁 %s (%d)", f.Folder.Title, len(f.Routines))
+			}
+		} else {
+			line = "    " + m.routines(r.folder)[r.routine].Title
+		}
+
+		switch {
+		case i == m.cursor && m.grabbed:
+			b.WriteString(common.WarningStyle.Render("⇅ " + line))
+		case i == m.cursor:
+			b.WriteString(common.SelectedItemStyle.Render("▸ " + line))
+		case r.routine < 0:
+			b.WriteString(common.FocusedStyle.Render("  " + line))
+		default:
+			b.WriteString(common.NormalItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	if plan := m.layout.Plan(); !plan.Empty() {
+		changes := len(plan.Moves)
+		if plan.Order != nil {
+			changes++
+		}
+		b.WriteString("\n")
+		b.WriteString(common.WarningStyle.Render(fmt.Sprintf("%d unsaved change(s)", changes)))
+		b.WriteString("\n")
+	}
+
+	help := "↑/↓ navigate • space pick up • enter save • esc cancel"
+	if m.grabbed {
+		help = "↑/↓ move • space drop • enter save • esc cancel"
+	}
+	b.WriteString(common.HelpStyle.Render(help))
+	return b.String()
+}
+
+// Saved reports whether the user chose to save the changes
+func (m TreeModel) Saved() bool {
+	return m.saved
+}
+
+// RunTree shows the tree and returns the changes to make, or an empty plan
+// if the user cancelled
+func RunTree(layout *organize.Layout) (organize.Plan, error) {
+	p := tea.NewProgram(NewTreeModel(layout), tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return organize.Plan{}, err
+	}
+	if m, ok := finalModel.(TreeModel); ok && m.Saved() {
+		return layout.Plan(), nil
+	}
+	return organize.Plan{}, nil
+}
@@ -56,7 +56,7 @@ type templateListItem struct {
 }
 
 func (i templateListItem) Title() string       { return i.template.Title }
-func (i templateListItem) Description() string { return i.template.PrimaryMuscleGroup + " • " + i.template.Equipment }
+func (i templateListItem) Description() string { return i.template.Summary() }
 func (i templateListItem) FilterValue() string { return i.template.Title }
 
 // BuilderModel is the routine builder TUI model
@@ -71,6 +71,7 @@ type BuilderModel struct {
 	templateSearch  textinput.Model
 	allTemplates    []api.ExerciseTemplate
 	filteredTempls  []api.ExerciseTemplate
+	filterErr       error
 	setsInput       textinput.Model
 	restInput       textinput.Model
 	notesInput      textinput.Model
@@ -97,7 +98,7 @@ func NewBuilderModel(client *api.Client) BuilderModel {
 
 	// Template search input
 	ts := textinput.New()
-	ts.Placeholder = "Search exercises..."
+	ts.Placeholder = "Search exercises, e.g. press muscle:chest..."
 	ts.CharLimit = 50
 	ts.Width = 40
 	ts.PromptStyle = common.FocusedStyle
@@ -460,18 +461,26 @@ func (m BuilderModel) handleEnter() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// filterTemplates filters templates based on search input
+// filterTemplates filters templates based on search input. Words match the
+// title or primary muscle group; "muscle:", "equipment:" and "type:" terms
+// filter by exact value. An unknown value keeps the previous results and
+// shows the error.
 func (m *BuilderModel) filterTemplates() {
-	query := strings.ToLower(m.templateSearch.Value())
-	if query == "" {
-		m.filteredTempls = m.allTemplates
-	} else {
-		m.filteredTempls = nil
-		for _, t := range m.allTemplates {
-			if strings.Contains(strings.ToLower(t.Title), query) ||
-				strings.Contains(strings.ToLower(t.PrimaryMuscleGroup), query) {
-				m.filteredTempls = append(m.filteredTempls, t)
-			}
+	filter, text, err := api.ParseTemplateFilter(m.templateSearch.Value())
+	m.filterErr = err
+	if err != nil {
+		return
+	}
+	query := strings.ToLower(text)
+	m.filteredTempls = nil
+	for _, t := range m.allTemplates {
+		if !filter.Match(t) {
+			continue
+		}
+		if query == "" ||
+			strings.Contains(strings.ToLower(t.Title), query) ||
+			strings.Contains(strings.ToLower(t.PrimaryMuscleGroup.DisplayName()), query) {
+			m.filteredTempls = append(m.filteredTempls, t)
 		}
 	}
 	m.updateTemplateList()
@@ -585,8 +594,12 @@ func (m BuilderModel) renderAddExerciseMode() string {
 	b.WriteString(m.templateSearch.View())
 	b.WriteString("\n\n")
 
-	b.WriteString(lipgloss.NewStyle().Foreground(common.MutedColor).Render(
-		fmt.Sprintf("%d exercises found", len(m.filteredTempls))))
+	if m.filterErr != nil {
+		b.WriteString(common.ErrorStyle.Render(m.filterErr.Error()))
+	} else {
+		b.WriteString(lipgloss.NewStyle().Foreground(common.MutedColor).Render(
+			fmt.Sprintf("%d exercises found", len(m.filteredTempls))))
+	}
 	b.WriteString("\n\n")
 
 	b.WriteString(m.templateList.View())